@@ -4,9 +4,14 @@
 package rtcp
 
 import (
+	"bytes"
 	"encoding/binary"
 )
 
+// appDefinedMaxDataLength is the largest Data an ApplicationDefined packet
+// can carry.
+const appDefinedMaxDataLength = 0xFFFF - 12
+
 // ApplicationDefined represents an RTCP application-defined packet.
 type ApplicationDefined struct {
 	SubType uint8
@@ -15,19 +20,144 @@ type ApplicationDefined struct {
 	Data    []byte
 }
 
+// NewApplicationDefined creates an ApplicationDefined packet, checking that
+// name is exactly 4 ASCII characters and that data fits in a packet. Data is
+// padded to a 32-bit boundary when the packet is marshaled.
+func NewApplicationDefined(ssrc uint32, name string, data []byte) (*ApplicationDefined, error) {
+	if !isAppDefinedName(name) {
+		return nil, errAppDefinedInvalidName
+	}
+	if len(data) > appDefinedMaxDataLength {
+		return nil, errAppDefinedDataTooLarge
+	}
+
+	return &ApplicationDefined{
+		SSRC: ssrc,
+		Name: name,
+		Data: data,
+	}, nil
+}
+
+// isAppDefinedName reports whether name is a valid APP packet name, which
+// RFC 3550 defines as four ASCII characters.
+func isAppDefinedName(name string) bool {
+	if len(name) != 4 {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if name[i] > 0x7f {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Validate checks that the ApplicationDefined can be sent: that its name is 4
+// ASCII characters, its subtype fits in 5 bits and its data in a packet.
+func (a *ApplicationDefined) Validate() error {
+	if !isAppDefinedName(a.Name) {
+		return errAppDefinedInvalidName
+	}
+	if a.SubType > countMax {
+		return ErrInvalidHeader
+	}
+	if len(a.Data) > appDefinedMaxDataLength {
+		return errAppDefinedDataTooLarge
+	}
+
+	return nil
+}
+
+// Type returns the packet type, TypeApplicationDefined.
+func (a *ApplicationDefined) Type() PacketType {
+	return TypeApplicationDefined
+}
+
 // DestinationSSRC returns the SSRC value for this packet.
 func (a ApplicationDefined) DestinationSSRC() []uint32 {
 	return []uint32{a.SSRC}
 }
 
+// SourceSSRC returns the SSRC of the sender.
+func (a ApplicationDefined) SourceSSRC() []uint32 {
+	return []uint32{a.SSRC}
+}
+
+// RewriteSSRC replaces every SSRC in the packet that equals from with to,
+// and returns the number of SSRCs replaced.
+func (a *ApplicationDefined) RewriteSSRC(from, to uint32) int {
+	return rewriteSSRC(&a.SSRC, from, to)
+}
+
+// Equal reports whether other is an ApplicationDefined packet with the same contents.
+func (a ApplicationDefined) Equal(other Packet) bool {
+	o, ok := other.(*ApplicationDefined)
+	if !ok || o == nil {
+		return false
+	}
+
+	return a.SubType == o.SubType &&
+		a.SSRC == o.SSRC &&
+		a.Name == o.Name &&
+		bytes.Equal(a.Data, o.Data)
+}
+
+// Clone returns a deep copy of the packet that shares no memory with a.
+func (a ApplicationDefined) Clone() Packet {
+	a.Data = cloneSlice(a.Data)
+
+	return &a
+}
+
+// MarshalJSON encodes the packet as a JSON object tagged with its type.
+func (a ApplicationDefined) MarshalJSON() ([]byte, error) {
+	return marshalJSON(a)
+}
+
+// UnmarshalJSON decodes a JSON object produced by MarshalJSON.
+func (a *ApplicationDefined) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, a)
+}
+
+// Header returns the Header associated with this packet. Its count field
+// holds SubType, and its padding bit is set when Data is not a multiple of 4
+// bytes long.
+func (a *ApplicationDefined) Header() Header {
+	h := Header{
+		Padding: len(a.Data)%4 != 0,
+		Count:   a.SubType,
+		Type:    TypeApplicationDefined,
+	}
+	_ = h.SetLengthFromBytes(a.MarshalSize())
+
+	return h
+}
+
 // Marshal serializes the application-defined struct into a byte slice with padding.
 func (a ApplicationDefined) Marshal() ([]byte, error) {
+	rawPacket := make([]byte, a.MarshalSize())
+	if _, err := a.MarshalTo(rawPacket); err != nil {
+		return nil, err
+	}
+
+	return rawPacket, nil
+}
+
+// MarshalAppend appends the packet in binary to dst and returns the
+// extended slice.
+func (a ApplicationDefined) MarshalAppend(dst []byte) ([]byte, error) {
+	return marshalAppend(dst, a.MarshalSize(), a.MarshalTo)
+}
+
+// MarshalTo serializes the application-defined struct into buf and returns the number of bytes written.
+func (a ApplicationDefined) MarshalTo(buf []byte) (int, error) {
 	dataLength := len(a.Data)
-	if dataLength > 0xFFFF-12 {
-		return nil, errAppDefinedDataTooLarge
+	if dataLength > appDefinedMaxDataLength {
+		return 0, errAppDefinedDataTooLarge
 	}
-	if len(a.Name) != 4 {
-		return nil, errAppDefinedInvalidName
+	if !isAppDefinedName(a.Name) {
+		return 0, errAppDefinedInvalidName
 	}
 	// Calculate the padding size to be added to make the packet length a multiple of 4 bytes.
 	paddingSize := 4 - (dataLength % 4)
@@ -36,36 +166,47 @@ func (a ApplicationDefined) Marshal() ([]byte, error) {
 	}
 
 	packetSize := a.MarshalSize()
-	header := Header{
-		Type:    TypeApplicationDefined,
-		Length:  uint16((packetSize / 4) - 1), //nolint:gosec // G115
-		Padding: paddingSize != 0,
-		Count:   a.SubType,
+	if len(buf) < packetSize {
+		return 0, ErrPacketTooShort
 	}
 
-	headerBytes, err := header.Marshal()
-	if err != nil {
-		return nil, err
+	header := a.Header()
+	if err := header.SetLengthFromBytes(packetSize); err != nil {
+		return 0, err
+	}
+
+	if _, err := header.MarshalTo(buf); err != nil {
+		return 0, err
 	}
 
-	rawPacket := make([]byte, packetSize)
-	copy(rawPacket, headerBytes)
-	binary.BigEndian.PutUint32(rawPacket[4:8], a.SSRC)
-	copy(rawPacket[8:12], a.Name)
-	copy(rawPacket[12:], a.Data)
+	binary.BigEndian.PutUint32(buf[4:8], a.SSRC)
+	copy(buf[8:12], a.Name)
+	copy(buf[12:], a.Data)
 
 	// Add padding if necessary.
 	if paddingSize > 0 {
 		for i := 0; i < paddingSize; i++ {
-			rawPacket[12+dataLength+i] = byte(paddingSize)
+			buf[12+dataLength+i] = byte(paddingSize)
 		}
 	}
 
-	return rawPacket, nil
+	return packetSize, nil
 }
 
-// Unmarshal parses the given raw packet into an application-defined struct, handling padding.
+// Unmarshal decodes the ApplicationDefined from binary. The data is copied so
+// the caller may reuse rawPacket once Unmarshal returns.
 func (a *ApplicationDefined) Unmarshal(rawPacket []byte) error {
+	if err := a.unmarshalNoCopy(rawPacket); err != nil {
+		return err
+	}
+	a.Data = cloneSlice(a.Data)
+
+	return nil
+}
+
+// unmarshalNoCopy implements Unmarshal, leaving a.Data pointing into
+// rawPacket.
+func (a *ApplicationDefined) unmarshalNoCopy(rawPacket []byte) error {
 	/*
 	    0                   1                   2                   3
 	    0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
@@ -84,11 +225,14 @@ func (a *ApplicationDefined) Unmarshal(rawPacket []byte) error {
 	if err != nil {
 		return err
 	}
+	if header.Type != TypeApplicationDefined {
+		return ErrWrongType
+	}
 	if len(rawPacket) < 12 {
-		return errPacketTooShort
+		return ErrPacketTooShort
 	}
 
-	if int(header.Length+1)*4 != len(rawPacket) {
+	if (int(header.Length)+1)*4 != len(rawPacket) {
 		return errAppDefinedInvalidLength
 	}
 
@@ -101,7 +245,7 @@ func (a *ApplicationDefined) Unmarshal(rawPacket []byte) error {
 	if header.Padding {
 		paddingSize = int(rawPacket[len(rawPacket)-1])
 		if paddingSize > len(rawPacket)-12 {
-			return errWrongPadding
+			return ErrWrongPadding
 		}
 	}
 
@@ -99,7 +99,7 @@ func TestTApplicationPacketUnmarshal(t *testing.T) {
 				// name='SUI'
 				0x53, 0x55, 0x49,
 			},
-			WantError: errPacketTooShort,
+			WantError: ErrPacketTooShort,
 		},
 		{
 			Name: "wrongPaddingSize",
@@ -115,7 +115,7 @@ func TestTApplicationPacketUnmarshal(t *testing.T) {
 				// 3 bytes padding as packet length must be a division of 4
 				0x03, 0x03, 0x09, // last byte has padding size 0x09 which is more than the data + padding bytes
 			},
-			WantError: errWrongPadding,
+			WantError: ErrWrongPadding,
 		},
 		{
 			Name: "invalidHeader",
@@ -123,7 +123,21 @@ func TestTApplicationPacketUnmarshal(t *testing.T) {
 				// Application Packet Type + invalid Length(0x00FF)
 				0xFF,
 			},
-			WantError: errPacketTooShort,
+			WantError: ErrPacketTooShort,
+		},
+		{
+			Name: "wrongType",
+			Data: []byte{
+				// Receiver Report Type + Length(0x0003)
+				0x80, 0xc9, 0x00, 0x03,
+				// sender=0x4baae1ab
+				0x4b, 0xaa, 0xe1, 0xab,
+				// name='NAME'
+				0x4E, 0x41, 0x4D, 0x45,
+				// data='ABCD'
+				0x41, 0x42, 0x43, 0x44,
+			},
+			WantError: ErrWrongType,
 		},
 	} {
 		var apk ApplicationDefined
@@ -223,9 +237,18 @@ func TestTApplicationPacketMarshal(t *testing.T) {
 				Data: []byte{0x41, 0x42, 0x43, 0x44},
 			},
 		},
+		{
+			Name:      "invalidNonASCIIName",
+			WantError: errAppDefinedInvalidName,
+			Packet: ApplicationDefined{
+				SSRC: 0x4baae1ab,
+				Name: "NÄM",
+				Data: []byte{0x41, 0x42, 0x43, 0x44},
+			},
+		},
 		{
 			Name:      "InvalidSubType",
-			WantError: errInvalidHeader,
+			WantError: ErrInvalidHeader,
 			Packet: ApplicationDefined{
 				SubType: 32, // Must be up to 31
 				SSRC:    0x4baae1ab,
@@ -246,3 +269,60 @@ func TestTApplicationPacketMarshal(t *testing.T) {
 		assert.Equalf(t, marshalSize, len(rawPacket), "MarshalSize %q", test.Name)
 	}
 }
+
+func TestNewApplicationDefined(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		AppName   string
+		Data      []byte
+		WantError error
+	}{
+		{Name: "valid", AppName: "NAME", Data: []byte("ABCDE")},
+		{Name: "no data", AppName: "NAME"},
+		{Name: "short name", AppName: "NAM", WantError: errAppDefinedInvalidName},
+		{Name: "long name", AppName: "NAMES", WantError: errAppDefinedInvalidName},
+		{Name: "non-ASCII name", AppName: "NÄM", WantError: errAppDefinedInvalidName},
+		{
+			Name:      "data too large",
+			AppName:   "NAME",
+			Data:      make([]byte, appDefinedMaxDataLength+1),
+			WantError: errAppDefinedDataTooLarge,
+		},
+	} {
+		app, err := NewApplicationDefined(0x4baae1ab, test.AppName, test.Data)
+		assert.ErrorIsf(t, err, test.WantError, "NewApplicationDefined %q", test.Name)
+		if err != nil {
+			continue
+		}
+
+		rawPacket, err := app.Marshal()
+		assert.NoErrorf(t, err, "Marshal %q", test.Name)
+		assert.Zerof(t, len(rawPacket)%4, "Marshal %q is not 32-bit aligned", test.Name)
+
+		var decoded ApplicationDefined
+		assert.NoErrorf(t, decoded.Unmarshal(rawPacket), "Unmarshal %q", test.Name)
+		assert.Truef(t, app.Equal(&decoded), "%q round trip mismatch", test.Name)
+	}
+}
+
+func TestApplicationDefinedSubType(t *testing.T) {
+	app := ApplicationDefined{
+		SubType: 7,
+		SSRC:    0x4baae1ab,
+		Name:    "NAME",
+		Data:    []byte{0x41, 0x42, 0x43, 0x44},
+	}
+	assert.Equal(t, uint8(7), app.Header().Count)
+
+	rawPacket, err := app.Marshal()
+	assert.NoError(t, err)
+	// V=2, P=0, subtype=7
+	assert.Equal(t, byte(0x87), rawPacket[0])
+	assert.Equal(t, byte(TypeApplicationDefined), rawPacket[1])
+
+	var decoded ApplicationDefined
+	assert.NoError(t, decoded.Unmarshal(rawPacket))
+	assert.Equal(t, uint8(7), decoded.SubType)
+	assert.Equal(t, app.Header(), decoded.Header())
+	assert.True(t, app.Equal(&decoded))
+}
@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import "fmt"
+
+// The ApplicationLayerFeedback packet carries feedback for an application
+// running on top of RTP, whose contents are not defined by RTCP. It is
+// returned for application layer feedback that is not a REMB message, with
+// the FCI left undecoded. See RFC 4585 Section 6.4.
+type ApplicationLayerFeedback struct {
+	// SSRC of sender
+	SenderSSRC uint32
+
+	// SSRC of the media source
+	MediaSSRC uint32
+
+	// Feedback Control Information, whose contents are up to the
+	// application. Its length must be a multiple of 4.
+	FCI []byte
+
+	packetPadding
+}
+
+// generic returns the packet as the GenericFeedback its framing is shared
+// with.
+func (p *ApplicationLayerFeedback) generic() *GenericFeedback {
+	return &GenericFeedback{
+		PacketType:    TypePayloadSpecificFeedback,
+		MessageType:   FormatAFB,
+		SenderSSRC:    p.SenderSSRC,
+		MediaSSRC:     p.MediaSSRC,
+		FCI:           p.FCI,
+		packetPadding: p.packetPadding,
+	}
+}
+
+// Marshal encodes the ApplicationLayerFeedback in binary.
+func (p ApplicationLayerFeedback) Marshal() ([]byte, error) {
+	return p.generic().Marshal()
+}
+
+// MarshalAppend appends the packet in binary to dst and returns the
+// extended slice.
+func (p ApplicationLayerFeedback) MarshalAppend(dst []byte) ([]byte, error) {
+	return p.generic().MarshalAppend(dst)
+}
+
+// MarshalTo encodes the ApplicationLayerFeedback in binary into buf and returns the number of bytes written.
+func (p ApplicationLayerFeedback) MarshalTo(buf []byte) (int, error) {
+	/*
+	 *  0                   1                   2                   3
+	 *  0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * |V=2|P| FMT=15  |   PT=206      |             length            |
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * |                  SSRC of packet sender                        |
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * |                  SSRC of media source                         |
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * :            Feedback Control Information (FCI)                 :
+	 * :                                                               :
+	 */
+	return p.generic().MarshalTo(buf)
+}
+
+// Unmarshal decodes the ApplicationLayerFeedback from binary. The FCI is
+// copied so the caller may reuse rawPacket once Unmarshal returns.
+func (p *ApplicationLayerFeedback) Unmarshal(rawPacket []byte) error {
+	if err := p.unmarshalNoCopy(rawPacket); err != nil {
+		return err
+	}
+	p.FCI = cloneSlice(p.FCI)
+
+	return nil
+}
+
+// unmarshalNoCopy implements Unmarshal, leaving p.FCI pointing into
+// rawPacket.
+func (p *ApplicationLayerFeedback) unmarshalNoCopy(rawPacket []byte) error {
+	var g GenericFeedback
+	if err := g.unmarshalNoCopy(rawPacket); err != nil {
+		return err
+	}
+
+	if g.PacketType != TypePayloadSpecificFeedback || g.MessageType != FormatAFB {
+		return ErrWrongType
+	}
+
+	p.SenderSSRC = g.SenderSSRC
+	p.MediaSSRC = g.MediaSSRC
+	p.FCI = g.FCI
+	p.packetPadding = g.packetPadding
+
+	return nil
+}
+
+// Header returns the Header associated with this packet.
+func (p *ApplicationLayerFeedback) Header() Header {
+	return p.generic().Header()
+}
+
+// MarshalSize returns the size of the packet once marshaled.
+func (p *ApplicationLayerFeedback) MarshalSize() int {
+	return p.generic().MarshalSize()
+}
+
+func (p *ApplicationLayerFeedback) String() string {
+	return fmt.Sprintf("ApplicationLayerFeedback %x %x %x", p.SenderSSRC, p.MediaSSRC, p.FCI)
+}
+
+// Validate checks that the ApplicationLayerFeedback can be sent: that its FCI
+// is a multiple of 4 bytes long.
+func (p *ApplicationLayerFeedback) Validate() error {
+	return p.generic().Validate()
+}
+
+// Type returns the packet type, TypePayloadSpecificFeedback.
+func (p *ApplicationLayerFeedback) Type() PacketType {
+	return TypePayloadSpecificFeedback
+}
+
+// Format returns the feedback message type, FormatAFB.
+func (p *ApplicationLayerFeedback) Format() uint8 {
+	return FormatAFB
+}
+
+// DestinationSSRC returns an array of SSRC values that this packet refers to.
+func (p *ApplicationLayerFeedback) DestinationSSRC() []uint32 {
+	return []uint32{p.MediaSSRC}
+}
+
+// TargetsSSRC reports whether the packet is feedback about the media source
+// ssrc, its MediaSSRC.
+func (p *ApplicationLayerFeedback) TargetsSSRC(ssrc uint32) bool {
+	return p.MediaSSRC == ssrc
+}
+
+// SourceSSRC returns the SSRC of the sender.
+func (p *ApplicationLayerFeedback) SourceSSRC() []uint32 {
+	return []uint32{p.SenderSSRC}
+}
+
+// RewriteSSRC replaces every SSRC in the packet that equals from with to,
+// and returns the number of SSRCs replaced.
+func (p *ApplicationLayerFeedback) RewriteSSRC(from, to uint32) int {
+	return rewriteSSRC(&p.SenderSSRC, from, to) + rewriteSSRC(&p.MediaSSRC, from, to)
+}
+
+// Equal reports whether other is an ApplicationLayerFeedback with the same contents.
+func (p *ApplicationLayerFeedback) Equal(other Packet) bool {
+	o, ok := other.(*ApplicationLayerFeedback)
+	if !ok || o == nil {
+		return false
+	}
+
+	return p.generic().Equal(o.generic())
+}
+
+// Clone returns a deep copy of the packet that shares no memory with p.
+func (p *ApplicationLayerFeedback) Clone() Packet {
+	c := *p
+	c.FCI = cloneSlice(p.FCI)
+
+	return &c
+}
+
+// MarshalJSON encodes the packet as a JSON object tagged with its type.
+func (p ApplicationLayerFeedback) MarshalJSON() ([]byte, error) {
+	return marshalJSON(p)
+}
+
+// UnmarshalJSON decodes a JSON object produced by MarshalJSON.
+func (p *ApplicationLayerFeedback) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, p)
+}
@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Packet = (*ApplicationLayerFeedback)(nil) // assert is a Packet
+
+func TestApplicationLayerFeedbackUnmarshal(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Data      []byte
+		Want      ApplicationLayerFeedback
+		WantError error
+	}{
+		{
+			Name: "valid",
+			Data: []byte{
+				// v=2, p=0, FMT=15, PSFB, len=4
+				0x8f, 0xce, 0x00, 0x04,
+				// sender=0x902f9e2e
+				0x90, 0x2f, 0x9e, 0x2e,
+				// media=0x4bc4fcb4
+				0x4b, 0xc4, 0xfc, 0xb4,
+				// FCI
+				'G', 'O', 'O', 'G',
+				0x01, 0x02, 0x03, 0x04,
+			},
+			Want: ApplicationLayerFeedback{
+				SenderSSRC: 0x902f9e2e,
+				MediaSSRC:  0x4bc4fcb4,
+				FCI:        []byte{'G', 'O', 'O', 'G', 0x01, 0x02, 0x03, 0x04},
+			},
+		},
+		{
+			Name: "no FCI",
+			Data: []byte{
+				// v=2, p=0, FMT=15, PSFB, len=2
+				0x8f, 0xce, 0x00, 0x02,
+				// sender=0x902f9e2e
+				0x90, 0x2f, 0x9e, 0x2e,
+				// media=0x4bc4fcb4
+				0x4b, 0xc4, 0xfc, 0xb4,
+			},
+			Want: ApplicationLayerFeedback{
+				SenderSSRC: 0x902f9e2e,
+				MediaSSRC:  0x4bc4fcb4,
+				FCI:        []byte{},
+			},
+		},
+		{
+			Name: "packet too short",
+			Data: []byte{
+				0x8f, 0xce, 0x00, 0x02,
+				0x90, 0x2f, 0x9e, 0x2e,
+			},
+			WantError: ErrPacketTooShort,
+		},
+		{
+			Name: "length beyond data",
+			Data: []byte{
+				// v=2, p=0, FMT=15, PSFB, len=3
+				0x8f, 0xce, 0x00, 0x03,
+				0x90, 0x2f, 0x9e, 0x2e,
+				0x4b, 0xc4, 0xfc, 0xb4,
+			},
+			WantError: ErrPacketTooShort,
+		},
+		{
+			Name: "length too small",
+			Data: []byte{
+				// v=2, p=0, FMT=15, PSFB, len=0
+				0x8f, 0xce, 0x00, 0x00,
+				0x90, 0x2f, 0x9e, 0x2e,
+				0x4b, 0xc4, 0xfc, 0xb4,
+			},
+			WantError: ErrPacketTooShort,
+		},
+		{
+			Name: "wrong fmt",
+			Data: []byte{
+				// v=2, p=0, FMT=1, PSFB, len=2
+				0x81, 0xce, 0x00, 0x02,
+				0x90, 0x2f, 0x9e, 0x2e,
+				0x4b, 0xc4, 0xfc, 0xb4,
+			},
+			WantError: ErrWrongType,
+		},
+	} {
+		var afb ApplicationLayerFeedback
+		err := afb.Unmarshal(test.Data)
+		assert.ErrorIsf(t, err, test.WantError, "Unmarshal %q", test.Name)
+		if err != nil {
+			continue
+		}
+
+		assert.Equalf(t, test.Want, afb, "Unmarshal %q", test.Name)
+	}
+}
+
+func TestApplicationLayerFeedbackRoundTrip(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Packet    ApplicationLayerFeedback
+		WantError error
+	}{
+		{
+			Name: "valid",
+			Packet: ApplicationLayerFeedback{
+				SenderSSRC: 1,
+				MediaSSRC:  2,
+				FCI:        []byte("ABCD1234"),
+			},
+		},
+		{
+			Name: "unaligned FCI",
+			Packet: ApplicationLayerFeedback{
+				SenderSSRC: 1,
+				FCI:        []byte("ABC"),
+			},
+			WantError: ErrBadLength,
+		},
+	} {
+		data, err := test.Packet.Marshal()
+		assert.ErrorIsf(t, err, test.WantError, "Marshal %q", test.Name)
+		if err != nil {
+			continue
+		}
+
+		var decoded ApplicationLayerFeedback
+		assert.NoErrorf(t, decoded.Unmarshal(data), "Unmarshal %q", test.Name)
+		assert.Equalf(t, test.Packet, decoded, "%q afb round trip mismatch", test.Name)
+	}
+}
+
+func TestApplicationLayerFeedbackDispatch(t *testing.T) {
+	remb := ReceiverEstimatedMaximumBitrate{SenderSSRC: 1, Bitrate: 8927168, SSRCs: []uint32{2}}
+	data, err := remb.Marshal()
+	assert.NoError(t, err)
+
+	// Unmarshal tells REMB apart from other AFB by its identifier.
+	packets, err := Unmarshal(data)
+	assert.NoError(t, err)
+	assert.IsType(t, &ReceiverEstimatedMaximumBitrate{}, packets[0])
+
+	data[12] = 'X'
+	packets, err = Unmarshal(data)
+	assert.NoError(t, err)
+	afb, ok := packets[0].(*ApplicationLayerFeedback)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(1), afb.SenderSSRC)
+	assert.Equal(t, data[12:], afb.FCI)
+}
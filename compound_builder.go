@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import "fmt"
+
+// A CompoundBuilder accumulates the packets of a compound packet while
+// keeping track of its marshaled size, so that a sender can fill a datagram
+// up to its MTU, e.g.
+//
+//	var b rtcp.CompoundBuilder
+//	_ = b.Add(rr, mtu)
+//	_ = b.Add(sdes, mtu)
+//	for _, nack := range nacks {
+//		if err := b.Add(nack, mtu); err != nil {
+//			break // the rest go in the next compound packet
+//		}
+//	}
+//	data, err := b.Marshal()
+//
+// The budget is passed on each call, so it may change between packets, such
+// as when space is kept for the SRTCP trailer. Packets must not be modified
+// once added, as their size is only computed by Add. The zero value is an
+// empty builder ready to use.
+type CompoundBuilder struct {
+	packets []Packet
+	size    int
+}
+
+// Add appends p if the compound packet would then be at most mtu bytes
+// long, and otherwise fails with ErrBudgetExceeded, leaving the builder
+// unchanged.
+func (b *CompoundBuilder) Add(p Packet, mtu int) error {
+	size := p.MarshalSize()
+	if b.size+size > mtu {
+		return fmt.Errorf("%w: %T of %d bytes with %d of %d bytes used", ErrBudgetExceeded, p, size, b.size, mtu)
+	}
+
+	b.packets = append(b.packets, p)
+	b.size += size
+
+	return nil
+}
+
+// Fits reports whether Add would accept p.
+func (b *CompoundBuilder) Fits(p Packet, mtu int) bool {
+	return b.size+p.MarshalSize() <= mtu
+}
+
+// RemainingBytes returns the number of bytes left before the compound packet
+// is mtu bytes long, or a negative number if it is already longer.
+func (b *CompoundBuilder) RemainingBytes(mtu int) int {
+	return mtu - b.size
+}
+
+// Size returns the marshaled size of the packets added so far.
+func (b *CompoundBuilder) Size() int {
+	return b.size
+}
+
+// Len returns the number of packets added so far.
+func (b *CompoundBuilder) Len() int {
+	return len(b.packets)
+}
+
+// Packets returns the packets added so far, in order. The slice is shared
+// with the builder until Reset is called.
+func (b *CompoundBuilder) Packets() CompoundPacket {
+	return b.packets[:len(b.packets):len(b.packets)]
+}
+
+// Marshal encodes the packets added so far in binary, as one datagram of
+// Size bytes.
+func (b *CompoundBuilder) Marshal() ([]byte, error) {
+	return MarshalAppend(make([]byte, 0, b.size), b.packets)
+}
+
+// Reset removes all packets, for the builder to be reused for the next
+// compound packet.
+func (b *CompoundBuilder) Reset() {
+	b.packets = nil
+	b.size = 0
+}
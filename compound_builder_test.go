@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompoundBuilder(t *testing.T) {
+	const mtu = 48
+
+	rr := &ReceiverReport{SSRC: 1, Reports: []ReceptionReport{{SSRC: 2}}} // 32 bytes
+	pli := &PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2}            // 12 bytes
+	nack := &TransportLayerNack{SenderSSRC: 1, MediaSSRC: 2, Nacks: []NackPair{{PacketID: 3}}}
+
+	var b CompoundBuilder
+	assert.Equal(t, mtu, b.RemainingBytes(mtu))
+
+	assert.NoError(t, b.Add(rr, mtu))
+	assert.Equal(t, 32, b.Size())
+	assert.Equal(t, 16, b.RemainingBytes(mtu))
+
+	assert.True(t, b.Fits(pli, mtu))
+	assert.NoError(t, b.Add(pli, mtu))
+	assert.Equal(t, 4, b.RemainingBytes(mtu))
+
+	// The 16 byte NACK does not fit, and is not added.
+	assert.False(t, b.Fits(nack, mtu))
+	assert.ErrorIs(t, b.Add(nack, mtu), ErrBudgetExceeded)
+	assert.Equal(t, 2, b.Len())
+	assert.Equal(t, 44, b.Size())
+
+	// A larger budget, such as without the SRTCP trailer, has room for it.
+	assert.NoError(t, b.Add(nack, mtu+16))
+	assert.Equal(t, -12, b.RemainingBytes(mtu))
+
+	assert.Equal(t, CompoundPacket{rr, pli, nack}, b.Packets())
+	data, err := b.Marshal()
+	assert.NoError(t, err)
+	assert.Len(t, data, b.Size())
+	want, err := Marshal([]Packet{rr, pli, nack})
+	assert.NoError(t, err)
+	assert.Equal(t, want, data)
+
+	b.Reset()
+	assert.Equal(t, 0, b.Len())
+	assert.Equal(t, 0, b.Size())
+	assert.Empty(t, b.Packets())
+	data, err = b.Marshal()
+	assert.NoError(t, err)
+	assert.Empty(t, data)
+}
@@ -4,7 +4,9 @@
 package rtcp
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"strings"
 )
 
@@ -23,13 +25,26 @@ import (
 type CompoundPacket []Packet
 
 // Validate returns an error if this is not an RFC-compliant CompoundPacket.
-//
-//nolint:cyclop
+// It checks that the first packet is a SenderReport or ReceiverReport,
+// that a SourceDescription with a CNAME follows before any other packet,
+// that no SourceDescription follows a Goodbye, and that only the last
+// packet uses padding. The returned error names the rule that failed.
 func (c CompoundPacket) Validate() error {
 	if len(c) == 0 {
 		return errEmptyCompound
 	}
 
+	if err := c.validateCNAME(); err != nil {
+		return err
+	}
+
+	return c.validateOrder()
+}
+
+// validateCNAME checks the leading report and the mandatory CNAME.
+//
+//nolint:cyclop
+func (c CompoundPacket) validateCNAME() error {
 	// SenderReport and ReceiverReport are the only types that
 	// are allowed to be the first packet in a compound datagram
 	switch c[0].(type) {
@@ -74,6 +89,39 @@ func (c CompoundPacket) Validate() error {
 	return errMissingCNAME
 }
 
+// validateOrder checks the placement rules that apply to the whole compound.
+func (c CompoundPacket) validateOrder() error {
+	var seenGoodbye bool
+	for i, pkt := range c {
+		switch pkt.(type) {
+		case *Goodbye:
+			seenGoodbye = true
+		case *SourceDescription:
+			if seenGoodbye {
+				return errSDESAfterGoodbye
+			}
+		}
+
+		if i != len(c)-1 && packetHasPadding(pkt) {
+			return ErrPaddingNotLast
+		}
+	}
+
+	return nil
+}
+
+// packetHasPadding reports whether pkt sets the padding bit in its header.
+func packetHasPadding(pkt Packet) bool {
+	switch p := pkt.(type) {
+	case *TransportLayerCC:
+		return p.Header.Padding
+	case interface{ Header() Header }:
+		return p.Header().Padding
+	}
+
+	return false
+}
+
 // CNAME returns the CNAME that *must* be present in every CompoundPacket.
 func (c CompoundPacket) CNAME() (string, error) {
 	var err error
@@ -124,23 +172,87 @@ func (c CompoundPacket) MarshalSize() int {
 	return l
 }
 
+// MarshalAppend appends the packet in binary to dst and returns the
+// extended slice.
+func (c CompoundPacket) MarshalAppend(dst []byte) ([]byte, error) {
+	return marshalAppend(dst, c.MarshalSize(), c.MarshalTo)
+}
+
+// MarshalTo encodes the CompoundPacket as binary into buf and returns the number of bytes written.
+func (c CompoundPacket) MarshalTo(buf []byte) (int, error) {
+	if err := c.Validate(); err != nil {
+		return 0, err
+	}
+
+	return marshalPacketsTo(buf, c)
+}
+
 // Unmarshal decodes a CompoundPacket from binary.
 func (c *CompoundPacket) Unmarshal(rawData []byte) error {
-	out := make(CompoundPacket, 0)
-	for len(rawData) != 0 {
-		p, processed, err := unmarshal(rawData)
-		if err != nil {
-			return err
-		}
+	return c.UnmarshalWithOptions(rawData, UnmarshalOptions{})
+}
 
-		out = append(out, p)
-		rawData = rawData[processed:]
+// UnmarshalWithOptions is like Unmarshal, but parses the packets as
+// UnmarshalWithOptions does with opts, such as with a different MaxPackets.
+// c is left unchanged on any error, including a truncated packet with
+// opts.Partial.
+func (c *CompoundPacket) UnmarshalWithOptions(rawData []byte, opts UnmarshalOptions) error {
+	if err := c.unmarshalPackets(rawData, opts); err != nil {
+		return err
 	}
-	*c = out
 
 	return c.Validate()
 }
 
+// UnmarshalReducedSize decodes a CompoundPacket from binary, also accepting
+// reduced-size RTCP as described in RFC 5506. Such datagrams need not start
+// with a SenderReport or ReceiverReport, nor carry a CNAME. A datagram that
+// does start with a report is still validated as a regular CompoundPacket.
+func (c *CompoundPacket) UnmarshalReducedSize(rawData []byte) error {
+	if err := c.unmarshalPackets(rawData, UnmarshalOptions{}); err != nil {
+		return err
+	}
+
+	if len(*c) == 0 {
+		return errEmptyCompound
+	}
+
+	switch (*c)[0].(type) {
+	case *SenderReport, *ReceiverReport:
+		return c.Validate()
+	default:
+		return nil
+	}
+}
+
+// unmarshalPackets sets c to the packets in rawData, or to an empty
+// CompoundPacket if there are none, for Validate to reject.
+func (c *CompoundPacket) unmarshalPackets(rawData []byte, opts UnmarshalOptions) error {
+	if len(rawData) == 0 {
+		*c = CompoundPacket{}
+
+		return nil
+	}
+
+	packets, err := UnmarshalWithOptions(rawData, opts)
+	if err != nil {
+		return err
+	}
+	*c = packets
+
+	return nil
+}
+
+// Type returns the type of the CompoundPacket's leading report, or 0 if it
+// is empty.
+func (c CompoundPacket) Type() PacketType {
+	if len(c) == 0 {
+		return 0
+	}
+
+	return c[0].Type()
+}
+
 // DestinationSSRC returns the synchronization sources associated with this
 // CompoundPacket's reception report.
 func (c CompoundPacket) DestinationSSRC() []uint32 {
@@ -151,6 +263,82 @@ func (c CompoundPacket) DestinationSSRC() []uint32 {
 	return c[0].DestinationSSRC()
 }
 
+// SourceSSRC returns the SSRC of the sender of the CompoundPacket's leading
+// report.
+func (c CompoundPacket) SourceSSRC() []uint32 {
+	if len(c) == 0 {
+		return nil
+	}
+
+	return c[0].SourceSSRC()
+}
+
+// RewriteSSRC replaces every SSRC that equals from with to in each of the
+// CompoundPacket's packets, and returns the number of SSRCs replaced.
+func (c CompoundPacket) RewriteSSRC(from, to uint32) int {
+	return RewriteSSRC(c, from, to)
+}
+
+// Equal reports whether other is a CompoundPacket holding equal packets.
+func (c CompoundPacket) Equal(other Packet) bool {
+	o, ok := other.(*CompoundPacket)
+
+	return ok && o != nil && PacketsEqual(c, *o)
+}
+
+// Clone returns a deep copy of every packet in the CompoundPacket.
+func (c CompoundPacket) Clone() Packet {
+	out := make(CompoundPacket, len(c))
+	for i, p := range c {
+		out[i] = ClonePacket(p)
+	}
+
+	return &out
+}
+
+type compoundPacketJSON struct {
+	Type    string            `json:"type"`
+	Packets []json.RawMessage `json:"Packets"`
+}
+
+// MarshalJSON encodes the CompoundPacket as a JSON object tagged with its
+// type, holding the encoding of each packet.
+func (c CompoundPacket) MarshalJSON() ([]byte, error) {
+	v := compoundPacketJSON{Type: jsonTypeNames[reflect.TypeOf(c)], Packets: make([]json.RawMessage, len(c))}
+	for i, p := range c {
+		data, err := json.Marshal(p)
+		if err != nil {
+			return nil, err
+		}
+		v.Packets[i] = data
+	}
+
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON decodes a JSON object produced by MarshalJSON.
+func (c *CompoundPacket) UnmarshalJSON(data []byte) error {
+	var v compoundPacketJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if want := jsonTypeNames[reflect.TypeOf(*c)]; v.Type != want {
+		return fmt.Errorf("%w: expected(%s) actual(%s)", errWrongJSONType, want, v.Type)
+	}
+
+	out := make(CompoundPacket, len(v.Packets))
+	for i, raw := range v.Packets {
+		p, err := UnmarshalPacketJSON(raw)
+		if err != nil {
+			return err
+		}
+		out[i] = p
+	}
+	*c = out
+
+	return nil
+}
+
 func (c CompoundPacket) String() string {
 	out := "CompoundPacket\n"
 	for _, p := range c {
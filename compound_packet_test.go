@@ -125,6 +125,45 @@ func TestValidPacket(t *testing.T) {
 			},
 			Err: nil,
 		},
+		{
+			Name: "SDES after goodbye",
+			Packet: CompoundPacket{
+				&ReceiverReport{},
+				cname,
+				&Goodbye{},
+				NewCNAMESourceDescription(5678, "other"),
+			},
+			Err: errSDESAfterGoodbye,
+		},
+		{
+			Name: "padding on last packet",
+			Packet: CompoundPacket{
+				&ReceiverReport{},
+				cname,
+				&RawPacket{0xa1, 0xce, 0x00, 0x01, 0x00, 0x00, 0x00, 0x04},
+			},
+			Err: nil,
+		},
+		{
+			Name: "padding before last packet",
+			Packet: CompoundPacket{
+				&ReceiverReport{},
+				cname,
+				&RawPacket{0xa1, 0xce, 0x00, 0x01, 0x00, 0x00, 0x00, 0x04},
+				&Goodbye{},
+			},
+			Err: ErrPaddingNotLast,
+		},
+		{
+			Name: "transport-cc padding before last packet",
+			Packet: CompoundPacket{
+				&ReceiverReport{},
+				cname,
+				&TransportLayerCC{Header: Header{Padding: true}},
+				&Goodbye{},
+			},
+			Err: ErrPaddingNotLast,
+		},
 	} {
 		assert.ErrorIsf(t, test.Packet.Validate(), test.Err, "Validate(%s)", test.Name)
 	}
@@ -251,3 +290,52 @@ func TestCompoundPacketRoundTrip(t *testing.T) {
 		assert.Equalf(t, data, data2, "Marshal(%v) mismatch", test.Name)
 	}
 }
+
+func TestCompoundPacketUnmarshalReducedSize(t *testing.T) {
+	pli := &PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2}
+	nack := &TransportLayerNack{SenderSSRC: 1, MediaSSRC: 2, Nacks: []NackPair{{PacketID: 5}}}
+	cname := NewCNAMESourceDescription(1234, "cname")
+
+	for _, test := range []struct {
+		Name      string
+		Packets   []Packet
+		WantError error
+	}{
+		{
+			Name:    "single feedback",
+			Packets: []Packet{pli},
+		},
+		{
+			Name:    "several feedback",
+			Packets: []Packet{nack, pli},
+		},
+		{
+			Name:    "regular compound",
+			Packets: []Packet{&ReceiverReport{SSRC: 1}, cname, pli},
+		},
+		{
+			Name:      "report without cname",
+			Packets:   []Packet{&ReceiverReport{SSRC: 1}, pli},
+			WantError: errPacketBeforeCNAME,
+		},
+	} {
+		data, err := Marshal(test.Packets)
+		assert.NoErrorf(t, err, "Marshal %q", test.Name)
+
+		var compound CompoundPacket
+		err = compound.UnmarshalReducedSize(data)
+		assert.ErrorIsf(t, err, test.WantError, "UnmarshalReducedSize %q", test.Name)
+		if err != nil {
+			continue
+		}
+		assert.Lenf(t, compound, len(test.Packets), "UnmarshalReducedSize %q", test.Name)
+	}
+
+	data, err := pli.Marshal()
+	assert.NoError(t, err)
+
+	var compound CompoundPacket
+	assert.ErrorIs(t, compound.Unmarshal(data), errBadFirstPacket)
+	assert.ErrorIs(t, compound.UnmarshalReducedSize(nil), errEmptyCompound)
+	assert.ErrorIs(t, compound.UnmarshalReducedSize([]byte{0x00, 0xce, 0x00, 0x02}), ErrBadVersion)
+}
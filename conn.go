@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"fmt"
+	"net"
+)
+
+// ReadPacket reads one datagram from conn into buf and unmarshals the packets
+// it contains, returning them along with the address they came from. buf
+// should be large enough for the largest expected datagram, such as 1500
+// bytes; datagrams longer than buf are truncated by most connections and then
+// fail to unmarshal.
+//
+// The returned packets do not refer to buf, so it may be reused for the next
+// read right away. Callers that want the packets to alias the datagram
+// instead, to save copying, can read it themselves and call UnmarshalNoCopy.
+func ReadPacket(conn net.PacketConn, buf []byte) ([]Packet, net.Addr, error) {
+	if len(buf) < headerLength {
+		return nil, nil, fmt.Errorf("%w: buffer of %d bytes", ErrPacketTooShort, len(buf))
+	}
+
+	n, addr, err := conn.ReadFrom(buf)
+	if err != nil {
+		return nil, addr, err
+	}
+
+	packets, err := Unmarshal(buf[:n])
+	if err != nil {
+		return nil, addr, err
+	}
+
+	return packets, addr, nil
+}
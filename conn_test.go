@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadPacket(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("no loopback UDP: %v", err)
+	}
+	defer func() { assert.NoError(t, conn.Close()) }()
+	assert.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	sender, err := net.Dial("udp", conn.LocalAddr().String())
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, sender.Close()) }()
+
+	want, err := Unmarshal(realPacket())
+	assert.NoError(t, err)
+
+	_, err = Packets(want).WriteTo(sender)
+	assert.NoError(t, err)
+
+	buf := make([]byte, 1500)
+	packets, addr, err := ReadPacket(conn, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, want, packets)
+	assert.Equal(t, sender.LocalAddr().String(), addr.String())
+
+	// A datagram that does not fit in buf is cut short.
+	_, err = sender.Write(realPacket())
+	assert.NoError(t, err)
+	_, addr, err = ReadPacket(conn, buf[:20])
+	assert.ErrorIs(t, err, ErrPacketTooShort)
+	assert.Equal(t, sender.LocalAddr().String(), addr.String())
+
+	_, _, err = ReadPacket(conn, buf[:3])
+	assert.ErrorIs(t, err, ErrPacketTooShort)
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// A Decoder reads RTCP packets one at a time from a stream of concatenated
+// packets, such as the payloads of a capture file, so the whole stream does
+// not have to be held in memory.
+type Decoder struct {
+	r io.Reader
+}
+
+// NewDecoder returns a Decoder that reads packets from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r}
+}
+
+// Next reads and unmarshals the next packet. It returns io.EOF once the
+// stream ends on a packet boundary. If the stream ends before the length
+// given in a packet's header, the returned error wraps io.ErrUnexpectedEOF.
+func (d *Decoder) Next() (Packet, error) {
+	var header Header
+	rawHeader := make([]byte, headerLength)
+	if _, err := io.ReadFull(d.r, rawHeader); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("%w: %w", ErrTruncatedPacket, err)
+		}
+
+		return nil, err
+	}
+
+	if err := header.Unmarshal(rawHeader); err != nil {
+		return nil, err
+	}
+
+	// Each packet gets its own buffer, as packets may keep slices of it.
+	rawPacket := make([]byte, (int(header.Length)+1)*4)
+	copy(rawPacket, rawHeader)
+	if _, err := io.ReadFull(d.r, rawPacket[headerLength:]); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("%w: %w", ErrTruncatedPacket, io.ErrUnexpectedEOF)
+		}
+
+		return nil, err
+	}
+
+	packet, _, err := unmarshal(rawPacket)
+	if err != nil {
+		return nil, err
+	}
+
+	return packet, nil
+}
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecoder(t *testing.T) {
+	want, err := Unmarshal(realPacket())
+	assert.NoError(t, err)
+
+	decoder := NewDecoder(bytes.NewReader(realPacket()))
+
+	var got []Packet
+	for {
+		packet, err := decoder.Next()
+		if err == io.EOF { //nolint:errorlint
+			break
+		}
+		assert.NoError(t, err)
+		got = append(got, packet)
+	}
+
+	assert.Equal(t, want, got)
+
+	// reading past the end keeps returning io.EOF
+	_, err = decoder.Next()
+	assert.Equal(t, io.EOF, err) //nolint:errorlint
+}
+
+func TestDecoderErrors(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Data      []byte
+		WantError error
+	}{
+		{
+			Name:      "empty",
+			Data:      []byte{},
+			WantError: io.EOF,
+		},
+		{
+			Name:      "partial header",
+			Data:      []byte{0x81, 0xcb},
+			WantError: ErrTruncatedPacket,
+		},
+		{
+			Name: "length past end",
+			Data: []byte{
+				// v=2, p=0, count=1, BYE, len=2
+				0x81, 0xcb, 0x00, 0x02,
+				0x90, 0x2f, 0x9e, 0x2e,
+			},
+			WantError: ErrTruncatedPacket,
+		},
+		{
+			Name:      "length past end of header",
+			Data:      []byte{0x81, 0xcb, 0x00, 0x01},
+			WantError: io.ErrUnexpectedEOF,
+		},
+		{
+			Name:      "bad version",
+			Data:      []byte{0x00, 0xcb, 0x00, 0x00},
+			WantError: ErrBadVersion,
+		},
+	} {
+		_, err := NewDecoder(bytes.NewReader(test.Data)).Next()
+		assert.ErrorIsf(t, err, test.WantError, "Next %q", test.Name)
+	}
+}
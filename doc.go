@@ -30,6 +30,16 @@ Decoding RTCP packets:
 		}
 	}
 
+Packets of a known type can also be decoded directly, without the dispatch
+and the []Packet allocation of Unmarshal. Each packet type's Unmarshal method
+parses one packet including its header, and fails with ErrWrongType for
+packets of other types:
+
+	var pli rtcp.PictureLossIndication
+	if err := pli.Unmarshal(rtcpData); err != nil {
+		// ...
+	}
+
 Encoding RTCP packets:
 
 	pkt := &rtcp.PictureLossIndication{
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dump returns a human-readable description of the RTCP packets in data,
+// for debugging. Each packet is listed with its offset, its header fields,
+// its decoded contents and its bytes in hex, one 32-bit word per line. Data
+// that cannot be parsed is dumped as hex along with the reason it failed.
+func Dump(data []byte) string {
+	var out strings.Builder
+	for offset := 0; offset < len(data); {
+		n := dumpPacket(&out, data, offset)
+		if n == 0 {
+			break
+		}
+		offset += n
+	}
+
+	return out.String()
+}
+
+// dumpPacket describes the packet at offset in data and returns its size. If
+// no packet can be framed at offset the rest of data is dumped as hex, and
+// the size returned is 0.
+func dumpPacket(out *strings.Builder, data []byte, offset int) int {
+	rawPacket := data[offset:]
+
+	var header Header
+	if err := header.Unmarshal(rawPacket); err != nil {
+		dumpInvalid(out, rawPacket, offset, err)
+
+		return 0
+	}
+
+	size := (int(header.Length) + 1) * 4
+	if size > len(rawPacket) {
+		dumpInvalid(out, rawPacket, offset, fmt.Errorf("%w: length %d needs %d bytes", ErrPacketTooShort, header.Length, size))
+
+		return 0
+	}
+	rawPacket = rawPacket[:size]
+
+	name := header.Type.String()
+	count := "RC"
+	switch header.Type {
+	case TypeTransportSpecificFeedback, TypePayloadSpecificFeedback:
+		name += " " + FormatString(header.Type, header.Count)
+		count = "FMT"
+	case TypeSourceDescription, TypeGoodbye:
+		count = "SC"
+	case TypeApplicationDefined:
+		count = "subtype"
+	default:
+	}
+
+	fmt.Fprintf(out, "offset %d: %s, %d bytes\n", offset, name, size)
+	fmt.Fprintf(out, "  header: V=%d P=%t %s=%d PT=%d length=%d\n",
+		rtpVersion, header.Padding, count, header.Count, uint8(header.Type), header.Length)
+
+	packet, _, err := unmarshal(rawPacket)
+	if err != nil {
+		fmt.Fprintf(out, "  unparseable: %v\n", err)
+	} else if _, raw := packet.(*RawPacket); !raw {
+		dumpIndented(out, describe(packet))
+	}
+	dumpHex(out, rawPacket, offset)
+
+	return size
+}
+
+func dumpInvalid(out *strings.Builder, rawPacket []byte, offset int, err error) {
+	fmt.Fprintf(out, "offset %d: unparseable, %d bytes: %v\n", offset, len(rawPacket), err)
+	dumpHex(out, rawPacket, offset)
+}
+
+// describe returns the packet's String, or its fields for the packet types
+// that have none.
+func describe(packet Packet) string {
+	if s, ok := packet.(fmt.Stringer); ok {
+		return s.String()
+	}
+
+	return fmt.Sprintf("%T %+v", packet, packet)
+}
+
+func dumpIndented(out *strings.Builder, s string) {
+	for _, line := range strings.Split(strings.TrimRight(s, "\n"), "\n") {
+		fmt.Fprintf(out, "  %s\n", strings.TrimLeft(line, "\t"))
+	}
+}
+
+// dumpHex writes b one 32-bit word per line, labeled with offsets starting at
+// offset.
+func dumpHex(out *strings.Builder, b []byte, offset int) {
+	for i := 0; i < len(b); i += 4 {
+		end := i + 4
+		if end > len(b) {
+			end = len(b)
+		}
+		fmt.Fprintf(out, "    %4d: % x\n", offset+i, b[i:end])
+	}
+}
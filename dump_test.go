@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDump(t *testing.T) {
+	dump := Dump(realPacket())
+	for _, want := range []string{
+		"offset 0: RR, 32 bytes\n  header: V=2 P=false RC=1 PT=201 length=7\n  ReceiverReport from 902f9e2e\n",
+		"       0: 81 c9 00 07\n       4: 90 2f 9e 2e\n",
+		"offset 32: SDES, 52 bytes\n  header: V=2 P=false SC=1 PT=202 length=12\n",
+		"offset 84: BYE, 8 bytes\n  header: V=2 P=false SC=1 PT=203 length=1\n",
+		"offset 92: PSFB PLI, 12 bytes\n  header: V=2 P=false FMT=1 PT=206 length=2\n" +
+			"  PictureLossIndication 902f9e2e 902f9e2e\n" +
+			"      92: 81 ce 00 02\n      96: 90 2f 9e 2e\n     100: 90 2f 9e 2e\n",
+		"offset 104: TSFB RRR, 12 bytes\n",
+		"offset 116: APP, 16 bytes\n  header: V=2 P=false subtype=0 PT=204 length=3\n",
+	} {
+		assert.Contains(t, dump, want)
+	}
+	assert.NotContains(t, dump, "unparseable")
+
+	for _, test := range []struct {
+		Name string
+		Data []byte
+		Want string
+	}{
+		{
+			Name: "empty",
+			Data: nil,
+			Want: "",
+		},
+		{
+			Name: "truncated",
+			Data: []byte{0x81, 0xc9, 0x00, 0x07, 0x01},
+			Want: "offset 0: unparseable, 5 bytes: rtcp: packet too short: length 7 needs 32 bytes\n" +
+				"       0: 81 c9 00 07\n" +
+				"       4: 01\n",
+		},
+		{
+			Name: "bad version",
+			Data: []byte{0x41, 0xc9, 0x00, 0x00},
+			Want: "offset 0: unparseable, 4 bytes: rtcp: invalid packet version\n" +
+				"       0: 41 c9 00 00\n",
+		},
+		{
+			Name: "unknown feedback format",
+			Data: []byte{0x80, 0xce, 0x00, 0x00},
+			Want: "offset 0: PSFB FMT(0), 4 bytes\n" +
+				"  header: V=2 P=false FMT=0 PT=206 length=0\n" +
+				"       0: 80 ce 00 00\n",
+		},
+		{
+			Name: "unparseable packet",
+			Data: []byte{0x81, 0xce, 0x00, 0x00},
+			Want: "offset 0: PSFB PLI, 4 bytes\n" +
+				"  header: V=2 P=false FMT=1 PT=206 length=0\n" +
+				"  unparseable: rtcp: packet too short\n" +
+				"       0: 81 ce 00 00\n",
+		},
+	} {
+		assert.Equalf(t, test.Want, Dump(test.Data), "Dump %q", test.Name)
+	}
+}
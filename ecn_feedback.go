@@ -0,0 +1,254 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// The ECNFeedback packet reports the Explicit Congestion Notification marks
+// of the RTP packets received from a media source, for the sender to react to
+// congestion before packets are lost. The counters are cumulative since the
+// receiver started. See RFC 6679 Section 5.1.
+type ECNFeedback struct {
+	// SSRC of sender
+	SenderSSRC uint32
+
+	// SSRC of the media source
+	MediaSSRC uint32
+
+	// The highest sequence number received, extended with the count of
+	// sequence number cycles as in a reception report.
+	ExtendedHighestSequenceNumber uint32
+
+	// The number of packets received marked ECT(0).
+	ECT0 uint32
+
+	// The number of packets received marked ECT(1).
+	ECT1 uint32
+
+	// The number of packets received marked ECN-CE.
+	ECNCE uint16
+
+	// The number of packets received not marked as ECN-capable.
+	NotECT uint16
+
+	// The number of packets expected but not received.
+	LostPackets uint16
+
+	// The number of duplicate packets received.
+	Duplication uint16
+
+	packetPadding
+}
+
+const (
+	ecnFCIOffset = 8
+	ecnFCILength = 20
+	ecnLength    = headerLength + ecnFCIOffset + ecnFCILength
+)
+
+// Marshal encodes the ECNFeedback in binary.
+func (p ECNFeedback) Marshal() ([]byte, error) {
+	rawPacket := make([]byte, p.MarshalSize())
+	if _, err := p.MarshalTo(rawPacket); err != nil {
+		return nil, err
+	}
+
+	return rawPacket, nil
+}
+
+// MarshalAppend appends the packet in binary to dst and returns the
+// extended slice.
+func (p ECNFeedback) MarshalAppend(dst []byte) ([]byte, error) {
+	return marshalAppend(dst, p.MarshalSize(), p.MarshalTo)
+}
+
+// MarshalTo encodes the ECNFeedback in binary into buf and returns the number of bytes written.
+func (p ECNFeedback) MarshalTo(buf []byte) (int, error) {
+	/*
+	 *  0                   1                   2                   3
+	 *  0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * |V=2|P|  FMT=8  |   PT=205      |          length=7             |
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * |                  SSRC of packet sender                        |
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * |                  SSRC of media source                         |
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * | Extended Highest Sequence Number                              |
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * | ECT (0) Counter                                               |
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * | ECT (1) Counter                                               |
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * | ECN-CE Counter                | not-ECT Counter               |
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * | Lost Packets Counter          | Duplication Counter           |
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 */
+	size := p.MarshalSize()
+	if len(buf) < size {
+		return 0, ErrPacketTooShort
+	}
+
+	header := p.Header()
+	if err := header.SetLengthFromBytes(size); err != nil {
+		return 0, err
+	}
+	if _, err := header.MarshalTo(buf); err != nil {
+		return 0, err
+	}
+
+	packetBody := buf[headerLength:]
+
+	binary.BigEndian.PutUint32(packetBody, p.SenderSSRC)
+	binary.BigEndian.PutUint32(packetBody[4:], p.MediaSSRC)
+
+	fci := packetBody[ecnFCIOffset:]
+	binary.BigEndian.PutUint32(fci, p.ExtendedHighestSequenceNumber)
+	binary.BigEndian.PutUint32(fci[4:], p.ECT0)
+	binary.BigEndian.PutUint32(fci[8:], p.ECT1)
+	binary.BigEndian.PutUint16(fci[12:], p.ECNCE)
+	binary.BigEndian.PutUint16(fci[14:], p.NotECT)
+	binary.BigEndian.PutUint16(fci[16:], p.LostPackets)
+	binary.BigEndian.PutUint16(fci[18:], p.Duplication)
+
+	writePadding(buf[:size], p.padding)
+
+	return size, nil
+}
+
+// Unmarshal decodes the ECNFeedback from binary.
+func (p *ECNFeedback) Unmarshal(rawPacket []byte) error {
+	rawPacket, padding, err := removePadding(rawPacket)
+	if err != nil {
+		return err
+	}
+
+	if len(rawPacket) < ecnLength {
+		return ErrPacketTooShort
+	}
+
+	var h Header
+	if err := h.Unmarshal(rawPacket); err != nil {
+		return err
+	}
+
+	if h.Type != TypeTransportSpecificFeedback || h.Count != FormatECN {
+		return ErrWrongType
+	}
+
+	// The FCI field holds exactly one set of counters.
+	if (int(h.Length)+1)*4 != ecnLength {
+		return ErrBadLength
+	}
+
+	p.SenderSSRC = binary.BigEndian.Uint32(rawPacket[headerLength:])
+	p.MediaSSRC = binary.BigEndian.Uint32(rawPacket[headerLength+ssrcLength:])
+
+	fci := rawPacket[headerLength+ecnFCIOffset:]
+	p.ExtendedHighestSequenceNumber = binary.BigEndian.Uint32(fci)
+	p.ECT0 = binary.BigEndian.Uint32(fci[4:])
+	p.ECT1 = binary.BigEndian.Uint32(fci[8:])
+	p.ECNCE = binary.BigEndian.Uint16(fci[12:])
+	p.NotECT = binary.BigEndian.Uint16(fci[14:])
+	p.LostPackets = binary.BigEndian.Uint16(fci[16:])
+	p.Duplication = binary.BigEndian.Uint16(fci[18:])
+
+	p.padding = padding
+
+	return nil
+}
+
+// MarshalSize returns the size of the packet once marshaled.
+func (p *ECNFeedback) MarshalSize() int {
+	return ecnLength + int(p.padding)
+}
+
+// Header returns the Header associated with this packet.
+func (p *ECNFeedback) Header() Header {
+	h := Header{
+		Padding: p.padding != 0,
+		Count:   FormatECN,
+		Type:    TypeTransportSpecificFeedback,
+	}
+	_ = h.SetLengthFromBytes(p.MarshalSize())
+
+	return h
+}
+
+// Validate returns nil, as an ECNFeedback has no fields that can be invalid.
+func (p *ECNFeedback) Validate() error {
+	return nil
+}
+
+// Type returns the packet type, TypeTransportSpecificFeedback.
+func (p *ECNFeedback) Type() PacketType {
+	return TypeTransportSpecificFeedback
+}
+
+// Format returns the feedback message type, FormatECN.
+func (p *ECNFeedback) Format() uint8 {
+	return FormatECN
+}
+
+// DestinationSSRC returns an array of SSRC values that this packet refers to.
+func (p *ECNFeedback) DestinationSSRC() []uint32 {
+	return []uint32{p.MediaSSRC}
+}
+
+// TargetsSSRC reports whether the packet is feedback about the media source
+// ssrc, its MediaSSRC.
+func (p *ECNFeedback) TargetsSSRC(ssrc uint32) bool {
+	return p.MediaSSRC == ssrc
+}
+
+// SourceSSRC returns the SSRC of the sender.
+func (p *ECNFeedback) SourceSSRC() []uint32 {
+	return []uint32{p.SenderSSRC}
+}
+
+// RewriteSSRC replaces every SSRC in the packet that equals from with to,
+// and returns the number of SSRCs replaced.
+func (p *ECNFeedback) RewriteSSRC(from, to uint32) int {
+	return rewriteSSRC(&p.SenderSSRC, from, to) + rewriteSSRC(&p.MediaSSRC, from, to)
+}
+
+// Equal reports whether other is an ECNFeedback with the same contents.
+func (p *ECNFeedback) Equal(other Packet) bool {
+	o, ok := other.(*ECNFeedback)
+	if !ok || o == nil {
+		return false
+	}
+
+	a, b := *p, *o
+	a.packetPadding, b.packetPadding = packetPadding{}, packetPadding{}
+
+	return a == b
+}
+
+// Clone returns a copy of the packet.
+func (p *ECNFeedback) Clone() Packet {
+	c := *p
+
+	return &c
+}
+
+// MarshalJSON encodes the packet as a JSON object tagged with its type.
+func (p ECNFeedback) MarshalJSON() ([]byte, error) {
+	return marshalJSON(p)
+}
+
+// UnmarshalJSON decodes a JSON object produced by MarshalJSON.
+func (p *ECNFeedback) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, p)
+}
+
+func (p *ECNFeedback) String() string {
+	return fmt.Sprintf("ECNFeedback %x %x seq %d ECT(0) %d ECT(1) %d ECN-CE %d not-ECT %d lost %d duplicates %d",
+		p.SenderSSRC, p.MediaSSRC, p.ExtendedHighestSequenceNumber,
+		p.ECT0, p.ECT1, p.ECNCE, p.NotECT, p.LostPackets, p.Duplication)
+}
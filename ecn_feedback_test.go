@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Packet = (*ECNFeedback)(nil) // assert is a Packet
+
+func TestECNFeedbackUnmarshal(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Data      []byte
+		Want      ECNFeedback
+		WantError error
+	}{
+		{
+			Name: "valid",
+			Data: []byte{
+				// ECNFeedback, len=7
+				0x88, 0xcd, 0x0, 0x7,
+				// sender=0x902f9e2e
+				0x90, 0x2f, 0x9e, 0x2e,
+				// media=0x4bc4fcb4
+				0x4b, 0xc4, 0xfc, 0xb4,
+				// extended highest sequence number=0x146e1
+				0x0, 0x1, 0x46, 0xe1,
+				// ECT(0)=1000
+				0x0, 0x0, 0x3, 0xe8,
+				// ECT(1)=2
+				0x0, 0x0, 0x0, 0x2,
+				// ECN-CE=30, not-ECT=4
+				0x0, 0x1e, 0x0, 0x4,
+				// lost=5, duplication=6
+				0x0, 0x5, 0x0, 0x6,
+			},
+			Want: ECNFeedback{
+				SenderSSRC:                    0x902f9e2e,
+				MediaSSRC:                     0x4bc4fcb4,
+				ExtendedHighestSequenceNumber: 0x146e1,
+				ECT0:                          1000,
+				ECT1:                          2,
+				ECNCE:                         30,
+				NotECT:                        4,
+				LostPackets:                   5,
+				Duplication:                   6,
+			},
+		},
+		{
+			Name: "short report",
+			Data: []byte{
+				0x88, 0xcd, 0x0, 0x7,
+				0x90, 0x2f, 0x9e, 0x2e,
+				0x4b, 0xc4, 0xfc, 0xb4,
+				0x0, 0x1, 0x46, 0xe1,
+				// counters end early
+				0x0, 0x0, 0x3, 0xe8,
+			},
+			WantError: ErrPacketTooShort,
+		},
+		{
+			Name: "extra FCI",
+			Data: []byte{
+				0x88, 0xcd, 0x0, 0x8,
+				0x90, 0x2f, 0x9e, 0x2e,
+				0x4b, 0xc4, 0xfc, 0xb4,
+				0x0, 0x1, 0x46, 0xe1,
+				0x0, 0x0, 0x3, 0xe8,
+				0x0, 0x0, 0x0, 0x2,
+				0x0, 0x1e, 0x0, 0x4,
+				0x0, 0x5, 0x0, 0x6,
+				0x0, 0x0, 0x0, 0x0,
+			},
+			WantError: ErrBadLength,
+		},
+		{
+			Name: "wrong format",
+			Data: []byte{
+				// TMMBN
+				0x84, 0xcd, 0x0, 0x7,
+				0x90, 0x2f, 0x9e, 0x2e,
+				0x4b, 0xc4, 0xfc, 0xb4,
+				0x0, 0x1, 0x46, 0xe1,
+				0x0, 0x0, 0x3, 0xe8,
+				0x0, 0x0, 0x0, 0x2,
+				0x0, 0x1e, 0x0, 0x4,
+				0x0, 0x5, 0x0, 0x6,
+			},
+			WantError: ErrWrongType,
+		},
+		{
+			Name:      "nil",
+			Data:      nil,
+			WantError: ErrPacketTooShort,
+		},
+	} {
+		var ecn ECNFeedback
+		err := ecn.Unmarshal(test.Data)
+		assert.ErrorIsf(t, err, test.WantError, "Unmarshal %q", test.Name)
+		if err != nil {
+			continue
+		}
+		assert.Equalf(t, test.Want, ecn, "Unmarshal %q", test.Name)
+
+		packets, err := Unmarshal(test.Data)
+		assert.NoErrorf(t, err, "Unmarshal %q", test.Name)
+		assert.Equalf(t, []Packet{&test.Want}, packets, "Unmarshal %q dispatch", test.Name)
+	}
+}
+
+func TestECNFeedbackRoundTrip(t *testing.T) {
+	for _, test := range []struct {
+		Name    string
+		Report  ECNFeedback
+		Padding uint8
+	}{
+		{
+			Name: "valid",
+			Report: ECNFeedback{
+				SenderSSRC:                    0x902f9e2e,
+				MediaSSRC:                     0x4bc4fcb4,
+				ExtendedHighestSequenceNumber: 0xffffffff,
+				ECT0:                          0xffffffff,
+				ECT1:                          1,
+				ECNCE:                         0xffff,
+				NotECT:                        2,
+				LostPackets:                   3,
+				Duplication:                   0xffff,
+			},
+		},
+		{
+			Name:    "padded",
+			Report:  ECNFeedback{SenderSSRC: 1, MediaSSRC: 2, ECT0: 3},
+			Padding: 4,
+		},
+	} {
+		assert.NoError(t, test.Report.SetPadding(test.Padding))
+		data, err := test.Report.Marshal()
+		assert.NoErrorf(t, err, "Marshal %q", test.Name)
+		assert.Lenf(t, data, 32+int(test.Padding), "Marshal %q", test.Name)
+
+		var decoded ECNFeedback
+		assert.NoErrorf(t, decoded.Unmarshal(data), "Unmarshal %q", test.Name)
+		assert.Equalf(t, test.Report, decoded, "%q round trip", test.Name)
+	}
+}
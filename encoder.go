@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"fmt"
+	"io"
+)
+
+// An Encoder batches RTCP packets into compound packets of at most an MTU
+// and writes each one to an io.Writer in a single Write call, so that each
+// becomes one datagram when the writer is a UDP connection. Packets are held
+// until Flush, or until the next one would make the compound packet larger
+// than the MTU, when the held ones are flushed first.
+//
+// The Encoder does not check the order of the packets; RFC 3550 compound
+// packets start with a report, as CompoundPacket.Validate checks. An Encoder
+// is not safe for concurrent use.
+type Encoder struct {
+	w       io.Writer
+	mtu     int
+	builder CompoundBuilder
+	buf     []byte
+}
+
+// NewEncoder returns an Encoder that writes compound packets of at most mtu
+// bytes to w.
+func NewEncoder(w io.Writer, mtu int) *Encoder {
+	return &Encoder{w: w, mtu: mtu}
+}
+
+// Write adds p to the compound packet being batched, flushing the packets
+// held first if p would not fit with them. It fails with ErrBudgetExceeded if
+// p alone is larger than the MTU, and with the error of the flush if that
+// fails, in which case p is not added.
+func (e *Encoder) Write(p Packet) error {
+	if e.builder.Fits(p, e.mtu) {
+		return e.builder.Add(p, e.mtu)
+	}
+	if e.builder.Len() == 0 {
+		return fmt.Errorf("%w: %T of %d bytes for an MTU of %d", ErrBudgetExceeded, p, p.MarshalSize(), e.mtu)
+	}
+
+	if err := e.Flush(); err != nil {
+		return err
+	}
+
+	return e.builder.Add(p, e.mtu)
+}
+
+// Buffered returns the number of bytes held for the next flush.
+func (e *Encoder) Buffered() int {
+	return e.builder.Size()
+}
+
+// Flush marshals the packets held as one compound packet and writes it. It
+// does nothing if no packets are held. The packets are discarded even if
+// marshaling or writing them fails, as a datagram that failed once is not
+// retried.
+func (e *Encoder) Flush() error {
+	if e.builder.Len() == 0 {
+		return nil
+	}
+	defer e.builder.Reset()
+
+	var err error
+	e.buf, err = MarshalAppend(e.buf[:0], e.builder.Packets())
+	if err != nil {
+		return err
+	}
+
+	_, err = e.w.Write(e.buf)
+
+	return err
+}
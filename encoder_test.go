@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// datagramWriter records each Write as a separate datagram.
+type datagramWriter struct {
+	datagrams [][]byte
+	err       error
+}
+
+func (w *datagramWriter) Write(b []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	w.datagrams = append(w.datagrams, append([]byte(nil), b...))
+
+	return len(b), nil
+}
+
+func TestEncoder(t *testing.T) {
+	rr := &ReceiverReport{SSRC: 1}                             // 8 bytes
+	pli := &PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2} // 12 bytes
+	nack := NewTransportLayerNack(1, 2, 3)                     // 16 bytes
+
+	var w datagramWriter
+	encoder := NewEncoder(&w, 32)
+
+	assert.NoError(t, encoder.Write(rr))
+	assert.NoError(t, encoder.Write(pli))
+	assert.Equal(t, 20, encoder.Buffered())
+	assert.Empty(t, w.datagrams, "packets are held until they are flushed")
+
+	// The NACK does not fit with the others, which are flushed first.
+	assert.NoError(t, encoder.Write(nack))
+	assert.Equal(t, 16, encoder.Buffered())
+	assert.NoError(t, encoder.Flush())
+	assert.Equal(t, 0, encoder.Buffered())
+
+	// Flushing nothing writes nothing.
+	assert.NoError(t, encoder.Flush())
+
+	first, err := Marshal([]Packet{rr, pli})
+	assert.NoError(t, err)
+	second, err := nack.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{first, second}, w.datagrams)
+}
+
+func TestEncoderErrors(t *testing.T) {
+	var w datagramWriter
+	encoder := NewEncoder(&w, 16)
+
+	// A packet larger than the MTU is refused.
+	rr := &ReceiverReport{SSRC: 1, Reports: []ReceptionReport{{SSRC: 2}}}
+	assert.ErrorIs(t, encoder.Write(rr), ErrBudgetExceeded)
+	assert.Equal(t, 0, encoder.Buffered())
+
+	// A failed write discards the packets and is reported by the Write
+	// whose packet triggered the flush, which is not added.
+	errWrite := errors.New("write failed")
+	w.err = errWrite
+	assert.NoError(t, encoder.Write(&PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2}))
+	assert.ErrorIs(t, encoder.Write(&PictureLossIndication{SenderSSRC: 1, MediaSSRC: 3}), errWrite)
+	assert.Equal(t, 0, encoder.Buffered())
+
+	// Packets that fail to marshal are discarded too.
+	w.err = nil
+	assert.NoError(t, encoder.Write(&ApplicationLayerFeedback{FCI: []byte{1}}))
+	assert.ErrorIs(t, encoder.Flush(), ErrBadLength)
+	assert.Equal(t, 0, encoder.Buffered())
+	assert.Empty(t, w.datagrams)
+}
@@ -3,29 +3,83 @@
 
 package rtcp
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors returned when unmarshaling malformed packets. They may be wrapped,
+// so match them with errors.Is.
+var (
+	// ErrBadVersion is returned when a packet's version field is not 2. Data
+	// that fails with it is usually not RTCP at all, such as misrouted RTP or
+	// SRTP.
+	ErrBadVersion = errors.New("rtcp: invalid packet version")
+	// ErrInvalidHeader is returned for data that holds no packet header.
+	ErrInvalidHeader = errors.New("rtcp: invalid header")
+	// ErrPacketTooShort is returned when a packet is shorter than its
+	// contents require, or a buffer is too short to marshal into.
+	ErrPacketTooShort = errors.New("rtcp: packet too short")
+	// ErrWrongType is returned when a packet is unmarshaled into a type that
+	// does not match its header.
+	ErrWrongType = errors.New("rtcp: wrong packet type")
+	// ErrBadLength is returned when a packet's length field is not valid for
+	// its type.
+	ErrBadLength = errors.New("rtcp: invalid packet length")
+	// ErrLengthOverflow is returned when marshaling a packet too large for
+	// the 16-bit length field of its header, 65536 32-bit words.
+	ErrLengthOverflow = errors.New("rtcp: packet too large for length field")
+	// ErrBudgetExceeded is returned by CompoundBuilder.Add for a packet that
+	// would make the compound packet larger than its size budget.
+	ErrBudgetExceeded = errors.New("rtcp: packet exceeds size budget")
+	// ErrWrongPadding is returned when a packet's padding count is invalid.
+	ErrWrongPadding = errors.New("rtcp: invalid padding value")
+	// ErrTruncatedPacket is returned by Decoder when the stream ends inside
+	// a packet, and by UnmarshalPartial when the datagram does.
+	ErrTruncatedPacket = errors.New("rtcp: packet length exceeds available data")
+	// ErrTrailingData is returned by UnmarshalStrict when bytes after the
+	// last whole packet remain.
+	ErrTrailingData = errors.New("rtcp: trailing data after last packet")
+	// ErrPacketLengthMismatch is returned by UnmarshalStrict when a packet's
+	// length field covers more data than the packet holds.
+	ErrPacketLengthMismatch = errors.New("rtcp: packet length does not match its contents")
+	// ErrTooManyPackets is returned when a datagram holds more packets than
+	// the limit set by DefaultMaxPackets or UnmarshalOptions.MaxPackets.
+	ErrTooManyPackets = errors.New("rtcp: too many packets")
+	// ErrPaddingNotLast is returned by CompoundPacket.Validate when a packet
+	// other than the last is padded, and passed to UnmarshalOptions.Warn for
+	// such packets.
+	ErrPaddingNotLast = errors.New("rtcp: only the last packet in a compound may be padded")
+	// ErrUnknownSDESType is passed to UnmarshalOptions.Warn for
+	// SourceDescription items of unknown types.
+	ErrUnknownSDESType = errors.New("rtcp: unknown sdes item type")
+)
 
 var (
 	errWrongMarshalSize         = errors.New("rtcp: wrong marshal size")
 	errInvalidTotalLost         = errors.New("rtcp: invalid total lost count")
-	errInvalidHeader            = errors.New("rtcp: invalid header")
 	errEmptyCompound            = errors.New("rtcp: empty compound packet")
 	errBadFirstPacket           = errors.New("rtcp: first packet in compound must be SR or RR")
 	errMissingCNAME             = errors.New("rtcp: compound missing SourceDescription with CNAME")
 	errPacketBeforeCNAME        = errors.New("rtcp: feedback packet seen before CNAME")
+	errSDESAfterGoodbye         = errors.New("rtcp: compound has SourceDescription after Goodbye")
+	errUnknownJSONType          = errors.New("rtcp: unknown JSON packet type")
+	errMissingJSONType          = errors.New("rtcp: JSON object has no type field")
+	errWrongJSONType            = errors.New("rtcp: JSON object has the wrong type")
+	errInvalidJSONSSRC          = errors.New("rtcp: invalid SSRC in JSON")
 	errTooManyReports           = errors.New("rtcp: too many reports")
+	errEmptyFCI                 = errors.New("rtcp: feedback message must have at least one FCI entry")
+	errFieldOutOfRange          = errors.New("rtcp: field value out of range")
 	errTooManyChunks            = errors.New("rtcp: too many chunks")
 	errTooManySources           = errors.New("rtcp: too many sources")
-	errPacketTooShort           = errors.New("rtcp: packet too short")
-	errWrongType                = errors.New("rtcp: wrong packet type")
 	errSDESTextTooLong          = errors.New("rtcp: sdes must be < 255 octets long")
 	errSDESMissingType          = errors.New("rtcp: sdes item missing type")
+	errSDESPrivateTooShort      = errors.New("rtcp: sdes private item prefix exceeds item length")
 	errReasonTooLong            = errors.New("rtcp: reason must be < 255 octets long")
-	errBadVersion               = errors.New("rtcp: invalid packet version")
-	errBadLength                = errors.New("rtcp: invalid packet length")
-	errWrongPadding             = errors.New("rtcp: invalid padding value")
+	errInvalidPadding           = errors.New("rtcp: padding must be a multiple of 4")
 	errWrongFeedbackType        = errors.New("rtcp: wrong feedback message type")
 	errWrongPayloadType         = errors.New("rtcp: wrong payload type")
+	errInvalidPayloadType       = errors.New("rtcp: RTP payload type must be < 128")
 	errHeaderTooSmall           = errors.New("rtcp: header length is too small")
 	errSSRCMustBeZero           = errors.New("rtcp: media SSRC must be 0")
 	errMissingREMBidentifier    = errors.New("missing REMB identifier")
@@ -33,9 +87,26 @@ var (
 	errInvalidSizeOrStartIndex  = errors.New("invalid size or startIndex")
 	errInvalidBitrate           = errors.New("invalid bitrate")
 	errWrongChunkType           = errors.New("rtcp: wrong chunk type")
+	errInvalidChunkValue        = errors.New("rtcp: chunk value out of range")
+	errTCCDeltaMismatch         = errors.New("rtcp: receive deltas do not match packet status chunks")
+	errInvalidMxTBR             = errors.New("rtcp: MxTBR entry field out of range")
+	errInvalidReceiptTimes      = errors.New("rtcp: receipt time count does not match sequence range")
 	errBadStructMemberType      = errors.New("rtcp: struct contains unexpected member type")
 	errBadReadParameter         = errors.New("rtcp: cannot read into non-pointer")
 	errAppDefinedInvalidLength  = errors.New("rtcp: application defined type invalid length")
 	errAppDefinedDataTooLarge   = errors.New("rtcp: application defined data is too large")
 	errAppDefinedInvalidName    = errors.New("rtcp: application defined name must be 4 ASCII chars")
+	errNoNacksToMerge           = errors.New("rtcp: no NACKs to merge")
+	errNackMediaSSRCMismatch    = errors.New("rtcp: NACKs are for different media sources")
 )
+
+// PacketNotFoundError is returned by UnmarshalFirst when the data holds no
+// packet of the requested type.
+type PacketNotFoundError struct {
+	// Type is the name of the requested packet type, e.g. "*rtcp.ReceiverReport".
+	Type string
+}
+
+func (e *PacketNotFoundError) Error() string {
+	return fmt.Sprintf("rtcp: no packet of type %s found", e.Type)
+}
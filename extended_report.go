@@ -4,7 +4,9 @@
 package rtcp
 
 import (
+	"bytes"
 	"fmt"
+	"time"
 )
 
 // The ExtendedReport packet is an Implementation of RTCP Extended
@@ -27,6 +29,8 @@ import (
 type ExtendedReport struct {
 	SenderSSRC uint32 `fmt:"0x%X"`
 	Reports    []ReportBlock
+
+	packetPadding `encoding:"omit"`
 }
 
 // ReportBlock represents a single report within an ExtendedReport
@@ -34,7 +38,7 @@ type ExtendedReport struct {
 type ReportBlock interface {
 	DestinationSSRC() []uint32
 	setupBlockHeader()
-	unpackBlockHeader()
+	unpackBlockHeader() error
 }
 
 // TypeSpecificField as described in RFC 3611 section 4.5. In typical
@@ -68,6 +72,19 @@ const (
 	VoIPMetricsReportBlockType           = 7 // RFC 3611, section 4.7
 )
 
+// reportBlockTypes allocates the report block for each known block type.
+// Blocks of other types are decoded as an UnknownReportBlock, which keeps
+// their bytes so they survive being marshaled again.
+var reportBlockTypes = map[BlockTypeType]func() ReportBlock{ //nolint:gochecknoglobals
+	LossRLEReportBlockType:               func() ReportBlock { return new(LossRLEReportBlock) },
+	DuplicateRLEReportBlockType:          func() ReportBlock { return new(DuplicateRLEReportBlock) },
+	PacketReceiptTimesReportBlockType:    func() ReportBlock { return new(PacketReceiptTimesReportBlock) },
+	ReceiverReferenceTimeReportBlockType: func() ReportBlock { return new(ReceiverReferenceTimeReportBlock) },
+	DLRRReportBlockType:                  func() ReportBlock { return new(DLRRReportBlock) },
+	StatisticsSummaryReportBlockType:     func() ReportBlock { return new(StatisticsSummaryReportBlock) },
+	VoIPMetricsReportBlockType:           func() ReportBlock { return new(VoIPMetricsReportBlock) },
+}
+
 // String converts the Extended report block types into readable strings.
 func (t BlockTypeType) String() string {
 	switch t {
@@ -158,17 +175,19 @@ func (b *LossRLEReportBlock) DestinationSSRC() []uint32 {
 }
 
 func (b *LossRLEReportBlock) setupBlockHeader() {
-	b.XRHeader.BlockType = LossRLEReportBlockType
-	b.XRHeader.TypeSpecific = TypeSpecificField(b.T & 0x0F)
-	b.XRHeader.BlockLength = uint16(wireSize(b)/4 - 1) //nolint:gosec // G115
+	(*rleReportBlock)(b).setupBlockHeader(LossRLEReportBlockType)
 }
 
-func (b *LossRLEReportBlock) unpackBlockHeader() {
-	b.T = uint8(b.XRHeader.TypeSpecific) & 0x0F
+func (b *LossRLEReportBlock) unpackBlockHeader() error {
+	return (*rleReportBlock)(b).unpackBlockHeader()
+}
+
+func (b *LossRLEReportBlock) trailingPadding() int {
+	return (*rleReportBlock)(b).trailingPadding()
 }
 
 // DuplicateRLEReportBlock is used to report information about packet
-// duplication, as described in RFC 3611, section 4.1.
+// duplication, as described in RFC 3611, section 4.2.
 type DuplicateRLEReportBlock rleReportBlock
 
 // DestinationSSRC returns an array of SSRC values that this report block refers to.
@@ -177,13 +196,40 @@ func (b *DuplicateRLEReportBlock) DestinationSSRC() []uint32 {
 }
 
 func (b *DuplicateRLEReportBlock) setupBlockHeader() {
-	b.XRHeader.BlockType = DuplicateRLEReportBlockType
+	(*rleReportBlock)(b).setupBlockHeader(DuplicateRLEReportBlockType)
+}
+
+func (b *DuplicateRLEReportBlock) unpackBlockHeader() error {
+	return (*rleReportBlock)(b).unpackBlockHeader()
+}
+
+func (b *DuplicateRLEReportBlock) trailingPadding() int {
+	return (*rleReportBlock)(b).trailingPadding()
+}
+
+// setupBlockHeader is shared by the Loss RLE and Duplicate RLE report
+// blocks, which differ only in their block type.
+func (b *rleReportBlock) setupBlockHeader(blockType BlockTypeType) {
+	b.XRHeader.BlockType = blockType
 	b.XRHeader.TypeSpecific = TypeSpecificField(b.T & 0x0F)
 	b.XRHeader.BlockLength = uint16(wireSize(b)/4 - 1) //nolint:gosec // G115
 }
 
-func (b *DuplicateRLEReportBlock) unpackBlockHeader() {
+func (b *rleReportBlock) unpackBlockHeader() error {
 	b.T = uint8(b.XRHeader.TypeSpecific) & 0x0F
+
+	return nil
+}
+
+// trailingPadding returns the size of the terminating null chunk that
+// follows an odd number of chunks, so that the report block ends on a 32-bit
+// boundary. The chunk is only written, not added to b.Chunks.
+func (b *rleReportBlock) trailingPadding() int {
+	if len(b.Chunks)%2 != 0 {
+		return 2
+	}
+
+	return 0
 }
 
 // ChunkType enumerates the three kinds of chunks described in RFC 3611 section 4.1.
@@ -211,6 +257,27 @@ func (c Chunk) String() string {
 	return fmt.Sprintf("[0x%X]", uint16(c))
 }
 
+// NewRunLengthChunk returns a run length Chunk. The runType is 1 for
+// a run of received (or duplicated) packets and 0 for a run of lost
+// (or non-duplicated) packets. The length must fit in 14 bits.
+func NewRunLengthChunk(runType uint, length uint) (Chunk, error) {
+	if runType > 1 || length == 0 || length > 0x3FFF {
+		return 0, errInvalidChunkValue
+	}
+
+	return Chunk(runType<<14 | length), nil //nolint:gosec // G115
+}
+
+// NewBitVectorChunk returns a bit vector Chunk holding the given 15
+// bits, the most significant of which describes the first packet.
+func NewBitVectorChunk(bits uint16) (Chunk, error) {
+	if bits > 0x7FFF {
+		return 0, errInvalidChunkValue
+	}
+
+	return Chunk(1<<15 | bits), nil
+}
+
 // Type returns the ChunkType that this Chunk represents.
 func (c Chunk) Type() ChunkType {
 	if c == 0 {
@@ -286,8 +353,24 @@ func (b *PacketReceiptTimesReportBlock) setupBlockHeader() {
 	b.XRHeader.BlockLength = uint16(wireSize(b)/4 - 1) //nolint:gosec // G115
 }
 
-func (b *PacketReceiptTimesReportBlock) unpackBlockHeader() {
+func (b *PacketReceiptTimesReportBlock) unpackBlockHeader() error {
 	b.T = uint8(b.XRHeader.TypeSpecific) & 0x0F
+	if len(b.ReceiptTime) != b.receiptTimeCount() {
+		return errInvalidReceiptTimes
+	}
+
+	return nil
+}
+
+// receiptTimeCount returns the number of receipt times expected for the
+// sequence number range. With thinning, only packets whose sequence
+// number is 0 mod 2^T are reported.
+func (b *PacketReceiptTimesReportBlock) receiptTimeCount() int {
+	step := 1 << (b.T & 0x0F)
+	begin := int(b.BeginSeq)
+	end := begin + int(b.EndSeq-b.BeginSeq)
+
+	return (end+step-1)/step - (begin+step-1)/step
 }
 
 // ReceiverReferenceTimeReportBlock encodes a Receiver Reference Time
@@ -314,13 +397,24 @@ func (b *ReceiverReferenceTimeReportBlock) DestinationSSRC() []uint32 {
 	return []uint32{}
 }
 
+// SetTime sets the NTP timestamp of the report block from t.
+func (b *ReceiverReferenceTimeReportBlock) SetTime(t time.Time) {
+	b.NTPTimestamp = toNTPTime(t)
+}
+
+// Time returns the NTP timestamp of the report block as a time.Time.
+func (b *ReceiverReferenceTimeReportBlock) Time() time.Time {
+	return fromNTPTime(b.NTPTimestamp)
+}
+
 func (b *ReceiverReferenceTimeReportBlock) setupBlockHeader() {
 	b.XRHeader.BlockType = ReceiverReferenceTimeReportBlockType
 	b.XRHeader.TypeSpecific = 0
 	b.XRHeader.BlockLength = uint16(wireSize(b)/4 - 1) //nolint:gosec // G115
 }
 
-func (b *ReceiverReferenceTimeReportBlock) unpackBlockHeader() {
+func (b *ReceiverReferenceTimeReportBlock) unpackBlockHeader() error {
+	return nil
 }
 
 // DLRRReportBlock encodes a DLRR Report Block as described in
@@ -371,7 +465,8 @@ func (b *DLRRReportBlock) setupBlockHeader() {
 	b.XRHeader.BlockLength = uint16(wireSize(b)/4 - 1) //nolint:gosec // G115
 }
 
-func (b *DLRRReportBlock) unpackBlockHeader() {
+func (b *DLRRReportBlock) unpackBlockHeader() error {
+	return nil
 }
 
 // StatisticsSummaryReportBlock encodes a Statistics Summary Report
@@ -468,11 +563,28 @@ func (b *StatisticsSummaryReportBlock) setupBlockHeader() {
 	b.XRHeader.BlockLength = uint16(wireSize(b)/4 - 1) //nolint:gosec // G115
 }
 
-func (b *StatisticsSummaryReportBlock) unpackBlockHeader() {
+func (b *StatisticsSummaryReportBlock) unpackBlockHeader() error {
 	b.LossReports = b.XRHeader.TypeSpecific&0x80 != 0
 	b.DuplicateReports = b.XRHeader.TypeSpecific&0x40 != 0
 	b.JitterReports = b.XRHeader.TypeSpecific&0x20 != 0
 	b.TTLorHopLimit = TTLorHopLimitType((b.XRHeader.TypeSpecific & 0x18) >> 3)
+
+	// Fields whose flag is unset carry no information, so drop
+	// whatever the sender left in them.
+	if !b.LossReports {
+		b.LostPackets = 0
+	}
+	if !b.DuplicateReports {
+		b.DupPackets = 0
+	}
+	if !b.JitterReports {
+		b.MinJitter, b.MaxJitter, b.MeanJitter, b.DevJitter = 0, 0, 0, 0
+	}
+	if b.TTLorHopLimit == ToHMissing {
+		b.MinTTLOrHL, b.MaxTTLOrHL, b.MeanTTLOrHL, b.DevTTLOrHL = 0, 0, 0, 0
+	}
+
+	return nil
 }
 
 // VoIPMetricsReportBlock encodes a VoIP Metrics Report Block as described
@@ -532,13 +644,38 @@ func (b *VoIPMetricsReportBlock) DestinationSSRC() []uint32 {
 	return []uint32{b.SSRC}
 }
 
+// MOSLQScore returns the listening quality MOS as a value between 1.0
+// and 5.0. The second return value is false if the sender marked the
+// metric as unavailable or the encoded value is out of range.
+func (b *VoIPMetricsReportBlock) MOSLQScore() (float64, bool) {
+	return mosScore(b.MOSLQ)
+}
+
+// MOSCQScore returns the conversational quality MOS as a value between
+// 1.0 and 5.0. The second return value is false if the sender marked the
+// metric as unavailable or the encoded value is out of range.
+func (b *VoIPMetricsReportBlock) MOSCQScore() (float64, bool) {
+	return mosScore(b.MOSCQ)
+}
+
+// mosScore decodes a MOS byte, which RFC 3611 section 4.7.5 defines as
+// the score multiplied by 10, with 127 meaning unavailable.
+func mosScore(v uint8) (float64, bool) {
+	if v < 10 || v > 50 {
+		return 0, false
+	}
+
+	return float64(v) / 10, true
+}
+
 func (b *VoIPMetricsReportBlock) setupBlockHeader() {
 	b.XRHeader.BlockType = VoIPMetricsReportBlockType
 	b.XRHeader.TypeSpecific = 0
 	b.XRHeader.BlockLength = uint16(wireSize(b)/4 - 1) //nolint:gosec // G115
 }
 
-func (b *VoIPMetricsReportBlock) unpackBlockHeader() {
+func (b *VoIPMetricsReportBlock) unpackBlockHeader() error {
+	return nil
 }
 
 // UnknownReportBlock is used to store bytes for any report block
@@ -557,66 +694,101 @@ func (b *UnknownReportBlock) setupBlockHeader() {
 	b.XRHeader.BlockLength = uint16(wireSize(b)/4 - 1) //nolint:gosec // G115
 }
 
-func (b *UnknownReportBlock) unpackBlockHeader() {
+func (b *UnknownReportBlock) unpackBlockHeader() error {
+	return nil
 }
 
-// MarshalSize returns the size of the packet once marshaled.
+// MarshalSize returns the size of the packet once marshaled. It also
+// brings the report block headers up to date, since their contents
+// determine the size.
 func (x ExtendedReport) MarshalSize() int {
-	return wireSize(x)
+	for _, p := range x.Reports {
+		p.setupBlockHeader()
+	}
+
+	return headerLength + wireSize(x) + int(x.padding)
 }
 
 // Marshal encodes the ExtendedReport in binary.
 func (x ExtendedReport) Marshal() ([]byte, error) {
-	for _, p := range x.Reports {
-		p.setupBlockHeader()
+	rawPacket := make([]byte, x.MarshalSize())
+	if _, err := x.MarshalTo(rawPacket); err != nil {
+		return []byte{}, err
 	}
 
-	length := wireSize(x)
+	return rawPacket, nil
+}
+
+// MarshalAppend appends the packet in binary to dst and returns the
+// extended slice.
+func (x ExtendedReport) MarshalAppend(dst []byte) ([]byte, error) {
+	return marshalAppend(dst, x.MarshalSize(), x.MarshalTo)
+}
+
+// MarshalTo encodes the ExtendedReport in binary into buf and returns the number of bytes written.
+func (x ExtendedReport) MarshalTo(buf []byte) (int, error) {
+	length := x.MarshalSize()
+	if len(buf) < length {
+		return 0, ErrPacketTooShort
+	}
 
 	// RTCP Header
 	header := Header{
-		Type:   TypeExtendedReport,
-		Length: uint16(length / 4), //nolint:gosec // G115
+		Padding: x.padding != 0,
+		Type:    TypeExtendedReport,
 	}
-	headerBuffer, err := header.Marshal()
-	if err != nil {
-		return []byte{}, err
+	if err := header.SetLengthFromBytes(length); err != nil {
+		return 0, err
+	}
+	if _, err := header.MarshalTo(buf); err != nil {
+		return 0, err
 	}
-	length += len(headerBuffer)
-
-	rawPacket := make([]byte, length)
-	buffer := packetBuffer{bytes: rawPacket}
 
-	err = buffer.write(headerBuffer)
-	if err != nil {
-		return []byte{}, err
+	// unexported fields are skipped rather than written, so clear them first
+	body := buf[headerLength : length-int(x.padding)]
+	for i := range body {
+		body[i] = 0
 	}
-	err = buffer.write(x)
-	if err != nil {
-		return []byte{}, err
+
+	buffer := packetBuffer{bytes: body}
+	if err := buffer.write(x); err != nil {
+		return 0, err
 	}
 
-	return rawPacket, nil
+	writePadding(buf[:length], x.padding)
+
+	return length, nil
 }
 
 // Unmarshal decodes the ExtendedReport from binary.
 //
 //nolint:cyclop
 func (x *ExtendedReport) Unmarshal(b []byte) error {
+	b, padding, err := removePadding(b)
+	if err != nil {
+		return err
+	}
+
 	var header Header
 	if err := header.Unmarshal(b); err != nil {
 		return err
 	}
 	if header.Type != TypeExtendedReport {
-		return errWrongType
+		return ErrWrongType
 	}
 
-	buffer := packetBuffer{bytes: b[headerLength:]}
-	err := buffer.read(&x.SenderSSRC)
+	end := (int(header.Length) + 1) * 4
+	if len(b) < end {
+		return ErrPacketTooShort
+	}
+
+	buffer := packetBuffer{bytes: b[headerLength:end]}
+	err = buffer.read(&x.SenderSSRC)
 	if err != nil {
 		return err
 	}
 
+	x.Reports = nil
 	for len(buffer.bytes) > 0 {
 		var block ReportBlock
 
@@ -627,22 +799,9 @@ func (x *ExtendedReport) Unmarshal(b []byte) error {
 			return err
 		}
 
-		switch xrHeader.BlockType {
-		case LossRLEReportBlockType:
-			block = new(LossRLEReportBlock)
-		case DuplicateRLEReportBlockType:
-			block = new(DuplicateRLEReportBlock)
-		case PacketReceiptTimesReportBlockType:
-			block = new(PacketReceiptTimesReportBlock)
-		case ReceiverReferenceTimeReportBlockType:
-			block = new(ReceiverReferenceTimeReportBlock)
-		case DLRRReportBlockType:
-			block = new(DLRRReportBlock)
-		case StatisticsSummaryReportBlockType:
-			block = new(StatisticsSummaryReportBlock)
-		case VoIPMetricsReportBlockType:
-			block = new(VoIPMetricsReportBlock)
-		default:
+		if newBlock, ok := reportBlockTypes[xrHeader.BlockType]; ok {
+			block = newBlock()
+		} else {
 			block = new(UnknownReportBlock)
 		}
 
@@ -654,13 +813,28 @@ func (x *ExtendedReport) Unmarshal(b []byte) error {
 		if err != nil {
 			return err
 		}
-		block.unpackBlockHeader()
+		if err = block.unpackBlockHeader(); err != nil {
+			return err
+		}
 		x.Reports = append(x.Reports, block)
 	}
 
+	x.padding = padding
+
 	return nil
 }
 
+// Validate checks that the ExtendedReport can be sent: that its report blocks
+// fit in a packet.
+func (x *ExtendedReport) Validate() error {
+	return checkPacketSize(x.MarshalSize())
+}
+
+// Type returns the packet type, TypeExtendedReport.
+func (x *ExtendedReport) Type() PacketType {
+	return TypeExtendedReport
+}
+
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
 func (x *ExtendedReport) DestinationSSRC() []uint32 {
 	ssrc := make([]uint32, 0, len(x.Reports)+1)
@@ -672,6 +846,189 @@ func (x *ExtendedReport) DestinationSSRC() []uint32 {
 	return ssrc
 }
 
+// SourceSSRC returns the SSRC of the sender.
+func (x *ExtendedReport) SourceSSRC() []uint32 {
+	return []uint32{x.SenderSSRC}
+}
+
+// RewriteSSRC replaces every SSRC in the packet that equals from with to,
+// including those of its report blocks, and returns the number of SSRCs
+// replaced.
+func (x *ExtendedReport) RewriteSSRC(from, to uint32) int {
+	n := rewriteSSRC(&x.SenderSSRC, from, to)
+	for _, block := range x.Reports {
+		n += rewriteReportBlockSSRC(block, from, to)
+	}
+
+	return n
+}
+
+// Equal reports whether other is an ExtendedReport with the same report
+// blocks. Block headers derived during marshaling are not compared.
+func (x *ExtendedReport) Equal(other Packet) bool {
+	o, ok := other.(*ExtendedReport)
+	if !ok || o == nil || x.SenderSSRC != o.SenderSSRC || len(x.Reports) != len(o.Reports) {
+		return false
+	}
+
+	for i := range x.Reports {
+		if !reportBlocksEqual(x.Reports[i], o.Reports[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Clone returns a deep copy of the packet that shares no memory with x.
+func (x *ExtendedReport) Clone() Packet {
+	c := *x
+	c.Reports = cloneSlice(x.Reports)
+	for i, block := range c.Reports {
+		c.Reports[i] = cloneReportBlock(block)
+	}
+
+	return &c
+}
+
+// MarshalJSON encodes the packet as a JSON object tagged with its type.
+func (x ExtendedReport) MarshalJSON() ([]byte, error) {
+	return marshalJSON(x)
+}
+
+// UnmarshalJSON decodes a JSON object produced by MarshalJSON.
+func (x *ExtendedReport) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, x)
+}
+
+// cloneReportBlock returns a deep copy of a known report block. Blocks of
+// other types are returned unchanged.
+func cloneReportBlock(block ReportBlock) ReportBlock {
+	switch b := block.(type) {
+	case *LossRLEReportBlock:
+		c := *b
+		c.Chunks = cloneSlice(b.Chunks)
+
+		return &c
+	case *DuplicateRLEReportBlock:
+		c := *b
+		c.Chunks = cloneSlice(b.Chunks)
+
+		return &c
+	case *PacketReceiptTimesReportBlock:
+		c := *b
+		c.ReceiptTime = cloneSlice(b.ReceiptTime)
+
+		return &c
+	case *ReceiverReferenceTimeReportBlock:
+		c := *b
+
+		return &c
+	case *DLRRReportBlock:
+		c := *b
+		c.Reports = cloneSlice(b.Reports)
+
+		return &c
+	case *StatisticsSummaryReportBlock:
+		c := *b
+
+		return &c
+	case *VoIPMetricsReportBlock:
+		c := *b
+
+		return &c
+	case *UnknownReportBlock:
+		c := *b
+		c.Bytes = cloneSlice(b.Bytes)
+
+		return &c
+	}
+
+	return block
+}
+
+// rewriteReportBlockSSRC replaces the SSRCs of a known report block that
+// equal from with to. Blocks of other types are left unchanged.
+func rewriteReportBlockSSRC(block ReportBlock, from, to uint32) int {
+	switch b := block.(type) {
+	case *LossRLEReportBlock:
+		return rewriteSSRC(&b.SSRC, from, to)
+	case *DuplicateRLEReportBlock:
+		return rewriteSSRC(&b.SSRC, from, to)
+	case *PacketReceiptTimesReportBlock:
+		return rewriteSSRC(&b.SSRC, from, to)
+	case *DLRRReportBlock:
+		n := 0
+		for i := range b.Reports {
+			n += rewriteSSRC(&b.Reports[i].SSRC, from, to)
+		}
+
+		return n
+	case *StatisticsSummaryReportBlock:
+		return rewriteSSRC(&b.SSRC, from, to)
+	case *VoIPMetricsReportBlock:
+		return rewriteSSRC(&b.SSRC, from, to)
+	}
+
+	return 0
+}
+
+//nolint:cyclop
+func reportBlocksEqual(a, b ReportBlock) bool {
+	switch a := a.(type) {
+	case *LossRLEReportBlock:
+		b, ok := b.(*LossRLEReportBlock)
+
+		return ok && (*rleReportBlock)(a).equal((*rleReportBlock)(b))
+	case *DuplicateRLEReportBlock:
+		b, ok := b.(*DuplicateRLEReportBlock)
+
+		return ok && (*rleReportBlock)(a).equal((*rleReportBlock)(b))
+	case *PacketReceiptTimesReportBlock:
+		b, ok := b.(*PacketReceiptTimesReportBlock)
+
+		return ok && a.T == b.T && a.SSRC == b.SSRC && a.BeginSeq == b.BeginSeq && a.EndSeq == b.EndSeq &&
+			slicesEqual(a.ReceiptTime, b.ReceiptTime)
+	case *ReceiverReferenceTimeReportBlock:
+		b, ok := b.(*ReceiverReferenceTimeReportBlock)
+
+		return ok && a.NTPTimestamp == b.NTPTimestamp
+	case *DLRRReportBlock:
+		b, ok := b.(*DLRRReportBlock)
+
+		return ok && slicesEqual(a.Reports, b.Reports)
+	case *StatisticsSummaryReportBlock:
+		b, ok := b.(*StatisticsSummaryReportBlock)
+		if !ok {
+			return false
+		}
+		x, y := *a, *b
+		x.XRHeader, y.XRHeader = XRHeader{}, XRHeader{}
+
+		return x == y
+	case *VoIPMetricsReportBlock:
+		b, ok := b.(*VoIPMetricsReportBlock)
+		if !ok {
+			return false
+		}
+		x, y := *a, *b
+		x.XRHeader, y.XRHeader = XRHeader{}, XRHeader{}
+
+		return x == y
+	case *UnknownReportBlock:
+		b, ok := b.(*UnknownReportBlock)
+
+		return ok && a.BlockType == b.BlockType && a.TypeSpecific == b.TypeSpecific && bytes.Equal(a.Bytes, b.Bytes)
+	}
+
+	return a == b
+}
+
+func (b *rleReportBlock) equal(o *rleReportBlock) bool {
+	return b.T == o.T && b.SSRC == o.SSRC && b.BeginSeq == o.BeginSeq && b.EndSeq == o.EndSeq &&
+		slicesEqual(b.Chunks, o.Chunks)
+}
+
 func (x *ExtendedReport) String() string {
 	return stringify(x)
 }
@@ -6,6 +6,7 @@ package rtcp
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -66,7 +67,7 @@ func testPacket() Packet {
 				T:        3,
 				SSRC:     0x98765432,
 				BeginSeq: 15432,
-				EndSeq:   15577,
+				EndSeq:   15472,
 				ReceiptTime: []uint32{
 					0x11111111,
 					0x22222222,
@@ -162,7 +163,7 @@ func encodedPacket() []byte {
 		// Source SSRC
 		0x98, 0x76, 0x54, 0x32,
 		// Begin & End Seq
-		0x3C, 0x48, 0x3C, 0xD9, // byte 56 - 59
+		0x3C, 0x48, 0x3C, 0x70, // byte 56 - 59
 		// Receipt times
 		0x11, 0x11, 0x11, 0x11,
 		0x22, 0x22, 0x22, 0x22, // byte 64 - 67
@@ -264,3 +265,431 @@ func TestDecode(t *testing.T) {
 	}
 	assert.True(t, includeSenderSSRC, "DestinationSSRC does not include the SenderSSRC")
 }
+
+func TestDecodeUnknownReportBlock(t *testing.T) {
+	encoded := []byte{
+		// RTP Header
+		0x80, 0xCF, 0x00, 0x06,
+		// Sender SSRC
+		0x01, 0x02, 0x03, 0x04,
+		// Unknown Report Block (BT=42)
+		0x2A, 0x5A, 0x00, 0x01,
+		0xDE, 0xAD, 0xBE, 0xEF,
+		// Receiver Reference Time Report
+		0x04, 0x00, 0x00, 0x02,
+		// Timestamp
+		0x01, 0x02, 0x03, 0x04,
+		0x05, 0x06, 0x07, 0x08,
+	}
+
+	packets, err := Unmarshal(encoded)
+	assert.NoError(t, err)
+	if !assert.Len(t, packets, 1) {
+		return
+	}
+
+	report, ok := packets[0].(*ExtendedReport)
+	assert.True(t, ok)
+	assert.Equal(t, &ExtendedReport{
+		SenderSSRC: 0x01020304,
+		Reports: []ReportBlock{
+			&UnknownReportBlock{
+				XRHeader: XRHeader{
+					BlockType:    42,
+					TypeSpecific: 0x5A,
+					BlockLength:  1,
+				},
+				Bytes: []byte{0xDE, 0xAD, 0xBE, 0xEF},
+			},
+			&ReceiverReferenceTimeReportBlock{
+				XRHeader: XRHeader{
+					BlockType:   ReceiverReferenceTimeReportBlockType,
+					BlockLength: 2,
+				},
+				NTPTimestamp: 0x0102030405060708,
+			},
+		},
+	}, report)
+
+	rawPacket, err := report.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, encoded, rawPacket)
+
+	// Decoding again into the same value replaces the previous blocks.
+	assert.NoError(t, report.Unmarshal(encoded))
+	assert.Len(t, report.Reports, 2)
+}
+
+func TestUnknownReportBlockWithVoIPMetrics(t *testing.T) {
+	report := &ExtendedReport{
+		SenderSSRC: 0x01020304,
+		Reports: []ReportBlock{
+			&VoIPMetricsReportBlock{SSRC: 0x89ABCDEF, LossRate: 5, MOSLQ: 40, MOSCQ: 41},
+			&UnknownReportBlock{
+				XRHeader: XRHeader{BlockType: 200, TypeSpecific: 0x5A},
+				Bytes:    []byte{0xDE, 0xAD, 0xBE, 0xEF, 0x01, 0x02, 0x03, 0x04},
+			},
+			&VoIPMetricsReportBlock{SSRC: 0x01020304, MOSLQ: 30},
+		},
+	}
+	encoded, err := report.Marshal()
+	assert.NoError(t, err)
+
+	packets, err := Unmarshal(encoded)
+	assert.NoError(t, err)
+	assert.True(t, PacketsEqual([]Packet{report}, packets))
+
+	decoded, ok := packets[0].(*ExtendedReport)
+	assert.True(t, ok)
+	unknown, ok := decoded.Reports[1].(*UnknownReportBlock)
+	assert.True(t, ok)
+	assert.Equal(t, BlockTypeType(200), unknown.BlockType)
+	assert.Equal(t, uint16(2), unknown.BlockLength)
+
+	rawPacket, err := decoded.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, encoded, rawPacket)
+}
+
+func TestNewChunk(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Chunk     func() (Chunk, error)
+		Want      Chunk
+		WantError error
+	}{
+		{
+			Name:  "received run",
+			Chunk: func() (Chunk, error) { return NewRunLengthChunk(1, 6) },
+			Want:  Chunk(0x4006),
+		},
+		{
+			Name:  "lost run",
+			Chunk: func() (Chunk, error) { return NewRunLengthChunk(0, 0x3FFF) },
+			Want:  Chunk(0x3FFF),
+		},
+		{
+			Name:      "bad run type",
+			Chunk:     func() (Chunk, error) { return NewRunLengthChunk(2, 6) },
+			WantError: errInvalidChunkValue,
+		},
+		{
+			Name:      "empty run",
+			Chunk:     func() (Chunk, error) { return NewRunLengthChunk(0, 0) },
+			WantError: errInvalidChunkValue,
+		},
+		{
+			Name:      "run too long",
+			Chunk:     func() (Chunk, error) { return NewRunLengthChunk(1, 0x4000) },
+			WantError: errInvalidChunkValue,
+		},
+		{
+			Name:  "bit vector",
+			Chunk: func() (Chunk, error) { return NewBitVectorChunk(0x0765) },
+			Want:  Chunk(0x8765),
+		},
+		{
+			Name:      "bit vector too wide",
+			Chunk:     func() (Chunk, error) { return NewBitVectorChunk(0x8000) },
+			WantError: errInvalidChunkValue,
+		},
+	} {
+		chunk, err := test.Chunk()
+		assert.ErrorIsf(t, err, test.WantError, "NewChunk %q", test.Name)
+		if err != nil {
+			continue
+		}
+		assert.Equalf(t, test.Want, chunk, "NewChunk %q", test.Name)
+	}
+}
+
+func TestLossRLEOddChunks(t *testing.T) {
+	report := ExtendedReport{
+		SenderSSRC: 0x01020304,
+		Reports: []ReportBlock{
+			&LossRLEReportBlock{
+				T:        3,
+				SSRC:     0x12345689,
+				BeginSeq: 5,
+				EndSeq:   12,
+				Chunks:   []Chunk{Chunk(0x4006)},
+			},
+		},
+	}
+
+	rawPacket, err := report.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{
+		0x80, 0xCF, 0x00, 0x05,
+		0x01, 0x02, 0x03, 0x04,
+		0x01, 0x03, 0x00, 0x03,
+		0x12, 0x34, 0x56, 0x89,
+		0x00, 0x05, 0x00, 0x0C,
+		// run length chunk followed by a terminating null chunk
+		0x40, 0x06, 0x00, 0x00,
+	}, rawPacket)
+
+	// The null chunk is only written, but is read back like any other.
+	var decoded ExtendedReport
+	assert.NoError(t, decoded.Unmarshal(rawPacket))
+	assert.Equal(t, []Chunk{Chunk(0x4006), Chunk(0)}, decoded.Reports[0].(*LossRLEReportBlock).Chunks) //nolint:forcetypeassert
+	remarshaled, err := decoded.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, rawPacket, remarshaled)
+}
+
+func TestRLEChunksUnchangedByMarshal(t *testing.T) {
+	backing := []Chunk{Chunk(0x4006), Chunk(0x1234)}
+	loss := &LossRLEReportBlock{Chunks: backing[:1]}
+	duplicate := &DuplicateRLEReportBlock{Chunks: []Chunk{Chunk(0x4123)}}
+	report := ExtendedReport{Reports: []ReportBlock{loss, duplicate}}
+
+	assert.Equal(t, headerLength+4+2*16, report.MarshalSize())
+	_, err := report.Marshal()
+	assert.NoError(t, err)
+
+	assert.Equal(t, []Chunk{Chunk(0x4006)}, loss.Chunks)
+	assert.Equal(t, []Chunk{Chunk(0x4123)}, duplicate.Chunks)
+	assert.Equal(t, Chunk(0x1234), backing[1], "shared backing array is not written")
+}
+
+func TestDuplicateRLERoundTrip(t *testing.T) {
+	report := ExtendedReport{
+		SenderSSRC: 0x01020304,
+		Reports: []ReportBlock{
+			&DuplicateRLEReportBlock{
+				T:        6,
+				SSRC:     0x12345689,
+				BeginSeq: 5,
+				EndSeq:   12,
+				Chunks:   []Chunk{Chunk(0x4123), Chunk(0x3FFF), Chunk(0xFFFF)},
+			},
+		},
+	}
+
+	rawPacket, err := report.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{
+		0x80, 0xCF, 0x00, 0x06,
+		0x01, 0x02, 0x03, 0x04,
+		0x02, 0x06, 0x00, 0x04,
+		0x12, 0x34, 0x56, 0x89,
+		0x00, 0x05, 0x00, 0x0C,
+		0x41, 0x23, 0x3F, 0xFF,
+		0xFF, 0xFF, 0x00, 0x00,
+	}, rawPacket)
+
+	var decoded ExtendedReport
+	assert.NoError(t, decoded.Unmarshal(rawPacket))
+	assert.Equal(t, []Chunk{Chunk(0x4123), Chunk(0x3FFF), Chunk(0xFFFF), Chunk(0)},
+		decoded.Reports[0].(*DuplicateRLEReportBlock).Chunks) //nolint:forcetypeassert
+	remarshaled, err := decoded.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, rawPacket, remarshaled)
+}
+
+func TestPacketReceiptTimesValidation(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Block     PacketReceiptTimesReportBlock
+		WantError error
+	}{
+		{
+			Name: "no thinning",
+			Block: PacketReceiptTimesReportBlock{
+				SSRC: 0x98765432, BeginSeq: 10, EndSeq: 13,
+				ReceiptTime: []uint32{1, 2, 3},
+			},
+		},
+		{
+			Name: "thinning",
+			Block: PacketReceiptTimesReportBlock{
+				T: 2, SSRC: 0x98765432, BeginSeq: 3, EndSeq: 13,
+				ReceiptTime: []uint32{4, 8, 12},
+			},
+		},
+		{
+			Name: "sequence wrap",
+			Block: PacketReceiptTimesReportBlock{
+				T: 1, SSRC: 0x98765432, BeginSeq: 0xFFFE, EndSeq: 2,
+				ReceiptTime: []uint32{0xFFFE, 0},
+			},
+		},
+		{
+			Name: "empty range",
+			Block: PacketReceiptTimesReportBlock{
+				SSRC: 0x98765432, BeginSeq: 7, EndSeq: 7,
+			},
+		},
+		{
+			Name: "too many receipt times",
+			Block: PacketReceiptTimesReportBlock{
+				T: 2, SSRC: 0x98765432, BeginSeq: 3, EndSeq: 13,
+				ReceiptTime: []uint32{4, 8, 12, 16},
+			},
+			WantError: errInvalidReceiptTimes,
+		},
+		{
+			Name: "too few receipt times",
+			Block: PacketReceiptTimesReportBlock{
+				SSRC: 0x98765432, BeginSeq: 10, EndSeq: 13,
+				ReceiptTime: []uint32{1},
+			},
+			WantError: errInvalidReceiptTimes,
+		},
+	} {
+		block := test.Block
+		report := ExtendedReport{SenderSSRC: 1, Reports: []ReportBlock{&block}}
+		rawPacket, err := report.Marshal()
+		assert.NoErrorf(t, err, "Marshal %q", test.Name)
+
+		var decoded ExtendedReport
+		err = decoded.Unmarshal(rawPacket)
+		assert.ErrorIsf(t, err, test.WantError, "Unmarshal %q", test.Name)
+		if err != nil {
+			continue
+		}
+		assert.Equalf(t, report, decoded, "Unmarshal %q", test.Name)
+	}
+}
+
+func TestReceiverReferenceTimeTime(t *testing.T) {
+	now := time.Unix(1700000000, 500000000)
+
+	var block ReceiverReferenceTimeReportBlock
+	block.SetTime(now)
+	assert.Equal(t, uint64(0xE8FE6F80_80000000), block.NTPTimestamp)
+	assert.True(t, now.Equal(block.Time()))
+}
+
+func TestDLRRReportBlock(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Data      []byte
+		Want      []uint32
+		WantError error
+	}{
+		{
+			Name: "two sub-blocks",
+			Data: []byte{
+				0x80, 0xCF, 0x00, 0x08,
+				0x01, 0x02, 0x03, 0x04,
+				0x05, 0x00, 0x00, 0x06,
+				0x88, 0x88, 0x88, 0x88,
+				0x12, 0x34, 0x56, 0x78,
+				0x99, 0x99, 0x99, 0x99,
+				0x09, 0x09, 0x09, 0x09,
+				0x12, 0x34, 0x56, 0x78,
+				0x99, 0x99, 0x99, 0x99,
+			},
+			Want: []uint32{0x01020304, 0x88888888, 0x09090909},
+		},
+		{
+			Name: "empty",
+			Data: []byte{
+				0x80, 0xCF, 0x00, 0x02,
+				0x01, 0x02, 0x03, 0x04,
+				0x05, 0x00, 0x00, 0x00,
+			},
+			Want: []uint32{0x01020304},
+		},
+		{
+			Name: "partial sub-block",
+			Data: []byte{
+				0x80, 0xCF, 0x00, 0x04,
+				0x01, 0x02, 0x03, 0x04,
+				0x05, 0x00, 0x00, 0x02,
+				0x88, 0x88, 0x88, 0x88,
+				0x12, 0x34, 0x56, 0x78,
+			},
+			WantError: errWrongMarshalSize,
+		},
+	} {
+		var report ExtendedReport
+		err := report.Unmarshal(test.Data)
+		assert.ErrorIsf(t, err, test.WantError, "Unmarshal %q", test.Name)
+		if err != nil {
+			continue
+		}
+		assert.Equalf(t, test.Want, report.DestinationSSRC(), "DestinationSSRC %q", test.Name)
+
+		rawPacket, err := report.Marshal()
+		assert.NoErrorf(t, err, "Marshal %q", test.Name)
+		assert.Equalf(t, test.Data, rawPacket, "Marshal %q", test.Name)
+	}
+}
+
+func TestStatisticsSummaryFlags(t *testing.T) {
+	encoded := []byte{
+		0x80, 0xCF, 0x00, 0x0B,
+		0x01, 0x02, 0x03, 0x04,
+		// L=1, D=0, J=0, ToH=IPv6
+		0x06, 0x90, 0x00, 0x09,
+		0xFE, 0xDC, 0xBA, 0x98,
+		0x12, 0x34, 0x56, 0x78,
+		0x11, 0x11, 0x11, 0x11,
+		0x22, 0x22, 0x22, 0x22,
+		0x33, 0x33, 0x33, 0x33,
+		0x44, 0x44, 0x44, 0x44,
+		0x55, 0x55, 0x55, 0x55,
+		0x66, 0x66, 0x66, 0x66,
+		0x01, 0x02, 0x03, 0x04,
+	}
+
+	var report ExtendedReport
+	assert.NoError(t, report.Unmarshal(encoded))
+	if !assert.Len(t, report.Reports, 1) {
+		return
+	}
+
+	block, ok := report.Reports[0].(*StatisticsSummaryReportBlock)
+	assert.True(t, ok)
+	assert.Equal(t, &StatisticsSummaryReportBlock{
+		XRHeader: XRHeader{
+			BlockType:    StatisticsSummaryReportBlockType,
+			TypeSpecific: 0x90,
+			BlockLength:  9,
+		},
+		LossReports:   true,
+		TTLorHopLimit: ToHIPv6,
+		SSRC:          0xFEDCBA98,
+		BeginSeq:      0x1234,
+		EndSeq:        0x5678,
+		LostPackets:   0x11111111,
+		MinTTLOrHL:    0x01,
+		MaxTTLOrHL:    0x02,
+		MeanTTLOrHL:   0x03,
+		DevTTLOrHL:    0x04,
+	}, block)
+	assert.Equal(t, "[ToH = IPv6]", block.TTLorHopLimit.String())
+
+	rawPacket, err := report.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, encoded[:12], rawPacket[:12])
+}
+
+func TestVoIPMetricsMOSScore(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Value     uint8
+		Want      float64
+		WantValid bool
+	}{
+		{Name: "minimum", Value: 10, Want: 1.0, WantValid: true},
+		{Name: "typical", Value: 43, Want: 4.3, WantValid: true},
+		{Name: "maximum", Value: 50, Want: 5.0, WantValid: true},
+		{Name: "unavailable", Value: 127},
+		{Name: "too low", Value: 9},
+		{Name: "too high", Value: 51},
+	} {
+		block := VoIPMetricsReportBlock{MOSLQ: test.Value, MOSCQ: test.Value}
+
+		score, valid := block.MOSLQScore()
+		assert.Equalf(t, test.WantValid, valid, "MOSLQScore %q", test.Name)
+		assert.InDeltaf(t, test.Want, score, 1e-9, "MOSLQScore %q", test.Name)
+
+		score, valid = block.MOSCQScore()
+		assert.Equalf(t, test.WantValid, valid, "MOSCQScore %q", test.Name)
+		assert.InDeltaf(t, test.Want, score, 1e-9, "MOSCQScore %q", test.Name)
+	}
+}
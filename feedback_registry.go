@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"fmt"
+	"sync"
+)
+
+type feedbackKey struct {
+	typ    PacketType
+	format uint8
+}
+
+var feedbackRegistry = struct { //nolint:gochecknoglobals
+	sync.RWMutex
+	factories map[feedbackKey]func() Packet
+}{factories: map[feedbackKey]func() Packet{}}
+
+func init() { //nolint:gochecknoinits
+	for _, f := range []struct {
+		typ     PacketType
+		format  uint8
+		factory func() Packet
+	}{
+		{TypeTransportSpecificFeedback, FormatTLN, func() Packet { return new(TransportLayerNack) }},
+		{TypeTransportSpecificFeedback, FormatTMMBR, func() Packet { return new(TemporaryMaximumMediaStreamBitrateRequest) }},
+		{TypeTransportSpecificFeedback, FormatTMMBN, func() Packet { return new(TemporaryMaximumMediaStreamBitrateNotification) }},
+		{TypeTransportSpecificFeedback, FormatRRR, func() Packet { return new(RapidResynchronizationRequest) }},
+		{TypeTransportSpecificFeedback, FormatECN, func() Packet { return new(ECNFeedback) }},
+		{TypeTransportSpecificFeedback, FormatTCC, func() Packet { return new(TransportLayerCC) }},
+		{TypeTransportSpecificFeedback, FormatCCFB, func() Packet { return new(CCFeedbackReport) }},
+		{TypePayloadSpecificFeedback, FormatPLI, func() Packet { return new(PictureLossIndication) }},
+		{TypePayloadSpecificFeedback, FormatSLI, func() Packet { return new(SliceLossIndication) }},
+		{TypePayloadSpecificFeedback, FormatRPSI, func() Packet { return new(ReferencePictureSelectionIndication) }},
+		{TypePayloadSpecificFeedback, FormatFIR, func() Packet { return new(FullIntraRequest) }},
+		{TypePayloadSpecificFeedback, FormatAFB, func() Packet { return new(ApplicationLayerFeedback) }},
+	} {
+		RegisterFeedback(f.typ, f.format, f.factory)
+	}
+}
+
+// RegisterFeedback makes Unmarshal decode transport or payload specific
+// feedback packets of type pt and feedback message type format into packets
+// returned by factory, so that feedback types this package does not know can
+// be parsed without forking it. Formats with no factory are unmarshaled as
+// GenericFeedback. A factory replaces any earlier one for the
+// same format, including the built-in one. REMB packets are recognized by
+// their identifier before the registered application layer feedback factory
+// is consulted.
+//
+// RegisterFeedback panics if pt is not a feedback packet type, format does not
+// fit in the header's count field, or factory is nil. It is safe to call
+// concurrently with Unmarshal, but is typically called from init.
+func RegisterFeedback(pt PacketType, format uint8, factory func() Packet) {
+	if !isFeedbackType(pt) {
+		panic(fmt.Sprintf("rtcp: RegisterFeedback for non-feedback packet type %v", pt))
+	}
+	if format > countMax {
+		panic(fmt.Sprintf("rtcp: RegisterFeedback for invalid format %d", format))
+	}
+	if factory == nil {
+		panic("rtcp: RegisterFeedback with nil factory")
+	}
+
+	feedbackRegistry.Lock()
+	defer feedbackRegistry.Unlock()
+	feedbackRegistry.factories[feedbackKey{pt, format}] = factory
+}
+
+// lookupFeedback returns the factory registered for the feedback format, or
+// nil if there is none.
+func lookupFeedback(pt PacketType, format uint8) func() Packet {
+	feedbackRegistry.RLock()
+	defer feedbackRegistry.RUnlock()
+
+	return feedbackRegistry.factories[feedbackKey{pt, format}]
+}
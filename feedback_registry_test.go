@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// vendorFeedback stands in for a feedback type defined outside the package.
+type vendorFeedback struct {
+	RawPacket
+}
+
+func TestRegisterFeedback(t *testing.T) {
+	const formatVendor = 7
+
+	data := []byte{
+		// v=2, p=0, FMT=7, PSFB, len=2
+		0x87, 0xce, 0x00, 0x02,
+		// sender=0x902f9e2e
+		0x90, 0x2f, 0x9e, 0x2e,
+		// media=0x902f9e2e
+		0x90, 0x2f, 0x9e, 0x2e,
+	}
+
+	packets, err := Unmarshal(data)
+	assert.NoError(t, err)
+	assert.IsType(t, &GenericFeedback{}, packets[0], "unregistered formats are generic")
+
+	RegisterFeedback(TypePayloadSpecificFeedback, formatVendor, func() Packet { return new(vendorFeedback) })
+	t.Cleanup(func() {
+		feedbackRegistry.Lock()
+		delete(feedbackRegistry.factories, feedbackKey{TypePayloadSpecificFeedback, formatVendor})
+		feedbackRegistry.Unlock()
+	})
+
+	packets, err = Unmarshal(data)
+	assert.NoError(t, err)
+	assert.Equal(t, []Packet{&vendorFeedback{RawPacket(data)}}, packets)
+
+	// The same format of the other feedback type is unaffected.
+	data[1] = byte(TypeTransportSpecificFeedback)
+	packets, err = Unmarshal(data)
+	assert.NoError(t, err)
+	assert.IsType(t, &GenericFeedback{}, packets[0])
+}
+
+func TestRegisterFeedbackInvalid(t *testing.T) {
+	factory := func() Packet { return new(RawPacket) }
+
+	assert.Panics(t, func() { RegisterFeedback(TypeReceiverReport, 1, factory) })
+	assert.Panics(t, func() { RegisterFeedback(TypePayloadSpecificFeedback, 32, factory) })
+	assert.Panics(t, func() { RegisterFeedback(TypePayloadSpecificFeedback, 7, nil) })
+}
+
+func TestRegisterFeedbackBuiltins(t *testing.T) {
+	for _, test := range []struct {
+		Type   PacketType
+		Format uint8
+		Want   Packet
+	}{
+		{TypeTransportSpecificFeedback, FormatTLN, &TransportLayerNack{}},
+		{TypeTransportSpecificFeedback, FormatTMMBR, &TemporaryMaximumMediaStreamBitrateRequest{}},
+		{TypeTransportSpecificFeedback, FormatTMMBN, &TemporaryMaximumMediaStreamBitrateNotification{}},
+		{TypeTransportSpecificFeedback, FormatRRR, &RapidResynchronizationRequest{}},
+		{TypeTransportSpecificFeedback, FormatECN, &ECNFeedback{}},
+		{TypeTransportSpecificFeedback, FormatTCC, &TransportLayerCC{}},
+		{TypeTransportSpecificFeedback, FormatCCFB, &CCFeedbackReport{}},
+		{TypePayloadSpecificFeedback, FormatPLI, &PictureLossIndication{}},
+		{TypePayloadSpecificFeedback, FormatSLI, &SliceLossIndication{}},
+		{TypePayloadSpecificFeedback, FormatRPSI, &ReferencePictureSelectionIndication{}},
+		{TypePayloadSpecificFeedback, FormatFIR, &FullIntraRequest{}},
+		{TypePayloadSpecificFeedback, FormatAFB, &ApplicationLayerFeedback{}},
+	} {
+		factory := lookupFeedback(test.Type, test.Format)
+		if assert.NotNilf(t, factory, "%v %s", test.Type, FormatString(test.Type, test.Format)) {
+			assert.IsType(t, test.Want, factory())
+		}
+	}
+}
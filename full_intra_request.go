@@ -22,36 +22,116 @@ type FullIntraRequest struct {
 	MediaSSRC  uint32
 
 	FIR []FIREntry
+
+	packetPadding
 }
 
 const (
-	firOffset = 8
+	firOffset      = 8
+	firEntryLength = 8
 )
 
 var _ Packet = (*FullIntraRequest)(nil)
 
+// NewFullIntraRequest creates a FullIntraRequest sent by sender carrying
+// entries. RFC 5104 asks for media to be 0, as the media sources are named
+// by the entries.
+func NewFullIntraRequest(sender, media uint32, entries ...FIREntry) *FullIntraRequest {
+	return &FullIntraRequest{
+		SenderSSRC: sender,
+		MediaSSRC:  media,
+		FIR:        entries,
+	}
+}
+
+// NewKeyframeRequest returns a request for a keyframe from the stream media,
+// sent by sender, which may be 0 for a receiver without an SSRC of its own.
+// It is a FullIntraRequest if fir is set, and otherwise a
+// PictureLossIndication. A FullIntraRequest carries sequenceNumber, which
+// must be incremented for each new request to the same source, as RFC 5104
+// Section 4.3.1 requires; it is ignored for a PictureLossIndication.
+func NewKeyframeRequest(sender, media uint32, fir bool, sequenceNumber uint8) Packet {
+	if fir {
+		return NewFullIntraRequest(sender, 0, FIREntry{SSRC: media, SequenceNumber: sequenceNumber})
+	}
+
+	return NewPictureLossIndication(sender, media)
+}
+
+// AddEntry requests an intra frame from ssrc. If the packet already has an
+// entry for ssrc its sequence number is incremented, as RFC 5104 requires
+// for each new request; otherwise an entry with sequence number 0 is added.
+// Reusing a FullIntraRequest for the requests to a set of sources therefore
+// keeps their sequence numbers correct.
+func (p *FullIntraRequest) AddEntry(ssrc uint32) {
+	for i := range p.FIR {
+		if p.FIR[i].SSRC == ssrc {
+			p.FIR[i].SequenceNumber++
+
+			return
+		}
+	}
+
+	p.FIR = append(p.FIR, FIREntry{SSRC: ssrc})
+}
+
 // Marshal encodes the FullIntraRequest.
 func (p FullIntraRequest) Marshal() ([]byte, error) {
-	rawPacket := make([]byte, firOffset+(len(p.FIR)*8))
-	binary.BigEndian.PutUint32(rawPacket, p.SenderSSRC)
-	binary.BigEndian.PutUint32(rawPacket[4:], p.MediaSSRC)
-	for i, fir := range p.FIR {
-		binary.BigEndian.PutUint32(rawPacket[firOffset+8*i:], fir.SSRC)
-		rawPacket[firOffset+8*i+4] = fir.SequenceNumber
-	}
-	h := p.Header()
-	hData, err := h.Marshal()
-	if err != nil {
+	rawPacket := make([]byte, p.MarshalSize())
+	if _, err := p.MarshalTo(rawPacket); err != nil {
 		return nil, err
 	}
 
-	return append(hData, rawPacket...), nil
+	return rawPacket, nil
+}
+
+// MarshalAppend appends the packet in binary to dst and returns the
+// extended slice.
+func (p FullIntraRequest) MarshalAppend(dst []byte) ([]byte, error) {
+	return marshalAppend(dst, p.MarshalSize(), p.MarshalTo)
+}
+
+// MarshalTo encodes the FullIntraRequest into buf and returns the number of bytes written.
+func (p FullIntraRequest) MarshalTo(buf []byte) (int, error) {
+	size := p.MarshalSize()
+	if len(buf) < size {
+		return 0, ErrPacketTooShort
+	}
+
+	header := p.Header()
+	if err := header.SetLengthFromBytes(size); err != nil {
+		return 0, err
+	}
+	if _, err := header.MarshalTo(buf); err != nil {
+		return 0, err
+	}
+
+	packetBody := buf[headerLength:size]
+
+	binary.BigEndian.PutUint32(packetBody, p.SenderSSRC)
+	binary.BigEndian.PutUint32(packetBody[4:], p.MediaSSRC)
+	for i, fir := range p.FIR {
+		entry := packetBody[firOffset+8*i:]
+		binary.BigEndian.PutUint32(entry, fir.SSRC)
+		entry[4] = fir.SequenceNumber
+		// reserved
+		entry[5], entry[6], entry[7] = 0, 0, 0
+	}
+
+	writePadding(buf[:size], p.padding)
+
+	return size, nil
 }
 
 // Unmarshal decodes the TransportLayerNack.
 func (p *FullIntraRequest) Unmarshal(rawPacket []byte) error {
+	rawPacket, padding, err := removePadding(rawPacket)
+	if err != nil {
+		return err
+	}
+
 	if len(rawPacket) < (headerLength + ssrcLength) {
-		return errPacketTooShort
+		return ErrPacketTooShort
 	}
 
 	var header Header
@@ -59,43 +139,57 @@ func (p *FullIntraRequest) Unmarshal(rawPacket []byte) error {
 		return err
 	}
 
-	if len(rawPacket) < (headerLength + int(4*header.Length)) {
-		return errPacketTooShort
+	if len(rawPacket) < (headerLength + 4*int(header.Length)) {
+		return ErrPacketTooShort
 	}
 
 	if header.Type != TypePayloadSpecificFeedback || header.Count != FormatFIR {
-		return errWrongType
+		return ErrWrongType
 	}
 
 	// The FCI field MUST contain one or more FIR entries
-	if 4*header.Length-firOffset <= 0 || (4*header.Length)%8 != 0 {
-		return errBadLength
+	if 4*int(header.Length) <= firOffset || (4*int(header.Length)-firOffset)%firEntryLength != 0 {
+		return ErrBadLength
 	}
 
+	end := headerLength + 4*int(header.Length)
+
 	p.SenderSSRC = binary.BigEndian.Uint32(rawPacket[headerLength:])
 	p.MediaSSRC = binary.BigEndian.Uint32(rawPacket[headerLength+ssrcLength:])
-	for i := headerLength + firOffset; i < (headerLength + int(header.Length*4)); i += 8 {
+	p.FIR = nil
+	for i := headerLength + firOffset; i < end; i += firEntryLength {
+		// Checked by the length validation above, but never index past the
+		// buffer on untrusted input.
+		if i+firEntryLength > len(rawPacket) {
+			return ErrPacketTooShort
+		}
+
 		p.FIR = append(p.FIR, FIREntry{
 			binary.BigEndian.Uint32(rawPacket[i:]),
 			rawPacket[i+4],
 		})
 	}
 
+	p.padding = padding
+
 	return nil
 }
 
 // Header returns the Header associated with this packet.
 func (p *FullIntraRequest) Header() Header {
-	return Header{
-		Count:  FormatFIR,
-		Type:   TypePayloadSpecificFeedback,
-		Length: uint16((p.MarshalSize() / 4) - 1), //nolint:gosec // G115
+	h := Header{
+		Padding: p.padding != 0,
+		Count:   FormatFIR,
+		Type:    TypePayloadSpecificFeedback,
 	}
+	_ = h.SetLengthFromBytes(p.MarshalSize())
+
+	return h
 }
 
 // MarshalSize returns the size of the packet once marshaled.
 func (p *FullIntraRequest) MarshalSize() int {
-	return headerLength + firOffset + len(p.FIR)*8
+	return headerLength + firOffset + len(p.FIR)*8 + int(p.padding)
 }
 
 func (p *FullIntraRequest) String() string {
@@ -108,6 +202,26 @@ func (p *FullIntraRequest) String() string {
 	return out
 }
 
+// Validate checks that the FullIntraRequest can be sent: RFC 5104 Section
+// 4.3.1.2 requires it to contain one or more FIR entries.
+func (p *FullIntraRequest) Validate() error {
+	if len(p.FIR) == 0 {
+		return errEmptyFCI
+	}
+
+	return checkPacketSize(p.MarshalSize())
+}
+
+// Type returns the packet type, TypePayloadSpecificFeedback.
+func (p *FullIntraRequest) Type() PacketType {
+	return TypePayloadSpecificFeedback
+}
+
+// Format returns the feedback message type, FormatFIR.
+func (p *FullIntraRequest) Format() uint8 {
+	return FormatFIR
+}
+
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
 func (p *FullIntraRequest) DestinationSSRC() []uint32 {
 	ssrcs := make([]uint32, 0, len(p.FIR))
@@ -117,3 +231,61 @@ func (p *FullIntraRequest) DestinationSSRC() []uint32 {
 
 	return ssrcs
 }
+
+// TargetsSSRC reports whether the packet is feedback about the media source
+// ssrc, the SSRC of one of its FIR entries.
+func (p *FullIntraRequest) TargetsSSRC(ssrc uint32) bool {
+	for _, entry := range p.FIR {
+		if entry.SSRC == ssrc {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SourceSSRC returns the SSRC of the sender.
+func (p *FullIntraRequest) SourceSSRC() []uint32 {
+	return []uint32{p.SenderSSRC}
+}
+
+// RewriteSSRC replaces every SSRC in the packet that equals from with to,
+// and returns the number of SSRCs replaced.
+func (p *FullIntraRequest) RewriteSSRC(from, to uint32) int {
+	n := rewriteSSRC(&p.SenderSSRC, from, to) + rewriteSSRC(&p.MediaSSRC, from, to)
+	for i := range p.FIR {
+		n += rewriteSSRC(&p.FIR[i].SSRC, from, to)
+	}
+
+	return n
+}
+
+// Equal reports whether other is a FullIntraRequest with the same contents.
+func (p *FullIntraRequest) Equal(other Packet) bool {
+	o, ok := other.(*FullIntraRequest)
+	if !ok || o == nil {
+		return false
+	}
+
+	return p.SenderSSRC == o.SenderSSRC &&
+		p.MediaSSRC == o.MediaSSRC &&
+		slicesEqual(p.FIR, o.FIR)
+}
+
+// Clone returns a deep copy of the packet that shares no memory with p.
+func (p *FullIntraRequest) Clone() Packet {
+	c := *p
+	c.FIR = cloneSlice(p.FIR)
+
+	return &c
+}
+
+// MarshalJSON encodes the packet as a JSON object tagged with its type.
+func (p FullIntraRequest) MarshalJSON() ([]byte, error) {
+	return marshalJSON(p)
+}
+
+// UnmarshalJSON decodes a JSON object produced by MarshalJSON.
+func (p *FullIntraRequest) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, p)
+}
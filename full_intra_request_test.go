@@ -79,7 +79,7 @@ func TestFullIntraRequestUnmarshal(t *testing.T) {
 			Data: []byte{
 				0x00, 0x00, 0x00, 0x00,
 			},
-			WantError: errPacketTooShort,
+			WantError: ErrPacketTooShort,
 		},
 		{
 			Name: "invalid header",
@@ -90,7 +90,7 @@ func TestFullIntraRequestUnmarshal(t *testing.T) {
 				0x00, 0x00, 0x00, 0x00,
 				0x00, 0x00, 0x00, 0x00,
 			},
-			WantError: errBadVersion,
+			WantError: ErrBadVersion,
 		},
 		{
 			Name: "wrong type",
@@ -106,7 +106,7 @@ func TestFullIntraRequestUnmarshal(t *testing.T) {
 				// Seqno=0x42
 				0x42, 0x00, 0x00, 0x00,
 			},
-			WantError: errWrongType,
+			WantError: ErrWrongType,
 		},
 		{
 			Name: "wrong fmt",
@@ -122,7 +122,7 @@ func TestFullIntraRequestUnmarshal(t *testing.T) {
 				// Seqno=0x42
 				0x42, 0x00, 0x00, 0x00,
 			},
-			WantError: errWrongType,
+			WantError: ErrWrongType,
 		},
 		{
 			Name: "wrong length",
@@ -136,7 +136,7 @@ func TestFullIntraRequestUnmarshal(t *testing.T) {
 				// ssrc=0x12345678
 				0x12, 0x34, 0x56, 0x78,
 			},
-			WantError: errBadLength,
+			WantError: ErrBadLength,
 		},
 	} {
 		var fir FullIntraRequest
@@ -229,3 +229,97 @@ func TestFullIntraRequestUnmarshalHeader(t *testing.T) {
 		assert.Equalf(t, test.Want, fir.Header(), "Unmarshal header %q rr mismatch", test.Name)
 	}
 }
+
+func TestNewFullIntraRequest(t *testing.T) {
+	fir := NewFullIntraRequest(0x902f9e2e, 0, FIREntry{SSRC: 0x4bc4fcb4, SequenceNumber: 3})
+	assert.Equal(t, &FullIntraRequest{
+		SenderSSRC: 0x902f9e2e,
+		FIR:        []FIREntry{{SSRC: 0x4bc4fcb4, SequenceNumber: 3}},
+	}, fir)
+
+	assert.Equal(t, &FullIntraRequest{SenderSSRC: 1, MediaSSRC: 2}, NewFullIntraRequest(1, 2))
+}
+
+func TestNewKeyframeRequest(t *testing.T) {
+	assert.Equal(t, &PictureLossIndication{SenderSSRC: 1, MediaSSRC: 0x4bc4fcb4}, NewKeyframeRequest(1, 0x4bc4fcb4, false, 7))
+	assert.Equal(t, &PictureLossIndication{MediaSSRC: 0x4bc4fcb4}, NewKeyframeRequest(0, 0x4bc4fcb4, false, 0))
+	assert.Equal(t, &FullIntraRequest{
+		SenderSSRC: 1,
+		FIR:        []FIREntry{{SSRC: 0x4bc4fcb4, SequenceNumber: 7}},
+	}, NewKeyframeRequest(1, 0x4bc4fcb4, true, 7))
+
+	for _, fir := range []bool{false, true} {
+		request := NewKeyframeRequest(0, 0x4bc4fcb4, fir, 0)
+		assert.NoErrorf(t, request.(interface{ Validate() error }).Validate(), "%T", request) //nolint:forcetypeassert
+		assert.Equalf(t, []uint32{0x4bc4fcb4}, request.DestinationSSRC(), "%T", request)
+	}
+}
+
+func TestFullIntraRequestAddEntry(t *testing.T) {
+	fir := NewFullIntraRequest(0x902f9e2e, 0)
+
+	fir.AddEntry(0x4bc4fcb4)
+	fir.AddEntry(0x12345678)
+	assert.Equal(t, []FIREntry{
+		{SSRC: 0x4bc4fcb4, SequenceNumber: 0},
+		{SSRC: 0x12345678, SequenceNumber: 0},
+	}, fir.FIR)
+
+	fir.AddEntry(0x4bc4fcb4)
+	fir.AddEntry(0x4bc4fcb4)
+	assert.Equal(t, []FIREntry{
+		{SSRC: 0x4bc4fcb4, SequenceNumber: 2},
+		{SSRC: 0x12345678, SequenceNumber: 0},
+	}, fir.FIR)
+
+	// The 8-bit sequence number wraps around.
+	fir.FIR[1].SequenceNumber = 255
+	fir.AddEntry(0x12345678)
+	assert.Equal(t, uint8(0), fir.FIR[1].SequenceNumber)
+}
+
+func TestFullIntraRequestUnmarshalCraftedLength(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Data      []byte
+		WantError error
+	}{
+		{
+			Name: "length past buffer",
+			Data: []byte{
+				0x84, 0xce, 0xff, 0xff,
+				0x00, 0x00, 0x00, 0x00,
+				0x4b, 0xc4, 0xfc, 0xb4,
+				0x12, 0x34, 0x56, 0x78,
+				0x42, 0x00, 0x00, 0x00,
+			},
+			WantError: ErrPacketTooShort,
+		},
+		{
+			Name: "partial entry",
+			Data: []byte{
+				0x84, 0xce, 0x00, 0x03,
+				0x00, 0x00, 0x00, 0x00,
+				0x4b, 0xc4, 0xfc, 0xb4,
+				0x12, 0x34, 0x56, 0x78,
+			},
+			WantError: ErrBadLength,
+		},
+	} {
+		var fir FullIntraRequest
+		assert.NotPanicsf(t, func() {
+			assert.ErrorIsf(t, fir.Unmarshal(test.Data), test.WantError, "Unmarshal %q", test.Name)
+		}, "Unmarshal %q", test.Name)
+	}
+
+	// Entries from an earlier packet must not be kept.
+	fir := FullIntraRequest{FIR: []FIREntry{{SSRC: 1}, {SSRC: 2}}}
+	assert.NoError(t, fir.Unmarshal([]byte{
+		0x84, 0xce, 0x00, 0x04,
+		0x00, 0x00, 0x00, 0x00,
+		0x4b, 0xc4, 0xfc, 0xb4,
+		0x12, 0x34, 0x56, 0x78,
+		0x42, 0x00, 0x00, 0x00,
+	}))
+	assert.Equal(t, []FIREntry{{SSRC: 0x12345678, SequenceNumber: 0x42}}, fir.FIR)
+}
@@ -9,17 +9,33 @@ import (
 
 func FuzzUnmarshal(f *testing.F) {
 	f.Add([]byte{})
+	f.Add(realPacket())
+	for _, packet := range samplePackets() {
+		if data, err := packet.Marshal(); err == nil {
+			f.Add(data)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// every packet type must reject malformed input without panicking,
+		// even when handed a packet of another type
+		for _, packet := range samplePackets() {
+			_ = packet.Unmarshal(data)
+		}
 
-	f.Fuzz(func(_ *testing.T, data []byte) {
 		packets, err := Unmarshal(data)
 		if err != nil {
 			return
 		}
 
 		for _, packet := range packets {
-			_, err = packet.Marshal()
+			buf, err := packet.Marshal()
 			if err != nil {
-				return
+				continue
+			}
+
+			if _, err := Unmarshal(buf); err != nil {
+				t.Fatalf("%T re-marshaled to an unparseable packet: %v", packet, err)
 			}
 		}
 	})
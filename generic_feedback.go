@@ -0,0 +1,259 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// The GenericFeedback packet is a transport or payload specific feedback
+// message whose format this package does not decode. Unmarshal returns it for
+// feedback formats with no registered type, so that the common framing of RFC
+// 4585 Section 6.1 is kept: a relay can rewrite its SSRCs and forward it with
+// the FCI untouched. ApplicationLayerFeedback shares its framing.
+type GenericFeedback struct {
+	// TypeTransportSpecificFeedback or TypePayloadSpecificFeedback
+	PacketType PacketType
+
+	// The feedback message type (FMT), at most 31.
+	MessageType uint8
+
+	// SSRC of sender
+	SenderSSRC uint32
+
+	// SSRC of the media source
+	MediaSSRC uint32
+
+	// Feedback Control Information, left undecoded. Its length must be a
+	// multiple of 4.
+	FCI []byte
+
+	packetPadding
+}
+
+const genericFCIOffset = 8
+
+// Marshal encodes the GenericFeedback in binary.
+func (p GenericFeedback) Marshal() ([]byte, error) {
+	rawPacket := make([]byte, p.MarshalSize())
+	if _, err := p.MarshalTo(rawPacket); err != nil {
+		return nil, err
+	}
+
+	return rawPacket, nil
+}
+
+// MarshalAppend appends the packet in binary to dst and returns the
+// extended slice.
+func (p GenericFeedback) MarshalAppend(dst []byte) ([]byte, error) {
+	return marshalAppend(dst, p.MarshalSize(), p.MarshalTo)
+}
+
+// MarshalTo encodes the GenericFeedback in binary into buf and returns the number of bytes written.
+func (p GenericFeedback) MarshalTo(buf []byte) (int, error) {
+	/*
+	 *  0                   1                   2                   3
+	 *  0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * |V=2|P|   FMT   |       PT      |          length               |
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * |                  SSRC of packet sender                        |
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * |                  SSRC of media source                         |
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * :            Feedback Control Information (FCI)                 :
+	 * :                                                               :
+	 */
+	if !isFeedbackType(p.PacketType) {
+		return 0, ErrWrongType
+	}
+	if len(p.FCI)%4 != 0 {
+		return 0, ErrBadLength
+	}
+
+	size := p.MarshalSize()
+	if len(buf) < size {
+		return 0, ErrPacketTooShort
+	}
+
+	header := p.Header()
+	if err := header.SetLengthFromBytes(size); err != nil {
+		return 0, err
+	}
+	if _, err := header.MarshalTo(buf); err != nil {
+		return 0, err
+	}
+
+	packetBody := buf[headerLength:]
+
+	binary.BigEndian.PutUint32(packetBody, p.SenderSSRC)
+	binary.BigEndian.PutUint32(packetBody[4:], p.MediaSSRC)
+	copy(packetBody[genericFCIOffset:], p.FCI)
+
+	writePadding(buf[:size], p.padding)
+
+	return size, nil
+}
+
+// Unmarshal decodes the GenericFeedback from binary. It accepts any transport
+// or payload specific feedback packet, including the formats that have a
+// type of their own. The FCI is copied so the caller may reuse rawPacket once
+// Unmarshal returns.
+func (p *GenericFeedback) Unmarshal(rawPacket []byte) error {
+	if err := p.unmarshalNoCopy(rawPacket); err != nil {
+		return err
+	}
+	p.FCI = cloneSlice(p.FCI)
+
+	return nil
+}
+
+// unmarshalNoCopy implements Unmarshal, leaving p.FCI pointing into
+// rawPacket.
+func (p *GenericFeedback) unmarshalNoCopy(rawPacket []byte) error {
+	rawPacket, padding, err := removePadding(rawPacket)
+	if err != nil {
+		return err
+	}
+
+	if len(rawPacket) < (headerLength + (ssrcLength * 2)) {
+		return ErrPacketTooShort
+	}
+
+	var h Header
+	if err := h.Unmarshal(rawPacket); err != nil {
+		return err
+	}
+
+	end := (int(h.Length) + 1) * 4
+	if end < headerLength+genericFCIOffset || len(rawPacket) < end {
+		return ErrPacketTooShort
+	}
+
+	if !isFeedbackType(h.Type) {
+		return ErrWrongType
+	}
+
+	p.PacketType = h.Type
+	p.MessageType = h.Count
+	p.SenderSSRC = binary.BigEndian.Uint32(rawPacket[headerLength:])
+	p.MediaSSRC = binary.BigEndian.Uint32(rawPacket[headerLength+ssrcLength:])
+	p.FCI = rawPacket[headerLength+genericFCIOffset : end]
+
+	p.padding = padding
+
+	return nil
+}
+
+// isFeedbackType reports whether pt is the type of the feedback messages of
+// RFC 4585.
+func isFeedbackType(pt PacketType) bool {
+	return pt == TypeTransportSpecificFeedback || pt == TypePayloadSpecificFeedback
+}
+
+// Header returns the Header associated with this packet.
+func (p *GenericFeedback) Header() Header {
+	h := Header{
+		Padding: p.padding != 0,
+		Count:   p.MessageType,
+		Type:    p.PacketType,
+	}
+	_ = h.SetLengthFromBytes(p.MarshalSize())
+
+	return h
+}
+
+// MarshalSize returns the size of the packet once marshaled.
+func (p *GenericFeedback) MarshalSize() int {
+	return headerLength + genericFCIOffset + len(p.FCI) + int(p.padding)
+}
+
+func (p *GenericFeedback) String() string {
+	return fmt.Sprintf("GenericFeedback %v %s %x %x %x",
+		p.PacketType, FormatString(p.PacketType, p.MessageType), p.SenderSSRC, p.MediaSSRC, p.FCI)
+}
+
+// Validate checks that the GenericFeedback can be sent: that it is a feedback
+// packet type, its feedback message type fits in the header and its FCI is a
+// multiple of 4 bytes long.
+func (p *GenericFeedback) Validate() error {
+	if !isFeedbackType(p.PacketType) {
+		return ErrWrongType
+	}
+	if p.MessageType > countMax {
+		return errFieldOutOfRange
+	}
+	if len(p.FCI)%4 != 0 {
+		return ErrBadLength
+	}
+
+	return checkPacketSize(p.MarshalSize())
+}
+
+// Type returns the packet type, PacketType.
+func (p *GenericFeedback) Type() PacketType {
+	return p.PacketType
+}
+
+// Format returns the feedback message type, MessageType.
+func (p *GenericFeedback) Format() uint8 {
+	return p.MessageType
+}
+
+// DestinationSSRC returns an array of SSRC values that this packet refers to.
+func (p *GenericFeedback) DestinationSSRC() []uint32 {
+	return []uint32{p.MediaSSRC}
+}
+
+// TargetsSSRC reports whether the packet is feedback about the media source
+// ssrc, its MediaSSRC.
+func (p *GenericFeedback) TargetsSSRC(ssrc uint32) bool {
+	return p.MediaSSRC == ssrc
+}
+
+// SourceSSRC returns the SSRC of the sender.
+func (p *GenericFeedback) SourceSSRC() []uint32 {
+	return []uint32{p.SenderSSRC}
+}
+
+// RewriteSSRC replaces every SSRC in the packet that equals from with to,
+// and returns the number of SSRCs replaced. SSRCs in the FCI are not
+// rewritten, as its layout is unknown.
+func (p *GenericFeedback) RewriteSSRC(from, to uint32) int {
+	return rewriteSSRC(&p.SenderSSRC, from, to) + rewriteSSRC(&p.MediaSSRC, from, to)
+}
+
+// Equal reports whether other is a GenericFeedback with the same contents.
+func (p *GenericFeedback) Equal(other Packet) bool {
+	o, ok := other.(*GenericFeedback)
+	if !ok || o == nil {
+		return false
+	}
+
+	return p.PacketType == o.PacketType &&
+		p.MessageType == o.MessageType &&
+		p.SenderSSRC == o.SenderSSRC &&
+		p.MediaSSRC == o.MediaSSRC &&
+		bytes.Equal(p.FCI, o.FCI)
+}
+
+// Clone returns a deep copy of the packet that shares no memory with p.
+func (p *GenericFeedback) Clone() Packet {
+	c := *p
+	c.FCI = cloneSlice(p.FCI)
+
+	return &c
+}
+
+// MarshalJSON encodes the packet as a JSON object tagged with its type.
+func (p GenericFeedback) MarshalJSON() ([]byte, error) {
+	return marshalJSON(p)
+}
+
+// UnmarshalJSON decodes a JSON object produced by MarshalJSON.
+func (p *GenericFeedback) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, p)
+}
@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Packet = (*GenericFeedback)(nil) // assert is a Packet
+
+func TestGenericFeedbackUnmarshal(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Data      []byte
+		Want      GenericFeedback
+		WantError error
+	}{
+		{
+			Name: "valid",
+			Data: []byte{
+				// v=2, p=0, FMT=9, RTPFB, len=3
+				0x89, 0xcd, 0x0, 0x3,
+				// sender=0x902f9e2e
+				0x90, 0x2f, 0x9e, 0x2e,
+				// media=0x4bc4fcb4
+				0x4b, 0xc4, 0xfc, 0xb4,
+				// FCI
+				0x01, 0x02, 0x03, 0x04,
+			},
+			Want: GenericFeedback{
+				PacketType:  TypeTransportSpecificFeedback,
+				MessageType: 9,
+				SenderSSRC:  0x902f9e2e,
+				MediaSSRC:   0x4bc4fcb4,
+				FCI:         []byte{0x01, 0x02, 0x03, 0x04},
+			},
+		},
+		{
+			Name: "no FCI",
+			Data: []byte{
+				// v=2, p=0, FMT=12, PSFB, len=2
+				0x8c, 0xce, 0x0, 0x2,
+				0x90, 0x2f, 0x9e, 0x2e,
+				0x4b, 0xc4, 0xfc, 0xb4,
+			},
+			Want: GenericFeedback{
+				PacketType:  TypePayloadSpecificFeedback,
+				MessageType: 12,
+				SenderSSRC:  0x902f9e2e,
+				MediaSSRC:   0x4bc4fcb4,
+				FCI:         []byte{},
+			},
+		},
+		{
+			Name: "no media SSRC",
+			Data: []byte{
+				0x89, 0xcd, 0x0, 0x1,
+				0x90, 0x2f, 0x9e, 0x2e,
+			},
+			WantError: ErrPacketTooShort,
+		},
+		{
+			Name: "FCI ends early",
+			Data: []byte{
+				0x89, 0xcd, 0x0, 0x4,
+				0x90, 0x2f, 0x9e, 0x2e,
+				0x4b, 0xc4, 0xfc, 0xb4,
+				0x01, 0x02, 0x03, 0x04,
+			},
+			WantError: ErrPacketTooShort,
+		},
+		{
+			Name: "wrong type",
+			Data: []byte{
+				// receiver report
+				0x80, 0xc9, 0x0, 0x2,
+				0x90, 0x2f, 0x9e, 0x2e,
+				0x4b, 0xc4, 0xfc, 0xb4,
+			},
+			WantError: ErrWrongType,
+		},
+		{
+			Name:      "nil",
+			Data:      nil,
+			WantError: ErrPacketTooShort,
+		},
+	} {
+		var feedback GenericFeedback
+		err := feedback.Unmarshal(test.Data)
+		assert.ErrorIsf(t, err, test.WantError, "Unmarshal %q", test.Name)
+		if err != nil {
+			continue
+		}
+		assert.Equalf(t, test.Want, feedback, "Unmarshal %q", test.Name)
+
+		packets, err := Unmarshal(test.Data)
+		assert.NoErrorf(t, err, "Unmarshal %q", test.Name)
+		assert.Equalf(t, []Packet{&test.Want}, packets, "Unmarshal %q dispatch", test.Name)
+	}
+}
+
+func TestGenericFeedbackKnownFormat(t *testing.T) {
+	pli := &PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2}
+	data, err := pli.Marshal()
+	assert.NoError(t, err)
+
+	var feedback GenericFeedback
+	assert.NoError(t, feedback.Unmarshal(data))
+	assert.Equal(t, TypePayloadSpecificFeedback, feedback.Type())
+	assert.Equal(t, FormatPLI, feedback.Format())
+	assert.Equal(t, uint32(1), feedback.SenderSSRC)
+	assert.Equal(t, uint32(2), feedback.MediaSSRC)
+
+	marshaled, err := feedback.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, data, marshaled)
+}
+
+func TestGenericFeedbackRoundTrip(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Packet    GenericFeedback
+		Padding   uint8
+		WantError error
+	}{
+		{
+			Name: "valid",
+			Packet: GenericFeedback{
+				PacketType:  TypePayloadSpecificFeedback,
+				MessageType: 10,
+				SenderSSRC:  0x902f9e2e,
+				MediaSSRC:   0x4bc4fcb4,
+				FCI:         []byte{1, 2, 3, 4, 5, 6, 7, 8},
+			},
+		},
+		{
+			Name: "padded",
+			Packet: GenericFeedback{
+				PacketType:  TypeTransportSpecificFeedback,
+				MessageType: 9,
+				SenderSSRC:  1,
+				MediaSSRC:   2,
+				FCI:         []byte{1, 2, 3, 4},
+			},
+			Padding: 4,
+		},
+		{
+			Name: "unaligned FCI",
+			Packet: GenericFeedback{
+				PacketType: TypeTransportSpecificFeedback,
+				FCI:        []byte{1, 2, 3},
+			},
+			WantError: ErrBadLength,
+		},
+		{
+			Name: "not feedback",
+			Packet: GenericFeedback{
+				PacketType: TypeReceiverReport,
+			},
+			WantError: ErrWrongType,
+		},
+	} {
+		assert.NoError(t, test.Packet.SetPadding(test.Padding))
+		data, err := test.Packet.Marshal()
+		assert.ErrorIsf(t, err, test.WantError, "Marshal %q", test.Name)
+		assert.ErrorIsf(t, test.Packet.Validate(), test.WantError, "Validate %q", test.Name)
+		if err != nil {
+			continue
+		}
+
+		var decoded GenericFeedback
+		assert.NoErrorf(t, decoded.Unmarshal(data), "Unmarshal %q", test.Name)
+		assert.Equalf(t, test.Packet, decoded, "%q round trip", test.Name)
+	}
+}
+
+func TestGenericFeedbackRewriteSSRC(t *testing.T) {
+	data := []byte{
+		// v=2, p=0, FMT=9, RTPFB, len=3
+		0x89, 0xcd, 0x0, 0x3,
+		0x90, 0x2f, 0x9e, 0x2e,
+		0x4b, 0xc4, 0xfc, 0xb4,
+		0x01, 0x02, 0x03, 0x04,
+	}
+	packets, err := Unmarshal(data)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, RewriteSSRC(packets, 0x4bc4fcb4, 0x11223344))
+	marshaled, err := Marshal(packets)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{
+		0x89, 0xcd, 0x0, 0x3,
+		0x90, 0x2f, 0x9e, 0x2e,
+		0x11, 0x22, 0x33, 0x44,
+		0x01, 0x02, 0x03, 0x04,
+	}, marshaled)
+}
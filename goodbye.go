@@ -14,10 +14,28 @@ type Goodbye struct {
 	Sources []uint32
 	// Optional text indicating the reason for leaving, e.g., "camera malfunction" or "RTP loop detected"
 	Reason string
+
+	packetPadding
 }
 
 // Marshal encodes the Goodbye packet in binary.
 func (g Goodbye) Marshal() ([]byte, error) {
+	rawPacket := make([]byte, g.MarshalSize())
+	if _, err := g.MarshalTo(rawPacket); err != nil {
+		return nil, err
+	}
+
+	return rawPacket, nil
+}
+
+// MarshalAppend appends the packet in binary to dst and returns the
+// extended slice.
+func (g Goodbye) MarshalAppend(dst []byte) ([]byte, error) {
+	return marshalAppend(dst, g.MarshalSize(), g.MarshalTo)
+}
+
+// MarshalTo encodes the Goodbye packet in binary into buf and returns the number of bytes written.
+func (g Goodbye) MarshalTo(buf []byte) (int, error) {
 	/*
 	 *        0                   1                   2                   3
 	 *        0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
@@ -31,37 +49,48 @@ func (g Goodbye) Marshal() ([]byte, error) {
 	 * (opt) |     length    |               reason for leaving            ...
 	 *       +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
 	 */
-
-	rawPacket := make([]byte, g.MarshalSize())
-	packetBody := rawPacket[headerLength:]
+	size := g.MarshalSize()
+	if len(buf) < size {
+		return 0, ErrPacketTooShort
+	}
 
 	if len(g.Sources) > countMax {
-		return nil, errTooManySources
+		return 0, errTooManySources
+	}
+
+	if len(g.Reason) > sdesMaxOctetCount {
+		return 0, errReasonTooLong
 	}
 
+	header := g.Header()
+	if err := header.SetLengthFromBytes(size); err != nil {
+		return 0, err
+	}
+	if _, err := header.MarshalTo(buf); err != nil {
+		return 0, err
+	}
+
+	packetBody := buf[headerLength:size]
+
 	for i, s := range g.Sources {
 		binary.BigEndian.PutUint32(packetBody[i*ssrcLength:], s)
 	}
 
+	offset := len(g.Sources) * ssrcLength
 	if g.Reason != "" {
-		reason := []byte(g.Reason)
-
-		if len(reason) > sdesMaxOctetCount {
-			return nil, errReasonTooLong
-		}
-
-		reasonOffset := len(g.Sources) * ssrcLength
-		packetBody[reasonOffset] = uint8(len(reason)) //nolint:gosec // G115
-		copy(packetBody[reasonOffset+1:], reason)
+		packetBody[offset] = uint8(len(g.Reason)) //nolint:gosec // G115
+		offset++
+		offset += copy(packetBody[offset:], g.Reason)
 	}
 
-	hData, err := g.Header().Marshal()
-	if err != nil {
-		return nil, err
+	// align to 32-bit boundary
+	for ; offset < len(packetBody); offset++ {
+		packetBody[offset] = 0
 	}
-	copy(rawPacket, hData)
 
-	return rawPacket, nil
+	writePadding(buf[:size], g.padding)
+
+	return size, nil
 }
 
 // Unmarshal decodes the Goodbye packet from binary.
@@ -80,24 +109,34 @@ func (g *Goodbye) Unmarshal(rawPacket []byte) error {
 	 *       +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
 	 */
 
+	rawPacket, padding, err := removePadding(rawPacket)
+	if err != nil {
+		return err
+	}
+
 	var header Header
 	if err := header.Unmarshal(rawPacket); err != nil {
 		return err
 	}
 
 	if header.Type != TypeGoodbye {
-		return errWrongType
+		return ErrWrongType
+	}
+
+	// The reason ends with the packet, where the length field says.
+	if end := (int(header.Length) + 1) * 4; end < len(rawPacket) {
+		rawPacket = rawPacket[:end]
 	}
 
 	if getPadding(len(rawPacket)) != 0 {
-		return errPacketTooShort
+		return ErrPacketTooShort
 	}
 
 	g.Sources = make([]uint32, header.Count)
 
 	reasonOffset := int(headerLength + header.Count*ssrcLength)
 	if reasonOffset > len(rawPacket) {
-		return errPacketTooShort
+		return ErrPacketTooShort
 	}
 
 	for i := 0; i < int(header.Count); i++ {
@@ -111,23 +150,27 @@ func (g *Goodbye) Unmarshal(rawPacket []byte) error {
 		reasonEnd := reasonOffset + 1 + reasonLen
 
 		if reasonEnd > len(rawPacket) {
-			return errPacketTooShort
+			return ErrPacketTooShort
 		}
 
 		g.Reason = string(rawPacket[reasonOffset+1 : reasonEnd])
 	}
 
+	g.padding = padding
+
 	return nil
 }
 
 // Header returns the Header associated with this packet.
 func (g *Goodbye) Header() Header {
-	return Header{
-		Padding: false,
+	h := Header{
+		Padding: g.padding != 0,
 		Count:   uint8(len(g.Sources)), //nolint:gosec //G115
 		Type:    TypeGoodbye,
-		Length:  uint16((g.MarshalSize() / 4) - 1), //nolint:gosec //G115
 	}
+	_ = h.SetLengthFromBytes(g.MarshalSize())
+
+	return h
 }
 
 // MarshalSize returns the size of the packet once marshaled.
@@ -142,7 +185,25 @@ func (g *Goodbye) MarshalSize() int {
 	l := headerLength + srcsLength + reasonLength
 
 	// align to 32-bit boundary
-	return l + getPadding(l)
+	return l + getPadding(l) + int(g.padding)
+}
+
+// Validate checks that the Goodbye can be sent: that it lists at most 31
+// sources, with a reason of at most 255 octets.
+func (g *Goodbye) Validate() error {
+	if len(g.Sources) > countMax {
+		return errTooManySources
+	}
+	if len(g.Reason) > sdesMaxOctetCount {
+		return errReasonTooLong
+	}
+
+	return checkPacketSize(g.MarshalSize())
+}
+
+// Type returns the packet type, TypeGoodbye.
+func (g *Goodbye) Type() PacketType {
+	return TypeGoodbye
 }
 
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
@@ -153,6 +214,50 @@ func (g *Goodbye) DestinationSSRC() []uint32 {
 	return out
 }
 
+// SourceSSRC returns the sources that are leaving, as they send the packet.
+func (g *Goodbye) SourceSSRC() []uint32 {
+	return g.DestinationSSRC()
+}
+
+// RewriteSSRC replaces every SSRC in the packet that equals from with to,
+// and returns the number of SSRCs replaced.
+func (g *Goodbye) RewriteSSRC(from, to uint32) int {
+	n := 0
+	for i := range g.Sources {
+		n += rewriteSSRC(&g.Sources[i], from, to)
+	}
+
+	return n
+}
+
+// Equal reports whether other is a Goodbye with the same contents.
+func (g *Goodbye) Equal(other Packet) bool {
+	o, ok := other.(*Goodbye)
+	if !ok || o == nil {
+		return false
+	}
+
+	return g.Reason == o.Reason && slicesEqual(g.Sources, o.Sources)
+}
+
+// Clone returns a deep copy of the packet that shares no memory with g.
+func (g *Goodbye) Clone() Packet {
+	c := *g
+	c.Sources = cloneSlice(g.Sources)
+
+	return &c
+}
+
+// MarshalJSON encodes the packet as a JSON object tagged with its type.
+func (g Goodbye) MarshalJSON() ([]byte, error) {
+	return marshalJSON(g)
+}
+
+// UnmarshalJSON decodes a JSON object produced by MarshalJSON.
+func (g *Goodbye) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, g)
+}
+
 func (g Goodbye) String() string {
 	out := "Goodbye\n"
 	for i, s := range g.Sources {
@@ -33,6 +33,25 @@ func TestGoodbyeUnmarshal(t *testing.T) {
 				Reason:  "FOO",
 			},
 		},
+		{
+			Name: "multiple sources with UTF-8 reason",
+			Data: []byte{
+				// v=2, p=0, count=2, BYE, len=5
+				0x82, 0xcb, 0x00, 0x05,
+				// ssrc=0x902f9e2e
+				0x90, 0x2f, 0x9e, 0x2e,
+				// ssrc=0xbc5e9a40
+				0xbc, 0x5e, 0x9a, 0x40,
+				// len=8, text=tschüss
+				0x08, 0x74, 0x73, 0x63,
+				0x68, 0xc3, 0xbc, 0x73,
+				0x73, 0x00, 0x00, 0x00,
+			},
+			Want: Goodbye{
+				Sources: []uint32{0x902f9e2e, 0xbc5e9a40},
+				Reason:  "tschüss",
+			},
+		},
 		{
 			Name: "invalid octet count",
 			Data: []byte{
@@ -43,7 +62,7 @@ func TestGoodbyeUnmarshal(t *testing.T) {
 				// len=4, text=FOO
 				0x04, 0x46, 0x4f, 0x4f,
 			},
-			WantError: errPacketTooShort,
+			WantError: ErrPacketTooShort,
 		},
 		{
 			Name: "wrong type",
@@ -55,7 +74,7 @@ func TestGoodbyeUnmarshal(t *testing.T) {
 				// len=3, text=FOO
 				0x03, 0x46, 0x4f, 0x4f,
 			},
-			WantError: errWrongType,
+			WantError: ErrWrongType,
 		},
 		{
 			Name: "short reason",
@@ -82,7 +101,7 @@ func TestGoodbyeUnmarshal(t *testing.T) {
 				// len=1, text=F
 				0x01, 0x46,
 			},
-			WantError: errPacketTooShort,
+			WantError: ErrPacketTooShort,
 		},
 		{
 			Name: "bad count in header",
@@ -92,7 +111,7 @@ func TestGoodbyeUnmarshal(t *testing.T) {
 				// ssrc=0x902f9e2e
 				0x90, 0x2f, 0x9e, 0x2e,
 			},
-			WantError: errPacketTooShort,
+			WantError: ErrPacketTooShort,
 		},
 		{
 			Name: "empty packet",
@@ -108,7 +127,7 @@ func TestGoodbyeUnmarshal(t *testing.T) {
 		{
 			Name:      "nil",
 			Data:      nil,
-			WantError: errPacketTooShort,
+			WantError: ErrPacketTooShort,
 		},
 	} {
 		var bye Goodbye
@@ -155,6 +174,13 @@ func TestGoodbyeRoundTrip(t *testing.T) {
 				Reason: "because",
 			},
 		},
+		{
+			Name: "multiple sources with UTF-8 reason",
+			Bye: Goodbye{
+				Sources: []uint32{0x902f9e2e, 0xbc5e9a40},
+				Reason:  "tschüss",
+			},
+		},
 		{
 			Name: "empty reason",
 			Bye: Goodbye{
@@ -203,3 +229,46 @@ func TestGoodbyeRoundTrip(t *testing.T) {
 		assert.Equalf(t, test.Bye, bye, "%q bye round trip mismatch", test.Name)
 	}
 }
+
+func TestGoodbyeMixerSources(t *testing.T) {
+	// A mixer leaving on behalf of itself and four contributing sources.
+	bye := Goodbye{
+		Sources: []uint32{0x902f9e2e, 0x11111111, 0x22222222, 0x33333333, 0x44444444},
+		Reason:  "mixer",
+	}
+	data, err := bye.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{
+		// v=2, p=0, count=5, BYE, len=7
+		0x85, 0xcb, 0x00, 0x07,
+		0x90, 0x2f, 0x9e, 0x2e,
+		0x11, 0x11, 0x11, 0x11,
+		0x22, 0x22, 0x22, 0x22,
+		0x33, 0x33, 0x33, 0x33,
+		0x44, 0x44, 0x44, 0x44,
+		// len=5, text=mixer + padding
+		0x05, 0x6d, 0x69, 0x78,
+		0x65, 0x72, 0x00, 0x00,
+	}, data)
+	assert.Equal(t, uint8(5), bye.Header().Count)
+
+	packets, err := Unmarshal(data)
+	assert.NoError(t, err)
+	assert.Equal(t, []Packet{&bye}, packets)
+	assert.Equal(t, bye.Sources, packets[0].DestinationSSRC())
+
+	// A count of six runs past the five sources into the reason, which
+	// then does not fit.
+	tooHigh := append([]byte(nil), data...)
+	tooHigh[0] = 0x86
+	_, err = Unmarshal(tooHigh)
+	assert.ErrorIs(t, err, ErrPacketTooShort)
+
+	// A count of four takes the fifth source for the start of a reason
+	// longer than the packet.
+	tooLow := append([]byte(nil), data[:24]...)
+	tooLow[0] = 0x84
+	tooLow[3] = 0x05
+	_, err = Unmarshal(tooLow)
+	assert.ErrorIs(t, err, ErrPacketTooShort)
+}
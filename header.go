@@ -5,6 +5,8 @@ package rtcp
 
 import (
 	"encoding/binary"
+	"fmt"
+	"math"
 )
 
 // PacketType specifies the type of an RTCP packet.
@@ -28,13 +30,18 @@ const (
 // Transport and Payload specific feedback messages overload the count field to act as a message type.
 // those are listed here.
 const (
-	FormatSLI  uint8 = 2
-	FormatPLI  uint8 = 1
-	FormatFIR  uint8 = 4
-	FormatTLN  uint8 = 1
-	FormatRRR  uint8 = 5
-	FormatCCFB uint8 = 11
-	FormatREMB uint8 = 15
+	FormatSLI   uint8 = 2
+	FormatRPSI  uint8 = 3
+	FormatPLI   uint8 = 1
+	FormatFIR   uint8 = 4
+	FormatTLN   uint8 = 1
+	FormatTMMBR uint8 = 3
+	FormatTMMBN uint8 = 4
+	FormatRRR   uint8 = 5
+	FormatECN   uint8 = 8
+	FormatCCFB  uint8 = 11
+	FormatREMB  uint8 = 15
+	FormatAFB   uint8 = 15
 
 	// https://tools.ietf.org/html/draft-holmer-rmcat-transport-wide-cc-extensions-01#page-5
 	FormatTCC uint8 = 15
@@ -59,12 +66,119 @@ func (p PacketType) String() string {
 	case TypeExtendedReport:
 		return "XR"
 	default:
-		return string(p)
+		return fmt.Sprintf("PacketType(%d)", uint8(p))
+	}
+}
+
+// FormatString returns the mnemonic of the feedback message type format
+// carried in the count field of a feedback packet of type t, such as "PLI"
+// or "NACK". Unknown formats are returned as "FMT(n)".
+func FormatString(t PacketType, format uint8) string {
+	switch t {
+	case TypeTransportSpecificFeedback:
+		switch format {
+		case FormatTLN:
+			return "NACK"
+		case FormatTMMBR:
+			return "TMMBR"
+		case FormatTMMBN:
+			return "TMMBN"
+		case FormatRRR:
+			return "RRR"
+		case FormatECN:
+			return "ECN"
+		case FormatCCFB:
+			return "CCFB"
+		case FormatTCC:
+			return "TCC"
+		}
+	case TypePayloadSpecificFeedback:
+		switch format {
+		case FormatPLI:
+			return "PLI"
+		case FormatSLI:
+			return "SLI"
+		case FormatRPSI:
+			return "RPSI"
+		case FormatFIR:
+			return "FIR"
+		case FormatAFB:
+			return "AFB"
+		}
+	default:
+	}
+
+	return fmt.Sprintf("FMT(%d)", format)
+}
+
+// SetLengthFromBytes sets Length from n, the size in bytes of the whole packet
+// including its header and padding. It fails with ErrBadLength, leaving Length
+// unchanged, if n is not a multiple of 4 that the length field can hold, and
+// also with ErrLengthOverflow if n is too large for it. The Header methods of
+// packets too large to encode thus return a zero Length, while their
+// MarshalTo methods fail with ErrLengthOverflow.
+func (h *Header) SetLengthFromBytes(n int) error {
+	if n/4-1 > math.MaxUint16 {
+		return fmt.Errorf("%w: %w: %d bytes", ErrBadLength, ErrLengthOverflow, n)
+	}
+	if n < headerLength || n%4 != 0 {
+		return fmt.Errorf("%w: %d bytes", ErrBadLength, n)
+	}
+
+	h.Length = uint16(n/4 - 1) //nolint:gosec // G115, checked above
+
+	return nil
+}
+
+// checkPacketSize returns the error SetLengthFromBytes gives for a packet of
+// size bytes, for the Validate methods of the packets.
+func checkPacketSize(size int) error {
+	var h Header
+
+	return h.SetLengthFromBytes(size)
+}
+
+// PeekType returns the type of the RTCP packet at the start of data, reading
+// only its header. For transport and payload specific feedback it also
+// returns the feedback message type (FMT), and 0 for other packet types.
+// Data shorter than a header fails with ErrPacketTooShort.
+func PeekType(data []byte) (PacketType, uint8, error) {
+	var header Header
+	if err := header.Unmarshal(data); err != nil {
+		return 0, 0, err
+	}
+
+	switch header.Type {
+	case TypeTransportSpecificFeedback, TypePayloadSpecificFeedback:
+		return header.Type, header.Count, nil
+	default:
+		return header.Type, 0, nil
 	}
 }
 
 const rtpVersion = 2
 
+// The range of the second header byte that RTCP packets use when multiplexed
+// with RTP, where it holds the RTP marker bit and payload types 64-95.
+const (
+	rtcpMuxTypeMin = 192
+	rtcpMuxTypeMax = 223
+)
+
+// IsRTCP reports whether b looks like RTCP rather than RTP, for transports
+// that carry both on one port, as described in RFC 5761 Section 4: the
+// version is 2 and the packet type, which for RTP holds the marker bit and
+// payload type, is between 192 and 223. RTP sessions must therefore not use
+// payload types 64-95. It reads only the header, so it works for SRTCP, and
+// returns false for data shorter than a header.
+func IsRTCP(b []byte) bool {
+	if len(b) < headerLength || b[0]>>versionShift&versionMask != rtpVersion {
+		return false
+	}
+
+	return b[1] >= rtcpMuxTypeMin && b[1] <= rtcpMuxTypeMax
+}
+
 // A Header is the common header shared by all RTCP packets.
 type Header struct {
 	// If the padding bit is set, this individual RTCP packet contains
@@ -93,6 +207,17 @@ const (
 
 // Marshal encodes the Header in binary.
 func (h Header) Marshal() ([]byte, error) {
+	rawPacket := make([]byte, h.MarshalSize())
+	if _, err := h.MarshalTo(rawPacket); err != nil {
+		return nil, err
+	}
+
+	return rawPacket, nil
+}
+
+// MarshalTo encodes the Header in binary into buf and returns the number of
+// bytes written. It fails if buf is too short to hold the Header.
+func (h Header) MarshalTo(buf []byte) (int, error) {
 	/*
 	 *  0                   1                   2                   3
 	 *  0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
@@ -100,30 +225,40 @@ func (h Header) Marshal() ([]byte, error) {
 	 * |V=2|P|    RC   |   PT=SR=200   |             length            |
 	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
 	 */
-	rawPacket := make([]byte, headerLength)
+	if len(buf) < headerLength {
+		return 0, ErrPacketTooShort
+	}
+
+	if h.Count > countMax {
+		return 0, ErrInvalidHeader
+	}
 
-	rawPacket[0] |= rtpVersion << versionShift
+	buf[0] = rtpVersion << versionShift
 
 	if h.Padding {
-		rawPacket[0] |= 1 << paddingShift
+		buf[0] |= 1 << paddingShift
 	}
 
-	if h.Count > 31 {
-		return nil, errInvalidHeader
-	}
-	rawPacket[0] |= h.Count << countShift
+	buf[0] |= h.Count << countShift
 
-	rawPacket[1] = uint8(h.Type)
+	buf[1] = uint8(h.Type)
 
-	binary.BigEndian.PutUint16(rawPacket[2:], h.Length)
+	binary.BigEndian.PutUint16(buf[2:], h.Length)
 
-	return rawPacket, nil
+	return headerLength, nil
+}
+
+// MarshalSize returns the size of the Header once marshaled, which is always
+// 4 bytes.
+func (h Header) MarshalSize() int {
+	return headerLength
 }
 
-// Unmarshal decodes the Header from binary.
+// Unmarshal decodes the Header from binary. It fails with ErrPacketTooShort
+// if rawPacket is shorter than a header.
 func (h *Header) Unmarshal(rawPacket []byte) error {
 	if len(rawPacket) < headerLength {
-		return errPacketTooShort
+		return ErrPacketTooShort
 	}
 
 	/*
@@ -136,7 +271,7 @@ func (h *Header) Unmarshal(rawPacket []byte) error {
 
 	version := rawPacket[0] >> versionShift & versionMask
 	if version != rtpVersion {
-		return errBadVersion
+		return ErrBadVersion
 	}
 
 	h.Padding = (rawPacket[0] >> paddingShift & paddingMask) > 0
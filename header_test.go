@@ -48,7 +48,17 @@ func TestHeaderUnmarshal(t *testing.T) {
 				// v=0, p=0, count=0, RR, len=4
 				0x00, 0xc9, 0x00, 0x04,
 			},
-			WantError: errBadVersion,
+			WantError: ErrBadVersion,
+		},
+		{
+			Name:      "empty",
+			Data:      []byte{},
+			WantError: ErrPacketTooShort,
+		},
+		{
+			Name:      "short",
+			Data:      []byte{0x81, 0xc9, 0x00},
+			WantError: ErrPacketTooShort,
 		},
 	} {
 		var h Header
@@ -91,7 +101,7 @@ func TestHeaderRoundTrip(t *testing.T) {
 			Header: Header{
 				Count: 40,
 			},
-			WantError: errInvalidHeader,
+			WantError: ErrInvalidHeader,
 		},
 	} {
 		data, err := test.Header.Marshal()
@@ -105,3 +115,178 @@ func TestHeaderRoundTrip(t *testing.T) {
 		assert.Equalf(t, test.Header, decoded, "%q header round trip mismatch", test.Name)
 	}
 }
+
+func TestPacketTypeString(t *testing.T) {
+	for _, test := range []struct {
+		Type PacketType
+		Want string
+	}{
+		{TypeSenderReport, "SR"},
+		{TypeReceiverReport, "RR"},
+		{TypeSourceDescription, "SDES"},
+		{TypeGoodbye, "BYE"},
+		{TypeApplicationDefined, "APP"},
+		{TypeTransportSpecificFeedback, "TSFB"},
+		{TypePayloadSpecificFeedback, "PSFB"},
+		{TypeExtendedReport, "XR"},
+		{208, "PacketType(208)"},
+		{0, "PacketType(0)"},
+	} {
+		assert.Equal(t, test.Want, test.Type.String())
+	}
+}
+
+func TestFormatString(t *testing.T) {
+	for _, test := range []struct {
+		Type   PacketType
+		Format uint8
+		Want   string
+	}{
+		{TypeTransportSpecificFeedback, FormatTLN, "NACK"},
+		{TypeTransportSpecificFeedback, FormatTMMBR, "TMMBR"},
+		{TypeTransportSpecificFeedback, FormatTMMBN, "TMMBN"},
+		{TypeTransportSpecificFeedback, FormatRRR, "RRR"},
+		{TypeTransportSpecificFeedback, FormatECN, "ECN"},
+		{TypeTransportSpecificFeedback, FormatCCFB, "CCFB"},
+		{TypeTransportSpecificFeedback, FormatTCC, "TCC"},
+		{TypeTransportSpecificFeedback, 9, "FMT(9)"},
+		{TypePayloadSpecificFeedback, FormatPLI, "PLI"},
+		{TypePayloadSpecificFeedback, FormatSLI, "SLI"},
+		{TypePayloadSpecificFeedback, FormatRPSI, "RPSI"},
+		{TypePayloadSpecificFeedback, FormatFIR, "FIR"},
+		{TypePayloadSpecificFeedback, FormatREMB, "AFB"},
+		{TypePayloadSpecificFeedback, 7, "FMT(7)"},
+		{TypeReceiverReport, 1, "FMT(1)"},
+	} {
+		assert.Equalf(t, test.Want, FormatString(test.Type, test.Format), "%s format %d", test.Type, test.Format)
+	}
+}
+
+func TestIsRTCP(t *testing.T) {
+	for _, test := range []struct {
+		Name string
+		Data []byte
+		Want bool
+	}{
+		{"receiver report", realPacket(), true},
+		{"sender report", []byte{0x80, 0xc8, 0x00, 0x06}, true},
+		{"lowest type", []byte{0x80, 0xc0, 0x00, 0x01}, true},
+		{"highest type", []byte{0x80, 0xdf, 0x00, 0x01}, true},
+		{"RTP payload type 96", []byte{0x80, 0x60, 0x12, 0x34}, false},
+		{"RTP payload type 96 with marker", []byte{0x80, 0xe0, 0x12, 0x34}, false},
+		{"RTP payload type 0 with marker", []byte{0x80, 0x80, 0x12, 0x34}, false},
+		{"RTP payload type 63 with marker", []byte{0x80, 0xbf, 0x12, 0x34}, false},
+		{"version 1", []byte{0x40, 0xc8, 0x00, 0x06}, false},
+		{"DTLS", []byte{0x16, 0xfe, 0xfd, 0x00}, false},
+		{"too short", []byte{0x80, 0xc8, 0x00}, false},
+		{"empty", []byte{}, false},
+		{"nil", nil, false},
+	} {
+		assert.Equalf(t, test.Want, IsRTCP(test.Data), "IsRTCP %q", test.Name)
+	}
+}
+
+func TestPeekType(t *testing.T) {
+	for _, test := range []struct {
+		Name       string
+		Data       []byte
+		WantType   PacketType
+		WantFormat uint8
+		WantError  error
+	}{
+		{
+			Name:     "receiver report",
+			Data:     realPacket(),
+			WantType: TypeReceiverReport,
+		},
+		{
+			Name:       "picture loss indication",
+			Data:       []byte{0x81, 0xce, 0x00, 0x02},
+			WantType:   TypePayloadSpecificFeedback,
+			WantFormat: FormatPLI,
+		},
+		{
+			Name:       "transport wide cc",
+			Data:       []byte{0x8f, 0xcd, 0x00, 0x05},
+			WantType:   TypeTransportSpecificFeedback,
+			WantFormat: FormatTCC,
+		},
+		{
+			Name:     "unknown type",
+			Data:     []byte{0x80, 0xd2, 0x00, 0x01},
+			WantType: 210,
+		},
+		{
+			Name:      "too short",
+			Data:      []byte{0x81, 0xc9, 0x00},
+			WantError: ErrPacketTooShort,
+		},
+		{
+			Name:      "bad version",
+			Data:      []byte{0x41, 0xc9, 0x00, 0x07},
+			WantError: ErrBadVersion,
+		},
+	} {
+		typ, format, err := PeekType(test.Data)
+		assert.ErrorIsf(t, err, test.WantError, "PeekType %q", test.Name)
+		assert.Equalf(t, test.WantType, typ, "PeekType %q", test.Name)
+		assert.Equalf(t, test.WantFormat, format, "PeekType %q", test.Name)
+	}
+
+	data := realPacket()
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _, _ = PeekType(data)
+	})
+	assert.Zero(t, allocs)
+}
+
+func TestHeaderMarshalTo(t *testing.T) {
+	h := Header{Padding: true, Count: 1, Type: TypeReceiverReport, Length: 7}
+	assert.Equal(t, 4, h.MarshalSize())
+
+	buf := []byte{0xff, 0xff, 0xff, 0xff, 0xff}
+	n, err := h.MarshalTo(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, h.MarshalSize(), n)
+	assert.Equal(t, []byte{0xa1, 0xc9, 0x00, 0x07, 0xff}, buf)
+
+	_, err = h.MarshalTo(buf[:3])
+	assert.ErrorIs(t, err, ErrPacketTooShort)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = h.MarshalTo(buf)
+	})
+	assert.Zero(t, allocs)
+}
+
+func TestHeaderSetLengthFromBytes(t *testing.T) {
+	for _, test := range []struct {
+		Name       string
+		Size       int
+		WantLength uint16
+		WantError  error
+	}{
+		{"header only", 4, 0, nil},
+		{"receiver report", 32, 7, nil},
+		{"largest", 4 * (1 << 16), 0xffff, nil},
+		{"too large", 4*(1<<16) + 4, 0, ErrBadLength},
+		{"overflow", 4*(1<<16) + 4, 0, ErrLengthOverflow},
+		{"not a multiple of 4", 34, 0, ErrBadLength},
+		{"shorter than header", 0, 0, ErrBadLength},
+		{"negative", -4, 0, ErrBadLength},
+	} {
+		var h Header
+		err := h.SetLengthFromBytes(test.Size)
+		assert.ErrorIsf(t, err, test.WantError, "SetLengthFromBytes %q", test.Name)
+		assert.Equalf(t, test.WantLength, h.Length, "SetLengthFromBytes %q", test.Name)
+	}
+
+	// Every packet's header describes its marshaled size.
+	for _, packet := range samplePackets() {
+		h, ok := packet.(interface{ Header() Header })
+		if !ok {
+			continue
+		}
+		assert.Equalf(t, packet.MarshalSize(), (int(h.Header().Length)+1)*4, "%T", packet)
+	}
+}
@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Constants of the RTCP transmission interval algorithm, see RFC 3550
+// Section 6.3 and Appendix A.7.
+const (
+	// The minimum interval between RTCP packets, in seconds. Halved for the
+	// first packet sent by a participant.
+	rtcpMinTime = 5.0
+	// The fraction of the RTCP bandwidth shared by the senders, when they
+	// are at most a quarter of the members.
+	rtcpSenderBandwidthFraction = 0.25
+	// Divides the randomized interval to compensate for timer
+	// reconsideration converging below the intended average, e - 3/2 with e
+	// rounded as in the reference implementation.
+	rtcpCompensation = 2.71828 - 1.5
+)
+
+// IntervalParams describes the state of an RTP session that the RTCP
+// transmission interval is computed from.
+type IntervalParams struct {
+	// The estimated number of session members, including ourselves.
+	Members int
+	// The number of members that recently sent RTP data.
+	Senders int
+	// The bandwidth available to RTCP in octets per second, typically 5% of
+	// the session bandwidth.
+	RTCPBandwidth float64
+	// Whether we sent RTP data since the second to last report.
+	WeSent bool
+	// The average size of the RTCP packets sent and received, in octets,
+	// including lower-layer headers. See RTCPSizeEstimator.
+	AvgRTCPSize float64
+	// Whether no RTCP packet has been sent yet.
+	Initial bool
+}
+
+// DeterministicInterval returns the interval Td of RFC 3550 Section 6.3.1,
+// the mean interval between RTCP packets before randomization. It never
+// falls below the minimum interval of 5 seconds, or 2.5 seconds for the
+// initial packet.
+func DeterministicInterval(p IntervalParams) time.Duration {
+	return seconds(deterministicInterval(p))
+}
+
+// Interval returns the randomized interval until the next RTCP packet, as
+// computed by rtcp_interval() in RFC 3550 Appendix A.7. It is the
+// deterministic interval scaled by a random factor between 0.5 and 1.5, and
+// divided by e-3/2 to compensate for timer reconsideration.
+func Interval(p IntervalParams) time.Duration {
+	return seconds(randomizedInterval(p, rand.Float64())) //nolint:gosec // G404, not security sensitive
+}
+
+// Reconsider applies the timer reconsideration of RFC 3550 Section 6.3.6
+// when the transmission timer expires at now, with last the time the
+// previous RTCP packet was sent. It recomputes the next transmission time
+// from the current session state; if that time is not after now the packet
+// should be sent and Reconsider returns true. Otherwise the timer should be
+// rescheduled for the returned time.
+func Reconsider(p IntervalParams, last, now time.Time) (time.Time, bool) {
+	next := last.Add(Interval(p))
+
+	return next, !next.After(now)
+}
+
+func deterministicInterval(p IntervalParams) float64 {
+	minTime := rtcpMinTime
+	if p.Initial {
+		minTime /= 2
+	}
+
+	// Dedicate a quarter of the bandwidth to senders, unless they make up
+	// more than a quarter of the members.
+	n := float64(p.Members)
+	bandwidth := p.RTCPBandwidth
+	if float64(p.Senders) <= float64(p.Members)*rtcpSenderBandwidthFraction {
+		if p.WeSent {
+			bandwidth *= rtcpSenderBandwidthFraction
+			n = float64(p.Senders)
+		} else {
+			bandwidth *= 1 - rtcpSenderBandwidthFraction
+			n -= float64(p.Senders)
+		}
+	}
+
+	if bandwidth <= 0 {
+		return minTime
+	}
+
+	return math.Max(p.AvgRTCPSize*n/bandwidth, minTime)
+}
+
+// randomizedInterval computes Interval with random drawn from [0, 1).
+func randomizedInterval(p IntervalParams, random float64) float64 {
+	return deterministicInterval(p) * (random + 0.5) / rtcpCompensation
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// RTCPSizeEstimator tracks the average RTCP packet size avg_rtcp_size of
+// RFC 3550 Section 6.3.3, for use as IntervalParams.AvgRTCPSize. Each
+// update moves the average a sixteenth of the way towards the new size.
+//
+// The zero value takes its first size as the average; use
+// NewRTCPSizeEstimator to start from the expected size of the first packet
+// instead, as the RFC suggests.
+type RTCPSizeEstimator struct {
+	value       float64
+	initialized bool
+}
+
+// NewRTCPSizeEstimator returns an RTCPSizeEstimator whose average starts at
+// initial.
+func NewRTCPSizeEstimator(initial float64) *RTCPSizeEstimator {
+	return &RTCPSizeEstimator{value: initial, initialized: true}
+}
+
+// Update adds the size in octets of an RTCP packet that was sent or
+// received, including lower-layer headers.
+func (e *RTCPSizeEstimator) Update(size int) {
+	if !e.initialized {
+		e.value = float64(size)
+		e.initialized = true
+
+		return
+	}
+
+	e.value = float64(size)/16 + e.value*15/16
+}
+
+// Value returns the current average RTCP packet size in octets.
+func (e *RTCPSizeEstimator) Value() float64 {
+	return e.value
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeterministicInterval(t *testing.T) {
+	for _, test := range []struct {
+		Name   string
+		Params IntervalParams
+		Want   time.Duration
+	}{
+		{
+			Name:   "initial minimum",
+			Params: IntervalParams{Members: 1, RTCPBandwidth: 1000, AvgRTCPSize: 100, Initial: true},
+			Want:   2500 * time.Millisecond,
+		},
+		{
+			Name:   "minimum",
+			Params: IntervalParams{Members: 1, RTCPBandwidth: 1000, AvgRTCPSize: 100},
+			Want:   5 * time.Second,
+		},
+		{
+			Name:   "receivers share three quarters",
+			Params: IntervalParams{Members: 1000, Senders: 10, RTCPBandwidth: 1000, AvgRTCPSize: 100},
+			Want:   132 * time.Second,
+		},
+		{
+			Name:   "senders share a quarter",
+			Params: IntervalParams{Members: 1000, Senders: 100, RTCPBandwidth: 1000, AvgRTCPSize: 100, WeSent: true},
+			Want:   40 * time.Second,
+		},
+		{
+			Name:   "senders above a quarter share everything",
+			Params: IntervalParams{Members: 4, Senders: 2, RTCPBandwidth: 1000, AvgRTCPSize: 2000, WeSent: true},
+			Want:   8 * time.Second,
+		},
+		{
+			Name:   "no bandwidth",
+			Params: IntervalParams{Members: 10, AvgRTCPSize: 100},
+			Want:   5 * time.Second,
+		},
+	} {
+		assert.Equalf(t, test.Want, DeterministicInterval(test.Params), "DeterministicInterval %q", test.Name)
+	}
+}
+
+func TestInterval(t *testing.T) {
+	params := IntervalParams{Members: 1000, Senders: 10, RTCPBandwidth: 1000, AvgRTCPSize: 100}
+
+	// 132s scaled by [0.5, 1.5) and divided by e-3/2
+	assert.InDelta(t, 54.1747, randomizedInterval(params, 0), 0.0001)
+	assert.InDelta(t, 108.3495, randomizedInterval(params, 0.5), 0.0001)
+	assert.InDelta(t, 162.5242, randomizedInterval(params, 1), 0.0001)
+
+	for i := 0; i < 100; i++ {
+		interval := Interval(params)
+		assert.GreaterOrEqual(t, interval, 54*time.Second)
+		assert.Less(t, interval, 163*time.Second)
+	}
+}
+
+func TestReconsider(t *testing.T) {
+	params := IntervalParams{Members: 2, RTCPBandwidth: 1000, AvgRTCPSize: 100}
+	now := time.Now()
+
+	// The interval is at most 1.5*5s/(e-3/2), about 6.2s.
+	next, send := Reconsider(params, now.Add(-10*time.Second), now)
+	assert.True(t, send)
+	assert.False(t, next.After(now))
+
+	// A session that grew since the timer was set pushes the packet back.
+	params.Members = 1000
+	next, send = Reconsider(params, now.Add(-10*time.Second), now)
+	assert.False(t, send)
+	assert.True(t, next.After(now))
+}
+
+func TestRTCPSizeEstimator(t *testing.T) {
+	var estimator RTCPSizeEstimator
+	assert.Equal(t, 0.0, estimator.Value())
+
+	estimator.Update(100)
+	assert.Equal(t, 100.0, estimator.Value())
+
+	estimator.Update(260)
+	assert.Equal(t, 110.0, estimator.Value())
+
+	estimator.Update(110)
+	assert.Equal(t, 110.0, estimator.Value())
+
+	started := NewRTCPSizeEstimator(200)
+	assert.Equal(t, 200.0, started.Value())
+	started.Update(40)
+	assert.Equal(t, 190.0, started.Value())
+
+	// The average converges on a constant size.
+	for i := 0; i < 200; i++ {
+		started.Update(80)
+	}
+	assert.InDelta(t, 80, started.Value(), 0.001)
+}
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import "time"
+
+// A JitterEstimator computes the interarrival jitter of an RTP source for its
+// reception reports, with the formula of RFC 3550 Section 6.4.1. Each packet
+// moves the estimate a sixteenth of the way towards the difference in transit
+// time between it and the previous packet. Like the reference implementation
+// in Appendix A.8, the estimate is kept scaled by 16 to limit rounding.
+type JitterEstimator struct {
+	clockRate   uint32
+	initialized bool
+	start       time.Time
+	lastTransit uint32
+	jitter      uint32
+}
+
+// NewJitterEstimator returns a JitterEstimator for a source whose RTP clock
+// runs at clockRate Hz, e.g. 90000 for video.
+func NewJitterEstimator(clockRate uint32) *JitterEstimator {
+	return &JitterEstimator{clockRate: clockRate}
+}
+
+// Update records the arrival at arrival of the RTP packet with timestamp
+// rtpTimestamp. Packets should be passed in the order they arrived.
+func (e *JitterEstimator) Update(rtpTimestamp uint32, arrival time.Time) {
+	if !e.initialized {
+		e.start = arrival
+		e.lastTransit = -rtpTimestamp
+		e.initialized = true
+
+		return
+	}
+
+	transit := e.timestampUnits(arrival.Sub(e.start)) - rtpTimestamp
+	d := int32(transit - e.lastTransit) //nolint:gosec // G115, difference of wrapping values
+	e.lastTransit = transit
+	if d < 0 {
+		d = -d
+	}
+	e.jitter += uint32(d) - ((e.jitter + 8) >> 4) //nolint:gosec // G115, d is not negative
+}
+
+// timestampUnits converts d to RTP timestamp units, wrapping around like RTP
+// timestamps do. Only differences in transit time matter, so arrival times
+// are measured from the first packet's.
+func (e *JitterEstimator) timestampUnits(d time.Duration) uint32 {
+	rate := time.Duration(e.clockRate)
+	seconds := d / time.Second
+	fraction := d % time.Second
+
+	return uint32(seconds*rate + fraction*rate/time.Second) //nolint:gosec // G115
+}
+
+// Jitter returns the current jitter estimate in RTP timestamp units, for the
+// Jitter of a ReceptionReport.
+func (e *JitterEstimator) Jitter() uint32 {
+	return e.jitter >> 4
+}
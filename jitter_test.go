@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJitterEstimator(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+
+	t.Run("steady", func(t *testing.T) {
+		e := NewJitterEstimator(90000)
+		for i := 0; i < 100; i++ {
+			// 20ms apart, crossing the RTP timestamp wraparound
+			e.Update(0xfffff000+uint32(i)*1800, start.Add(time.Duration(i)*20*time.Millisecond))
+		}
+		assert.Zero(t, e.Jitter())
+	})
+
+	t.Run("one late packet", func(t *testing.T) {
+		e := NewJitterEstimator(90000)
+		e.Update(0, start)
+		e.Update(1800, start.Add(20*time.Millisecond))
+		assert.Zero(t, e.Jitter())
+
+		// 1ms, or 90 timestamp units, late: J = 90/16
+		e.Update(3600, start.Add(41*time.Millisecond))
+		assert.Equal(t, uint32(5), e.Jitter())
+
+		// Back on time, so the difference is 90 again: J = 5.625 + (90-5.625)/16
+		e.Update(5400, start.Add(60*time.Millisecond))
+		assert.Equal(t, uint32(10), e.Jitter())
+	})
+
+	t.Run("converges", func(t *testing.T) {
+		e := NewJitterEstimator(8000)
+		for i := 0; i < 500; i++ {
+			// every other packet arrives 20ms, or 160 timestamp units, late
+			arrival := start.Add(time.Duration(i) * 20 * time.Millisecond)
+			if i%2 == 1 {
+				arrival = arrival.Add(20 * time.Millisecond)
+			}
+			e.Update(uint32(i)*160, arrival)
+		}
+		assert.InDelta(t, 160, float64(e.Jitter()), 1)
+
+		report := ReceptionReport{Jitter: e.Jitter()}
+		assert.InDelta(t, 0.02, report.JitterSeconds(8000), 0.001)
+	})
+}
@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+// Constants of the sequence number tracking algorithm, see RFC 3550
+// Appendix A.1.
+const (
+	// The largest forward jump in sequence numbers that is taken as loss
+	// rather than as a restarted source.
+	lossMaxDropout = 3000
+	// The largest backward jump in sequence numbers that is taken as a
+	// reordered packet.
+	lossMaxMisorder = 100
+	// The number of distinct sequence numbers.
+	lossSeqMod = 1 << 16
+
+	// The bounds of the signed 24-bit cumulative loss.
+	lossTotalMax = 1<<23 - 1
+	lossTotalMin = -1 << 23
+)
+
+// A LossCalculator tracks the RTP sequence numbers received from a source to
+// compute the loss statistics of its reception reports, as described in RFC
+// 3550 Appendix A.1 and A.3. Sequence numbers are extended with a count of
+// their 16-bit cycles, so loss is tracked across wraparound. Jumps too large
+// to be loss or reordering are taken as the source restarting once two
+// consecutive packets confirm them.
+//
+// The zero value is ready to use.
+type LossCalculator struct {
+	initialized bool
+	baseSeq     uint16
+	maxSeq      uint16
+	badSeq      uint32
+	cycles      uint32
+	received    uint32
+
+	expectedPrior uint32
+	receivedPrior uint32
+}
+
+// Update records the arrival of the RTP packet with sequence number seq.
+func (c *LossCalculator) Update(seq uint16) {
+	if !c.initialized {
+		c.reset(seq)
+		c.initialized = true
+		c.received = 1
+
+		return
+	}
+
+	delta := seq - c.maxSeq
+	switch {
+	case delta < lossMaxDropout:
+		// In order, with a permissible gap.
+		if seq < c.maxSeq {
+			c.cycles += lossSeqMod
+		}
+		c.maxSeq = seq
+	case delta <= lossSeqMod-lossMaxMisorder:
+		// A very large jump. If the next packet follows on, the source
+		// restarted its sequence numbers.
+		if uint32(seq) != c.badSeq {
+			c.badSeq = (uint32(seq) + 1) & (lossSeqMod - 1)
+
+			return
+		}
+		c.reset(seq)
+	default:
+		// A duplicate or reordered packet.
+	}
+	c.received++
+}
+
+func (c *LossCalculator) reset(seq uint16) {
+	c.baseSeq = seq
+	c.maxSeq = seq
+	c.badSeq = lossSeqMod + 1 // so seq == badSeq is false
+	c.cycles = 0
+	c.received = 0
+	c.expectedPrior = 0
+	c.receivedPrior = 0
+}
+
+// ExtendedHighestSequenceNumber returns the highest sequence number received,
+// extended with the count of sequence number cycles, for the
+// LastSequenceNumber of a ReceptionReport.
+func (c *LossCalculator) ExtendedHighestSequenceNumber() uint32 {
+	return c.cycles + uint32(c.maxSeq)
+}
+
+// NextReport returns the FractionLost and TotalLost of the next
+// ReceptionReport for the source, and starts the interval that the next
+// fraction lost is computed over. TotalLost is the signed 24-bit count of RFC
+// 3550, negative if duplicates made more packets arrive than were expected;
+// ReceptionReport.SignedTotalLost decodes it.
+func (c *LossCalculator) NextReport() (fractionLost uint8, totalLost uint32) {
+	if !c.initialized {
+		return 0, 0
+	}
+
+	expected := c.ExtendedHighestSequenceNumber() - uint32(c.baseSeq) + 1
+	lost := int64(expected) - int64(c.received)
+	if lost > lossTotalMax {
+		lost = lossTotalMax
+	} else if lost < lossTotalMin {
+		lost = lossTotalMin
+	}
+
+	expectedInterval := expected - c.expectedPrior
+	receivedInterval := c.received - c.receivedPrior
+	c.expectedPrior = expected
+	c.receivedPrior = c.received
+
+	if expectedInterval != 0 && expectedInterval > receivedInterval {
+		lostInterval := uint64(expectedInterval - receivedInterval)
+		fraction := (lostInterval << 8) / uint64(expectedInterval)
+		if fraction > 0xFF {
+			fraction = 0xFF
+		}
+		fractionLost = uint8(fraction)
+	}
+
+	return fractionLost, uint32(lost) & 0xFFFFFF //nolint:gosec // G115, 24-bit two's complement
+}
@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLossCalculator(t *testing.T) {
+	for _, test := range []struct {
+		Name             string
+		Sequence         []uint16
+		WantFractionLost uint8
+		WantTotalLost    int32
+		WantHighest      uint32
+	}{
+		{
+			Name: "no packets",
+		},
+		{
+			Name:        "no loss",
+			Sequence:    []uint16{10, 11, 12, 13},
+			WantHighest: 13,
+		},
+		{
+			Name:             "one in four lost",
+			Sequence:         []uint16{10, 11, 13},
+			WantFractionLost: 64,
+			WantTotalLost:    1,
+			WantHighest:      13,
+		},
+		{
+			Name:        "reordered",
+			Sequence:    []uint16{10, 12, 11, 13},
+			WantHighest: 13,
+		},
+		{
+			Name:             "wraparound",
+			Sequence:         []uint16{65534, 65535, 1, 2},
+			WantFractionLost: 51,
+			WantTotalLost:    1,
+			WantHighest:      1<<16 + 2,
+		},
+		{
+			Name:          "duplicates",
+			Sequence:      []uint16{10, 11, 11, 12},
+			WantTotalLost: -1,
+			WantHighest:   12,
+		},
+		{
+			Name:     "restarted source",
+			Sequence: []uint16{10, 11, 40000, 40001, 40002},
+			// the jump is confirmed by the second packet, which restarts the count.
+			WantHighest: 40002,
+		},
+		{
+			Name:        "single stray jump",
+			Sequence:    []uint16{10, 11, 40000, 12},
+			WantHighest: 12,
+		},
+	} {
+		var calculator LossCalculator
+		for _, seq := range test.Sequence {
+			calculator.Update(seq)
+		}
+
+		fractionLost, totalLost := calculator.NextReport()
+		report := ReceptionReport{FractionLost: fractionLost, TotalLost: totalLost}
+		assert.Equalf(t, test.WantFractionLost, report.FractionLost, "FractionLost %q", test.Name)
+		assert.Equalf(t, test.WantTotalLost, report.SignedTotalLost(), "TotalLost %q", test.Name)
+		assert.Equalf(t, test.WantHighest, calculator.ExtendedHighestSequenceNumber(), "highest %q", test.Name)
+
+		_, err := report.Marshal()
+		assert.NoErrorf(t, err, "Marshal %q", test.Name)
+	}
+}
+
+func TestLossCalculatorIntervals(t *testing.T) {
+	var calculator LossCalculator
+	for seq := uint16(0); seq < 10; seq++ {
+		if seq%2 == 0 {
+			calculator.Update(seq)
+		}
+	}
+	fractionLost, totalLost := calculator.NextReport()
+	assert.Equal(t, uint8(113), fractionLost) // 4 lost out of 9
+	assert.Equal(t, uint32(4), totalLost)
+
+	// The fraction lost only covers packets since the previous report.
+	for seq := uint16(10); seq < 20; seq++ {
+		calculator.Update(seq)
+	}
+	fractionLost, totalLost = calculator.NextReport()
+	assert.Equal(t, uint8(23), fractionLost) // 9 lost out of 9 to 19
+	assert.Equal(t, uint32(5), totalLost)
+
+	calculator.Update(30)
+	fractionLost, totalLost = calculator.NextReport()
+	assert.Equal(t, uint8(232), fractionLost) // 10 lost out of 20 to 30
+	assert.Equal(t, uint32(15), totalLost)
+
+	// No packets since the previous report.
+	fractionLost, totalLost = calculator.NextReport()
+	assert.Equal(t, uint8(0), fractionLost)
+	assert.Equal(t, uint32(15), totalLost)
+}
@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"time"
+)
+
+// ntpEpochOffset is the number of seconds between the NTP epoch
+// (1 January 1900) and the Unix epoch (1 January 1970).
+const ntpEpochOffset = 2208988800
+
+// toNTPTime converts a time.Time into a 64-bit NTP timestamp, with the
+// integer seconds in the most significant word and the fraction in the
+// least significant word.
+func toNTPTime(t time.Time) uint64 {
+	seconds := uint64(t.Unix() + ntpEpochOffset) //nolint:gosec // G115
+	fraction := (uint64(t.Nanosecond()) << 32) / uint64(time.Second)
+
+	return seconds<<32 | fraction
+}
+
+// fromNTPTime converts a 64-bit NTP timestamp into a time.Time.
+func fromNTPTime(ntp uint64) time.Time {
+	seconds := int64(ntp>>32) - ntpEpochOffset
+	nanoseconds := int64(((ntp & 0xFFFFFFFF) * uint64(time.Second)) >> 32) //nolint:gosec // G115
+
+	return time.Unix(seconds, nanoseconds)
+}
@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNTPTime(t *testing.T) {
+	for _, test := range []struct {
+		Name string
+		Time time.Time
+		NTP  uint64
+	}{
+		{
+			Name: "unix epoch",
+			Time: time.Unix(0, 0),
+			NTP:  0x83AA7E80_00000000,
+		},
+		{
+			Name: "half second",
+			Time: time.Unix(1, 500000000),
+			NTP:  0x83AA7E81_80000000,
+		},
+		{
+			Name: "ntp epoch",
+			Time: time.Unix(-ntpEpochOffset, 0),
+			NTP:  0,
+		},
+	} {
+		assert.Equalf(t, test.NTP, toNTPTime(test.Time), "toNTPTime %q", test.Name)
+		assert.Truef(t, test.Time.Equal(fromNTPTime(test.NTP)), "fromNTPTime %q", test.Name)
+	}
+
+	now := time.Unix(1700000000, 123456789)
+	assert.WithinDuration(t, now, fromNTPTime(toNTPTime(now)), time.Nanosecond)
+}
@@ -3,15 +3,114 @@
 
 package rtcp
 
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+)
+
 // Packet represents an RTCP packet, a protocol used for out-of-band statistics
 // and control information for an RTP session.
 type Packet interface {
 	// DestinationSSRC returns an array of SSRC values that this packet refers to.
 	DestinationSSRC() []uint32
 
+	// SourceSSRC returns the SSRC values of the sources that sent this packet.
+	SourceSSRC() []uint32
+
+	// Type returns the RTCP packet type. Feedback packets also have a Format
+	// method returning their feedback message type (FMT).
+	Type() PacketType
+
 	Marshal() ([]byte, error)
+
+	// Unmarshal decodes a single packet of this type, including its header,
+	// from rawPacket. It fails with ErrWrongType if the header is for another
+	// packet type. Callers that know which type to expect may use it instead
+	// of the package level Unmarshal; RawPacket and CompoundPacket accept any
+	// packets, and GenericFeedback any feedback packet.
 	Unmarshal(rawPacket []byte) error
 	MarshalSize() int
+
+	// MarshalTo encodes the packet into buf and returns the number of bytes
+	// written. It fails if buf is shorter than MarshalSize.
+	MarshalTo(buf []byte) (int, error)
+}
+
+// PacketsEqual reports whether a and b contain equal packets in the same
+// order. Packets are compared with their Equal method, which treats nil and
+// empty slices as equal.
+func PacketsEqual(a, b []Packet) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		eq, ok := a[i].(interface{ Equal(other Packet) bool })
+		if !ok || !eq.Equal(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ClonePacket returns a deep copy of p that shares no memory with it, so the
+// copy may be mutated or the buffer p was parsed from reused. Packet types
+// without a Clone method are returned unchanged.
+func ClonePacket(p Packet) Packet {
+	if c, ok := p.(interface{ Clone() Packet }); ok {
+		return c.Clone()
+	}
+
+	return p
+}
+
+// RewriteSSRC replaces every SSRC that equals from with to in packets, such
+// as the sender, media source, report block and FIR entry SSRCs, and returns
+// the number of SSRCs replaced. It lets an SFU forward feedback between
+// streams with different SSRCs; a count of 0 means nothing referred to from.
+// Packet types without a RewriteSSRC method are left unchanged.
+func RewriteSSRC(packets []Packet, from, to uint32) int {
+	n := 0
+	for _, p := range packets {
+		if r, ok := p.(interface{ RewriteSSRC(from, to uint32) int }); ok {
+			n += r.RewriteSSRC(from, to)
+		}
+	}
+
+	return n
+}
+
+// ByType returns the packets of type T, in order, such as all the
+// TransportLayerNacks of a compound packet:
+//
+//	nacks := rtcp.ByType[*rtcp.TransportLayerNack](packets)
+//
+// It returns nil if there are none.
+func ByType[T Packet](packets []Packet) []T {
+	var out []T
+	for _, p := range packets {
+		if t, ok := p.(T); ok {
+			out = append(out, t)
+		}
+	}
+
+	return out
+}
+
+// Count returns the number of packets of type T, as len(ByType[T](packets))
+// would without allocating.
+func Count[T Packet](packets []Packet) int {
+	n := 0
+	for _, p := range packets {
+		if _, ok := p.(T); ok {
+			n++
+		}
+	}
+
+	return n
 }
 
 // Unmarshal takes an entire udp datagram (which may consist of multiple RTCP packets) and
@@ -20,47 +119,420 @@ type Packet interface {
 // If this is a reduced-size RTCP packet a feedback packet (Goodbye, SliceLossIndication, etc)
 // will be returned. Otherwise, the underlying type of the returned packet will be
 // CompoundPacket.
+//
+// A stray P bit on a packet before the last is tolerated, as described for
+// UnmarshalOptions.
 func Unmarshal(rawData []byte) ([]Packet, error) {
+	return unmarshalPackets(rawData, unmarshalConfig{newPacket: newPacket})
+}
+
+// UnmarshalNoCopy is like Unmarshal, but saves copying byte slice fields, such
+// as ApplicationDefined.Data, the ProfileExtensions of reports and RawPacket,
+// by having them refer to rawData instead. The packets are only valid while
+// rawData is not modified; rawData must not be reused for another datagram
+// until the caller is done with them, or has copied them with ClonePacket.
+// Padded packets are always copied.
+func UnmarshalNoCopy(rawData []byte) ([]Packet, error) {
+	return unmarshalPackets(rawData, unmarshalConfig{newPacket: newPacket, noCopy: true})
+}
+
+// DefaultMaxPackets is the number of packets that Unmarshal and its variants
+// parse from one datagram before failing with ErrTooManyPackets, unless
+// UnmarshalOptions.MaxPackets says otherwise. Real compound packets hold a
+// handful; the limit keeps data crafted from thousands of empty packets from
+// forcing large allocations.
+const DefaultMaxPackets = 128
+
+// UnmarshalOptions configures UnmarshalWithOptions.
+type UnmarshalOptions struct {
+	// Warn, if not nil, is called with an error describing each deviation
+	// from the RFCs that is tolerated rather than failing the datagram:
+	//
+	//   - a packet before the last with the P bit set, which RFC 3550
+	//     Section 6.4.1 reserves for the last packet but some senders set
+	//     anyway. Such packets are framed by their length field as usual, and
+	//     their padding is only removed if it is valid; if it is not, the P
+	//     bit is taken to be stray and the packet is parsed as unpadded.
+	//   - a packet whose length field covers bytes after its contents, which
+	//     are skipped, as UnmarshalStrict would reject.
+	//   - a SourceDescription item of an unknown type.
+	//
+	// The errors wrap ErrPaddingNotLast, ErrPacketLengthMismatch and
+	// ErrUnknownSDESType respectively.
+	Warn func(err error)
+
+	// NoCopy makes the packets refer to the data they are unmarshaled from,
+	// as UnmarshalNoCopy does.
+	NoCopy bool
+
+	// MaxPackets is the number of packets parsed from rawData before failing
+	// with ErrTooManyPackets. Zero means DefaultMaxPackets, and a negative
+	// value means no limit.
+	MaxPackets int
+
+	// Strict rejects the data that Unmarshal would skip over or parse a
+	// prefix of, as UnmarshalStrict does.
+	Strict bool
+
+	// Partial tolerates a truncated last packet, as UnmarshalPartial does.
+	Partial bool
+}
+
+// UnmarshalWithOptions is like Unmarshal, but configured by opts.
+func UnmarshalWithOptions(rawData []byte, opts UnmarshalOptions) ([]Packet, error) {
+	return unmarshalPackets(rawData, unmarshalConfig{
+		newPacket:  newPacket,
+		warn:       opts.Warn,
+		noCopy:     opts.NoCopy,
+		maxPackets: opts.MaxPackets,
+		strict:     opts.Strict,
+		partial:    opts.Partial,
+	})
+}
+
+// UnmarshalFirst unmarshals rawData like Unmarshal and returns the first
+// packet of type T, e.g.
+//
+//	rr, err := rtcp.UnmarshalFirst[*rtcp.ReceiverReport](buf)
+//
+// If rawData parses but contains no such packet, the error is a
+// *PacketNotFoundError.
+func UnmarshalFirst[T Packet](rawData []byte) (T, error) {
+	var zero T
+
+	packets, err := Unmarshal(rawData)
+	if err != nil {
+		return zero, err
+	}
+
+	for _, p := range packets {
+		if t, ok := p.(T); ok {
+			return t, nil
+		}
+	}
+
+	return zero, &PacketNotFoundError{Type: reflect.TypeOf((*T)(nil)).Elem().String()}
+}
+
+// UnmarshalStrict is like Unmarshal, but rejects data that Unmarshal would
+// skip over or parse a prefix of. It fails if bytes remain after the last
+// whole packet, and if a packet's length field covers more data than the
+// packet is made of, such as unused bytes after a Goodbye reason. Packets of
+// unknown types are only checked to be whole. It is UnmarshalWithOptions with
+// only Strict set.
+func UnmarshalStrict(rawData []byte) ([]Packet, error) {
+	return unmarshalPackets(rawData, unmarshalConfig{newPacket: newPacket, strict: true})
+}
+
+// UnmarshalPartial is like Unmarshal, but tolerates a datagram whose last
+// packet was cut short, as may happen on lossy transports. If the last packet
+// is shorter than its header or than its length field declares, the packets
+// before it are returned along with an error wrapping ErrTruncatedPacket.
+// Any other error fails the whole datagram, as with Unmarshal. It is
+// UnmarshalWithOptions with only Partial set.
+func UnmarshalPartial(rawData []byte) ([]Packet, error) {
+	return unmarshalPackets(rawData, unmarshalConfig{newPacket: newPacket, partial: true})
+}
+
+// ReceivedPackets holds the packets of one datagram along with the time it
+// was received, for monitoring code that correlates report timing with
+// arrival. The receive time is not part of any packet, so marshaling the
+// packets does not carry it.
+type ReceivedPackets struct {
+	packets     []Packet
+	receiveTime time.Time
+}
+
+// UnmarshalWithTime is like Unmarshal, but annotates the packets with recv,
+// the time rawData was received.
+func UnmarshalWithTime(rawData []byte, recv time.Time) (ReceivedPackets, error) {
+	packets, err := Unmarshal(rawData)
+	if err != nil {
+		return ReceivedPackets{}, err
+	}
+
+	return ReceivedPackets{packets: packets, receiveTime: recv}, nil
+}
+
+// Packets returns the unmarshaled packets.
+func (r ReceivedPackets) Packets() []Packet {
+	return r.packets
+}
+
+// ReceiveTime returns the time the packets were received.
+func (r ReceivedPackets) ReceiveTime() time.Time {
+	return r.receiveTime
+}
+
+// PacketCount returns the number of RTCP packets in data, walking their
+// header length fields without unmarshaling or allocating the packets. It
+// fails if a header is invalid or a length field runs past the end of data,
+// and like Unmarshal rejects empty data with ErrInvalidHeader.
+func PacketCount(data []byte) (int, error) {
+	if len(data) == 0 {
+		return 0, ErrInvalidHeader
+	}
+
+	count := 0
+	for offset := 0; offset < len(data); count++ {
+		var header Header
+		if err := header.Unmarshal(data[offset:]); err != nil {
+			return 0, err
+		}
+
+		size := (int(header.Length) + 1) * 4
+		if size > len(data)-offset {
+			return 0, fmt.Errorf("%w: length %d at offset %d needs %d bytes, %d remain",
+				ErrPacketTooShort, header.Length, offset, size, len(data)-offset)
+		}
+		offset += size
+	}
+
+	return count, nil
+}
+
+// isTruncated reports whether rawData ends before the packet at its start
+// does. Data with an invalid header is not considered truncated.
+func isTruncated(rawData []byte) bool {
+	if len(rawData) < headerLength {
+		return true
+	}
+
+	var header Header
+	if err := header.Unmarshal(rawData); err != nil {
+		return false
+	}
+
+	return (int(header.Length)+1)*4 > len(rawData)
+}
+
+// unmarshalConfig configures unmarshalPackets.
+type unmarshalConfig struct {
+	// newPacket allocates each packet before it is unmarshaled.
+	newPacket func(Header, []byte) Packet
+	// warn, if not nil, is called for each tolerated problem.
+	warn func(error)
+	// noCopy lets packets refer to the data they are unmarshaled from.
+	noCopy bool
+	// maxPackets is the most packets parsed, DefaultMaxPackets if zero and
+	// unlimited if negative.
+	maxPackets int
+	// strict fails on trailing bytes and length mismatches, and does not
+	// retry a stray P bit.
+	strict bool
+	// partial returns the packets before a truncated last packet.
+	partial bool
+}
+
+// unmarshalPackets implements Unmarshal and its variants.
+func unmarshalPackets(rawData []byte, cfg unmarshalConfig) ([]Packet, error) {
+	maxPackets := cfg.maxPackets
+	if maxPackets == 0 {
+		maxPackets = DefaultMaxPackets
+	}
+
 	var packets []Packet
-	for len(rawData) != 0 {
-		p, processed, err := unmarshal(rawData)
+	for offset := 0; offset < len(rawData); {
+		if len(packets) == maxPackets {
+			return nil, tooManyPackets(maxPackets, offset)
+		}
+
+		if cfg.partial && isTruncated(rawData[offset:]) {
+			return packets, fmt.Errorf("%w: %d bytes at offset %d: %w",
+				ErrTruncatedPacket, len(rawData)-offset, offset, ErrPacketTooShort)
+		}
+
+		p, processed, err := unmarshalWith(rawData[offset:], cfg.newPacket, cfg.noCopy)
+		if processed != 0 && offset+processed < len(rawData) && rawData[offset]>>paddingShift&paddingMask != 0 {
+			if err != nil && !cfg.strict {
+				p, err = unmarshalStrayPadding(rawData[offset:offset+processed], cfg.newPacket, err)
+			}
+			if err == nil && cfg.warn != nil {
+				cfg.warn(fmt.Errorf("%w: %T at offset %d", ErrPaddingNotLast, p, offset))
+			}
+		}
 		if err != nil {
+			if cfg.strict && processed == 0 && offset > 0 {
+				return nil, fmt.Errorf("%w: %d bytes at offset %d: %w", ErrTrailingData, len(rawData)-offset, offset, err)
+			}
+
 			return nil, err
 		}
+		if cfg.strict {
+			if err := lengthMismatch(p, processed, offset); err != nil {
+				return nil, err
+			}
+		}
+		if cfg.warn != nil {
+			warnTolerated(p, processed, offset, cfg.warn)
+		}
 
 		packets = append(packets, p)
-		rawData = rawData[processed:]
+		offset += processed
 	}
 
 	switch len(packets) {
 	// Empty packet
 	case 0:
-		return nil, errInvalidHeader
+		return nil, ErrInvalidHeader
 	// Multiple Packets
 	default:
 		return packets, nil
 	}
 }
 
+// tooManyPackets returns the error for data with a packet at offset after the
+// maxPackets already parsed.
+func tooManyPackets(maxPackets, offset int) error {
+	return fmt.Errorf("%w: more than %d, the next at offset %d", ErrTooManyPackets, maxPackets, offset)
+}
+
+// warnTolerated reports the deviations in p, unmarshaled from processed
+// bytes at offset, that Unmarshal does not fail on.
+func warnTolerated(p Packet, processed, offset int, warn func(error)) {
+	if err := lengthMismatch(p, processed, offset); err != nil {
+		warn(err)
+	}
+
+	if sdes, ok := p.(*SourceDescription); ok {
+		for _, chunk := range sdes.Chunks {
+			for _, item := range chunk.Items {
+				if item.Type > SDESPrivate {
+					warn(fmt.Errorf("%w: %d for source %x at offset %d", ErrUnknownSDESType, item.Type, chunk.Source, offset))
+				}
+			}
+		}
+	}
+}
+
+// lengthMismatch returns an error wrapping ErrPacketLengthMismatch if p,
+// unmarshaled from processed bytes at offset, holds fewer bytes than its
+// length field covers. Packets of unknown types are not checked.
+func lengthMismatch(p Packet, processed, offset int) error {
+	if _, raw := p.(*RawPacket); !raw && p.MarshalSize() != processed {
+		return fmt.Errorf("%w: %T at offset %d has length %d but holds %d bytes",
+			ErrPacketLengthMismatch, p, offset, processed, p.MarshalSize())
+	}
+
+	return nil
+}
+
+// unmarshalStrayPadding retries unmarshaling inPacket, a whole packet with
+// the P bit set that failed with err, as if the P bit were clear. If that
+// fails too, err is returned. The packet refers to a copy of inPacket.
+func unmarshalStrayPadding(inPacket []byte, newPacket func(Header, []byte) Packet, err error) (Packet, error) {
+	unpadded := append([]byte{}, inPacket...)
+	unpadded[0] &^= 1 << paddingShift
+
+	p, _, retryErr := unmarshalWith(unpadded, newPacket, true)
+	if retryErr != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
 // Marshal takes an array of Packets and serializes them to a single buffer.
+// It is the inverse of Unmarshal. Only the individual packets are checked;
+// use CompoundPacket.Validate to check that they form a valid compound
+// packet.
 func Marshal(packets []Packet) ([]byte, error) {
-	out := make([]byte, 0)
+	size := 0
 	for _, p := range packets {
-		data, err := p.Marshal()
+		size += p.MarshalSize()
+	}
+
+	out := make([]byte, size)
+	n, err := marshalPacketsTo(out, packets)
+	if err != nil {
+		return nil, err
+	}
+
+	return out[:n], nil
+}
+
+// MarshalStrict is like Marshal, but first checks each packet with its
+// Validate method. It catches packets that Marshal encodes but the RFCs
+// forbid, such as a FullIntraRequest without FIR entries or a
+// TransportLayerNack without NACK pairs.
+func MarshalStrict(packets []Packet) ([]byte, error) {
+	for i, p := range packets {
+		if v, ok := p.(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return nil, fmt.Errorf("%w: %T at index %d", err, p, i)
+			}
+		}
+	}
+
+	return Marshal(packets)
+}
+
+// MarshalAppend marshals packets as Marshal does, appending them to dst, and
+// returns the extended slice. A dst with enough spare capacity, such as a
+// scratch buffer reused across sends, is written in place without allocating.
+// On error dst is returned unchanged.
+func MarshalAppend(dst []byte, packets []Packet) ([]byte, error) {
+	size := 0
+	for _, p := range packets {
+		size += p.MarshalSize()
+	}
+
+	return marshalAppend(dst, size, func(buf []byte) (int, error) {
+		return marshalPacketsTo(buf, packets)
+	})
+}
+
+// Packets is a list of packets to be sent together, which implements
+// io.WriterTo so that they can be written to a net.Conn or other io.Writer
+// directly:
+//
+//	_, err := rtcp.Packets{sr, sdes}.WriteTo(conn)
+type Packets []Packet
+
+// WriteTo marshals the packets as Marshal does and writes them to w with a
+// single call to Write, so that they are sent as one datagram on packet
+// oriented connections. It returns the number of bytes written.
+func (p Packets) WriteTo(w io.Writer) (int64, error) {
+	data, err := Marshal(p)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(data)
+	if err == nil && n < len(data) {
+		err = io.ErrShortWrite
+	}
+
+	return int64(n), err
+}
+
+// marshalPacketsTo encodes packets back to back into buf and returns the
+// number of bytes written.
+func marshalPacketsTo(buf []byte, packets []Packet) (int, error) {
+	offset := 0
+	for _, p := range packets {
+		n, err := p.MarshalTo(buf[offset:])
 		if err != nil {
-			return nil, err
+			return 0, err
 		}
-		out = append(out, data...)
+		offset += n
 	}
 
-	return out, nil
+	return offset, nil
 }
 
 // unmarshal is a factory which pulls the first RTCP packet from a bytestream,
 // and returns it's parsed representation, and the amount of data that was processed.
-//
-//nolint:cyclop
 func unmarshal(rawData []byte) (packet Packet, bytesprocessed int, err error) {
+	return unmarshalWith(rawData, newPacket, false)
+}
+
+// unmarshalWith implements unmarshal, using newPacket to allocate the packet.
+// If noCopy is set, packets that support it refer to rawData instead of
+// copying it. The packet is returned even if unmarshaling it fails.
+func unmarshalWith(
+	rawData []byte, newPacket func(Header, []byte) Packet, noCopy bool,
+) (packet Packet, bytesprocessed int, err error) {
 	var header Header
 
 	err = header.Unmarshal(rawData)
@@ -68,12 +540,26 @@ func unmarshal(rawData []byte) (packet Packet, bytesprocessed int, err error) {
 		return nil, 0, err
 	}
 
-	bytesprocessed = int(header.Length+1) * 4
+	bytesprocessed = (int(header.Length) + 1) * 4
 	if bytesprocessed > len(rawData) {
-		return nil, 0, errPacketTooShort
+		return nil, 0, ErrPacketTooShort
 	}
 	inPacket := rawData[:bytesprocessed]
 
+	packet = newPacket(header, inPacket)
+	if p, ok := packet.(interface{ unmarshalNoCopy(rawPacket []byte) error }); ok && noCopy {
+		err = p.unmarshalNoCopy(inPacket)
+	} else {
+		err = packet.Unmarshal(inPacket)
+	}
+
+	return packet, bytesprocessed, err
+}
+
+// newPacket allocates the packet type described by header. inPacket is the
+// whole packet, which is needed to tell REMB apart from other application
+// layer feedback.
+func newPacket(header Header, inPacket []byte) (packet Packet) {
 	switch header.Type {
 	case TypeSenderReport:
 		packet = new(SenderReport)
@@ -87,31 +573,15 @@ func unmarshal(rawData []byte) (packet Packet, bytesprocessed int, err error) {
 	case TypeGoodbye:
 		packet = new(Goodbye)
 
-	case TypeTransportSpecificFeedback:
-		switch header.Count {
-		case FormatTLN:
-			packet = new(TransportLayerNack)
-		case FormatRRR:
-			packet = new(RapidResynchronizationRequest)
-		case FormatTCC:
-			packet = new(TransportLayerCC)
-		case FormatCCFB:
-			packet = new(CCFeedbackReport)
-		default:
-			packet = new(RawPacket)
-		}
-
-	case TypePayloadSpecificFeedback:
-		switch header.Count {
-		case FormatPLI:
-			packet = new(PictureLossIndication)
-		case FormatSLI:
-			packet = new(SliceLossIndication)
-		case FormatREMB:
+	case TypeTransportSpecificFeedback, TypePayloadSpecificFeedback:
+		// REMB is one kind of application layer feedback
+		if header.Type == TypePayloadSpecificFeedback && header.Count == FormatREMB && hasREMBIdentifier(inPacket) {
 			packet = new(ReceiverEstimatedMaximumBitrate)
-		case FormatFIR:
-			packet = new(FullIntraRequest)
-		default:
+		} else if factory := lookupFeedback(header.Type, header.Count); factory != nil {
+			packet = factory()
+		} else if len(inPacket) >= headerLength+genericFCIOffset {
+			packet = new(GenericFeedback)
+		} else {
 			packet = new(RawPacket)
 		}
 
@@ -125,7 +595,19 @@ func unmarshal(rawData []byte) (packet Packet, bytesprocessed int, err error) {
 		packet = new(RawPacket)
 	}
 
-	err = packet.Unmarshal(inPacket)
+	return packet
+}
 
-	return packet, bytesprocessed, err
+// marshalAppend implements the MarshalAppend methods of the packet types,
+// appending size bytes written by marshalTo to dst. On error dst is returned
+// unchanged.
+func marshalAppend(dst []byte, size int, marshalTo func(buf []byte) (int, error)) ([]byte, error) {
+	n := len(dst)
+	dst = append(dst, make([]byte, size)...)
+	written, err := marshalTo(dst[n:])
+	if err != nil {
+		return dst[:n], err
+	}
+
+	return dst[:n+written], nil
 }
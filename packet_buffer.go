@@ -36,6 +36,14 @@ import (
 // encode as 8 bytes of value "0", followed by two bytes
 // encoding "C" in network order.
 
+// A trailingPadder is a structure whose encoding ends with bytes that no
+// field holds, such as a terminating null chunk. They are skipped rather
+// than written, so the buffer must be zeroed beforehand, and are counted by
+// wireSize.
+type trailingPadder interface {
+	trailingPadding() int
+}
+
 type packetBuffer struct {
 	bytes []byte
 }
@@ -116,6 +124,13 @@ func (b *packetBuffer) write(v interface{}) error {
 				b.bytes = b.bytes[advance:]
 			}
 		}
+		if p, ok := v.(trailingPadder); ok {
+			advance := p.trailingPadding()
+			if len(b.bytes) < advance {
+				return errWrongMarshalSize
+			}
+			b.bytes = b.bytes[advance:]
+		}
 	default:
 		return errBadStructMemberType
 	}
@@ -261,6 +276,9 @@ func wireSize(v interface{}) int {
 				size += int(value.Field(i).Type().Size())
 			}
 		}
+		if p, ok := v.(trailingPadder); ok {
+			size += p.trailingPadding()
+		}
 
 	default:
 		size = int(value.Type().Size())
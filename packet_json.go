@@ -0,0 +1,366 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+/*
+These functions implement an introspective JSON encoding for RTCP packets,
+intended for logging and for capturing packets in tests.
+
+  - Every packet, and every report block or status chunk stored behind an
+    interface, is encoded as an object with a "type" discriminator
+    (e.g. "receiver_report") followed by its exported fields under their
+    Go names. Embedded structs are flattened.
+
+  - uint32 fields (and slices of them) that hold SSRCs are encoded as hex
+    strings such as "0x902f9e2e". A field holds SSRCs when its name
+    contains "SSRC" or is "Source" or "Sources".
+
+  - All other fields use the encoding/json defaults.
+*/
+
+const jsonTypeKey = "type"
+
+// jsonTypes maps each "type" discriminator to a constructor for the value
+// it describes.
+var jsonTypes = map[string]func() interface{}{ //nolint:gochecknoglobals
+	"sender_report":                                  func() interface{} { return &SenderReport{} },
+	"receiver_report":                                func() interface{} { return &ReceiverReport{} },
+	"source_description":                             func() interface{} { return &SourceDescription{} },
+	"goodbye":                                        func() interface{} { return &Goodbye{} },
+	"application_defined":                            func() interface{} { return &ApplicationDefined{} },
+	"picture_loss_indication":                        func() interface{} { return &PictureLossIndication{} },
+	"slice_loss_indication":                          func() interface{} { return &SliceLossIndication{} },
+	"reference_picture_selection_indication":         func() interface{} { return &ReferencePictureSelectionIndication{} },
+	"full_intra_request":                             func() interface{} { return &FullIntraRequest{} },
+	"application_layer_feedback":                     func() interface{} { return &ApplicationLayerFeedback{} },
+	"receiver_estimated_maximum_bitrate":             func() interface{} { return &ReceiverEstimatedMaximumBitrate{} },
+	"rapid_resynchronization_request":                func() interface{} { return &RapidResynchronizationRequest{} },
+	"transport_layer_nack":                           func() interface{} { return &TransportLayerNack{} },
+	"transport_layer_cc":                             func() interface{} { return &TransportLayerCC{} },
+	"temporary_maximum_media_stream_bitrate_request": func() interface{} { return &TemporaryMaximumMediaStreamBitrateRequest{} },
+	"temporary_maximum_media_stream_bitrate_notification": func() interface{} {
+		return &TemporaryMaximumMediaStreamBitrateNotification{}
+	},
+	"cc_feedback_report":                   func() interface{} { return &CCFeedbackReport{} },
+	"ecn_feedback":                         func() interface{} { return &ECNFeedback{} },
+	"generic_feedback":                     func() interface{} { return &GenericFeedback{} },
+	"extended_report":                      func() interface{} { return &ExtendedReport{} },
+	"raw_packet":                           func() interface{} { return &RawPacket{} },
+	"compound_packet":                      func() interface{} { return &CompoundPacket{} },
+	"loss_rle_report_block":                func() interface{} { return &LossRLEReportBlock{} },
+	"duplicate_rle_report_block":           func() interface{} { return &DuplicateRLEReportBlock{} },
+	"packet_receipt_times_report_block":    func() interface{} { return &PacketReceiptTimesReportBlock{} },
+	"receiver_reference_time_report_block": func() interface{} { return &ReceiverReferenceTimeReportBlock{} },
+	"dlrr_report_block":                    func() interface{} { return &DLRRReportBlock{} },
+	"statistics_summary_report_block":      func() interface{} { return &StatisticsSummaryReportBlock{} },
+	"voip_metrics_report_block":            func() interface{} { return &VoIPMetricsReportBlock{} },
+	"unknown_report_block":                 func() interface{} { return &UnknownReportBlock{} },
+	"run_length_chunk":                     func() interface{} { return &RunLengthChunk{} },
+	"status_vector_chunk":                  func() interface{} { return &StatusVectorChunk{} },
+}
+
+// jsonTypeNames is the inverse of jsonTypes, keyed by the non-pointer type.
+var jsonTypeNames = func() map[reflect.Type]string { //nolint:gochecknoglobals
+	names := make(map[reflect.Type]string, len(jsonTypes))
+	for name, ctor := range jsonTypes {
+		names[reflect.TypeOf(ctor()).Elem()] = name
+	}
+
+	return names
+}()
+
+// UnmarshalPacketJSON decodes a packet encoded by one of the MarshalJSON
+// methods, using the "type" field to pick the concrete packet type.
+func UnmarshalPacketJSON(data []byte) (Packet, error) {
+	name, err := jsonTypeName(data)
+	if err != nil {
+		return nil, err
+	}
+
+	ctor, ok := jsonTypes[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errUnknownJSONType, name)
+	}
+
+	packet, ok := ctor().(Packet)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errUnknownJSONType, name)
+	}
+
+	if err := json.Unmarshal(data, packet); err != nil {
+		return nil, err
+	}
+
+	return packet, nil
+}
+
+// jsonTypeName returns the "type" discriminator of a JSON object. The key is
+// matched exactly, as some types also have a field named Type.
+func jsonTypeName(data []byte) (string, error) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return "", err
+	}
+
+	var name string
+	if err := json.Unmarshal(object[jsonTypeKey], &name); err != nil {
+		return "", errMissingJSONType
+	}
+
+	return name, nil
+}
+
+// marshalJSON encodes v, which must be a struct or a pointer to one.
+func marshalJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeJSONValue(&buf, reflect.Indirect(reflect.ValueOf(v)), false); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// unmarshalJSON decodes data into v, which must be a pointer to a struct.
+func unmarshalJSON(data []byte, v interface{}) error {
+	return readJSONValue(data, reflect.ValueOf(v).Elem(), false)
+}
+
+func isSSRCField(field reflect.StructField) bool {
+	return strings.Contains(field.Name, "SSRC") || field.Name == "Source" || field.Name == "Sources"
+}
+
+// jsonFields returns the exported fields of a struct value, flattening
+// embedded structs and skipping embedded interfaces.
+func jsonFields(value reflect.Value) ([]reflect.StructField, []reflect.Value) {
+	var fields []reflect.StructField
+	var values []reflect.Value
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		switch {
+		case !field.IsExported():
+		case field.Anonymous && field.Type.Kind() == reflect.Interface:
+		case field.Anonymous && field.Type.Kind() == reflect.Struct:
+			f, v := jsonFields(value.Field(i))
+			fields = append(fields, f...)
+			values = append(values, v...)
+		default:
+			fields = append(fields, field)
+			values = append(values, value.Field(i))
+		}
+	}
+
+	return fields, values
+}
+
+//nolint:cyclop
+func writeJSONValue(buf *bytes.Buffer, value reflect.Value, hex bool) error {
+	switch value.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if value.IsNil() {
+			buf.WriteString("null")
+
+			return nil
+		}
+		if marshaler, ok := value.Interface().(json.Marshaler); ok {
+			data, err := marshaler.MarshalJSON()
+			if err != nil {
+				return err
+			}
+			buf.Write(data)
+
+			return nil
+		}
+
+		return writeJSONValue(buf, value.Elem(), hex)
+	case reflect.Struct:
+		return writeJSONObject(buf, value)
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+		if value.IsNil() {
+			buf.WriteString("null")
+
+			return nil
+		}
+		buf.WriteByte('[')
+		for i := 0; i < value.Len(); i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSONValue(buf, value.Index(i), hex); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+
+		return nil
+	case reflect.Uint32:
+		if hex {
+			fmt.Fprintf(buf, "\"0x%08x\"", value.Uint())
+
+			return nil
+		}
+	default:
+	}
+
+	data, err := json.Marshal(value.Interface())
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+
+	return nil
+}
+
+func writeJSONObject(buf *bytes.Buffer, value reflect.Value) error {
+	buf.WriteByte('{')
+	sep := ""
+	if name, ok := jsonTypeNames[value.Type()]; ok {
+		fmt.Fprintf(buf, "%q:%q", jsonTypeKey, name)
+		sep = ","
+	}
+
+	fields, values := jsonFields(value)
+	for i, field := range fields {
+		fmt.Fprintf(buf, "%s%q:", sep, field.Name)
+		sep = ","
+		if err := writeJSONValue(buf, values[i], isSSRCField(field)); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+
+	return nil
+}
+
+//nolint:cyclop,gocognit
+func readJSONValue(data []byte, value reflect.Value, hex bool) error {
+	isNull := bytes.Equal(bytes.TrimSpace(data), []byte("null"))
+
+	switch value.Kind() {
+	case reflect.Interface:
+		if isNull {
+			value.Set(reflect.Zero(value.Type()))
+
+			return nil
+		}
+		name, err := jsonTypeName(data)
+		if err != nil {
+			return err
+		}
+		ctor, ok := jsonTypes[name]
+		if !ok {
+			return fmt.Errorf("%w: %q", errUnknownJSONType, name)
+		}
+		elem := reflect.ValueOf(ctor())
+		if !elem.Type().AssignableTo(value.Type()) {
+			return fmt.Errorf("%w: %q is not a %s", errUnknownJSONType, name, value.Type())
+		}
+		if unmarshaler, ok := elem.Interface().(json.Unmarshaler); ok {
+			err = unmarshaler.UnmarshalJSON(data)
+		} else {
+			err = readJSONValue(data, elem.Elem(), false)
+		}
+		if err != nil {
+			return err
+		}
+		value.Set(elem)
+
+		return nil
+	case reflect.Ptr:
+		if isNull {
+			value.Set(reflect.Zero(value.Type()))
+
+			return nil
+		}
+		elem := reflect.New(value.Type().Elem())
+		if err := readJSONValue(data, elem.Elem(), hex); err != nil {
+			return err
+		}
+		value.Set(elem)
+
+		return nil
+	case reflect.Struct:
+		return readJSONObject(data, value)
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+		if isNull {
+			value.Set(reflect.Zero(value.Type()))
+
+			return nil
+		}
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return err
+		}
+		slice := reflect.MakeSlice(value.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := readJSONValue(item, slice.Index(i), hex); err != nil {
+				return err
+			}
+		}
+		value.Set(slice)
+
+		return nil
+	case reflect.Uint32:
+		if hex {
+			var s string
+			if err := json.Unmarshal(data, &s); err != nil {
+				return err
+			}
+			ssrc, err := strconv.ParseUint(s, 0, 32)
+			if err != nil {
+				return fmt.Errorf("%w: %q", errInvalidJSONSSRC, s)
+			}
+			value.SetUint(ssrc)
+
+			return nil
+		}
+	default:
+	}
+
+	return json.Unmarshal(data, value.Addr().Interface())
+}
+
+func readJSONObject(data []byte, value reflect.Value) error {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+
+	if want, ok := jsonTypeNames[value.Type()]; ok {
+		name, err := jsonTypeName(data)
+		if err != nil {
+			return err
+		}
+		if name != want {
+			return fmt.Errorf("%w: expected(%s) actual(%s)", errWrongJSONType, want, name)
+		}
+	}
+
+	fields, values := jsonFields(value)
+	for i, field := range fields {
+		raw, ok := object[field.Name]
+		if !ok {
+			continue
+		}
+		if err := readJSONValue(raw, values[i], isSSRCField(field)); err != nil {
+			return fmt.Errorf("%s: %w", field.Name, err)
+		}
+	}
+
+	return nil
+}
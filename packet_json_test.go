@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPacketJSONRoundTrip(t *testing.T) {
+	packets := samplePackets()
+	packets = append(packets, &CompoundPacket{
+		&ReceiverReport{SSRC: 1},
+		NewCNAMESourceDescription(1, "cname"),
+	})
+
+	for _, packet := range packets {
+		data, err := json.Marshal(packet)
+		assert.NoErrorf(t, err, "Marshal %T", packet)
+
+		decoded, err := UnmarshalPacketJSON(data)
+		assert.NoErrorf(t, err, "Unmarshal %T: %s", packet, data)
+		assert.Truef(t, PacketsEqual([]Packet{packet}, []Packet{decoded}), "round trip %T: %s", packet, data)
+	}
+}
+
+func TestPacketJSONShape(t *testing.T) {
+	data, err := json.Marshal(&ReceiverReport{
+		SSRC:    0x902f9e2e,
+		Reports: []ReceptionReport{{SSRC: 0xbc5e9a40, Jitter: 273}},
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "receiver_report",
+		"SSRC": "0x902f9e2e",
+		"Reports": [{
+			"SSRC": "0xbc5e9a40",
+			"FractionLost": 0,
+			"TotalLost": 0,
+			"LastSequenceNumber": 0,
+			"Jitter": 273,
+			"LastSenderReport": 0,
+			"Delay": 0
+		}],
+		"ProfileExtensions": null
+	}`, string(data))
+
+	data, err = json.Marshal(&ExtendedReport{
+		SenderSSRC: 1,
+		Reports:    []ReportBlock{&ReceiverReferenceTimeReportBlock{NTPTimestamp: 2}},
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "extended_report",
+		"SenderSSRC": "0x00000001",
+		"Reports": [{
+			"type": "receiver_reference_time_report_block",
+			"BlockType": 0,
+			"TypeSpecific": 0,
+			"BlockLength": 0,
+			"NTPTimestamp": 2
+		}]
+	}`, string(data))
+
+	data, err = json.Marshal(&Goodbye{Sources: []uint32{0xabcdef12}})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type": "goodbye", "Sources": ["0xabcdef12"], "Reason": ""}`, string(data))
+}
+
+func TestPacketJSONErrors(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Data      string
+		WantError error
+	}{
+		{
+			Name:      "missing type",
+			Data:      `{"SSRC": "0x1"}`,
+			WantError: errMissingJSONType,
+		},
+		{
+			Name:      "unknown type",
+			Data:      `{"type": "not_a_packet"}`,
+			WantError: errUnknownJSONType,
+		},
+		{
+			Name:      "report block is not a packet",
+			Data:      `{"type": "dlrr_report_block"}`,
+			WantError: errUnknownJSONType,
+		},
+		{
+			Name:      "invalid SSRC",
+			Data:      `{"type": "goodbye", "Sources": ["zz"]}`,
+			WantError: errInvalidJSONSSRC,
+		},
+		{
+			Name:      "report block of wrong type",
+			Data:      `{"type": "extended_report", "Reports": [{"type": "run_length_chunk"}]}`,
+			WantError: errUnknownJSONType,
+		},
+	} {
+		_, err := UnmarshalPacketJSON([]byte(test.Data))
+		assert.ErrorIsf(t, err, test.WantError, "Unmarshal %q", test.Name)
+	}
+
+	var rr ReceiverReport
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"type": "sender_report"}`), &rr), errWrongJSONType)
+
+	var raw RawPacket
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"type": "goodbye"}`), &raw), errWrongJSONType)
+}
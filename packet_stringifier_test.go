@@ -310,7 +310,8 @@ func TestPrint(t *testing.T) {
 				"\t\t\tItems:\n" +
 				"\t\t\t\t0:\n" +
 				"\t\t\t\t\tType: [CNAME]\n" +
-				"\t\t\t\t\tText: {9c00eb92-1afb-9d49-a47d-91f64eee69f5}\n",
+				"\t\t\t\t\tText: {9c00eb92-1afb-9d49-a47d-91f64eee69f5}\n" +
+				"\t\t\t\t\tPrefix: \n",
 		},
 		{
 			&PictureLossIndication{
@@ -427,9 +428,11 @@ func TestPrint(t *testing.T) {
 				"\t\t\t\t0:\n" +
 				"\t\t\t\t\tType: [CNAME]\n" +
 				"\t\t\t\t\tText: A\n" +
+				"\t\t\t\t\tPrefix: \n" +
 				"\t\t\t\t1:\n" +
 				"\t\t\t\t\tType: [PHONE]\n" +
-				"\t\t\t\t\tText: B\n",
+				"\t\t\t\t\tText: B\n" +
+				"\t\t\t\t\tPrefix: \n",
 		},
 		{
 			&TransportLayerCC{
@@ -4,7 +4,11 @@
 package rtcp
 
 import (
+	"bytes"
+	"io"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -124,7 +128,529 @@ func TestUnmarshal(t *testing.T) {
 
 func TestUnmarshalNil(t *testing.T) {
 	_, err := Unmarshal(nil)
-	assert.ErrorIs(t, err, errInvalidHeader)
+	assert.ErrorIs(t, err, ErrInvalidHeader)
+}
+
+func TestUnmarshalFirst(t *testing.T) {
+	packets, err := Unmarshal(realPacket())
+	assert.NoError(t, err)
+
+	rr, err := UnmarshalFirst[*ReceiverReport](realPacket())
+	assert.NoError(t, err)
+	assert.Equal(t, packets[0], rr)
+
+	pli, err := UnmarshalFirst[*PictureLossIndication](realPacket())
+	assert.NoError(t, err)
+	assert.Equal(t, packets[3], pli)
+
+	_, err = UnmarshalFirst[*SenderReport](realPacket())
+	var notFound *PacketNotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.Equal(t, "*rtcp.SenderReport", notFound.Type)
+
+	_, err = UnmarshalFirst[*ReceiverReport](nil)
+	assert.ErrorIs(t, err, ErrInvalidHeader)
+}
+
+func TestUnmarshalStrict(t *testing.T) {
+	want, err := Unmarshal(realPacket())
+	assert.NoError(t, err)
+
+	packets, err := UnmarshalStrict(realPacket())
+	assert.NoError(t, err)
+	assert.Equal(t, want, packets)
+
+	for _, packet := range samplePackets() {
+		data, err := packet.Marshal()
+		assert.NoError(t, err)
+		_, err = UnmarshalStrict(data)
+		assert.NoErrorf(t, err, "UnmarshalStrict %T", packet)
+
+		if padded, ok := packet.(interface{ SetPadding(n uint8) error }); ok {
+			assert.NoError(t, padded.SetPadding(4))
+			data, err = packet.Marshal()
+			assert.NoError(t, err)
+			_, err = UnmarshalStrict(data)
+			assert.NoErrorf(t, err, "UnmarshalStrict padded %T", packet)
+		}
+	}
+
+	// A Goodbye whose length covers a word after its reason.
+	bye := []byte{
+		0x81, 0xcb, 0x00, 0x03,
+		0x90, 0x2f, 0x9e, 0x2e,
+		0x03, 0x46, 0x4f, 0x4f,
+		0x00, 0x00, 0x00, 0x00,
+	}
+	_, err = Unmarshal(bye)
+	assert.NoError(t, err)
+
+	for _, test := range []struct {
+		Name      string
+		Data      []byte
+		WantError error
+	}{
+		{"empty", nil, ErrInvalidHeader},
+		{"trailing bytes", append(realPacket(), 0x00, 0x00), ErrTrailingData},
+		{"trailing header", append(realPacket(), 0x81, 0xc9, 0x00, 0x07), ErrTrailingData},
+		{"truncated first packet", realPacket()[:20], ErrPacketTooShort},
+		{"unused bytes in packet", bye, ErrPacketLengthMismatch},
+	} {
+		_, err := UnmarshalStrict(test.Data)
+		assert.ErrorIsf(t, err, test.WantError, "UnmarshalStrict %q", test.Name)
+	}
+}
+
+func TestUnmarshalWarnings(t *testing.T) {
+	want, err := Unmarshal(realPacket())
+	assert.NoError(t, err)
+
+	goodbye := []byte{0x81, 0xcb, 0x00, 0x01, 0x90, 0x2f, 0x9e, 0x2e}
+	paddedPLI := &PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2}
+	assert.NoError(t, paddedPLI.SetPadding(4))
+	rawPaddedPLI, err := paddedPLI.Marshal()
+	assert.NoError(t, err)
+
+	for _, test := range []struct {
+		Name         string
+		Data         []byte
+		WantPackets  []Packet
+		WantWarnings int
+		WantError    error
+	}{
+		{
+			Name:        "no padding",
+			Data:        realPacket(),
+			WantPackets: want,
+		},
+		{
+			Name: "stray padding bit",
+			Data: append([]byte{
+				// receiver report with the P bit set and no padding
+				0xa0, 0xc9, 0x00, 0x01,
+				0x90, 0x2f, 0x9e, 0x2e,
+			}, goodbye...),
+			WantPackets:  []Packet{&ReceiverReport{SSRC: 0x902f9e2e}, &Goodbye{Sources: []uint32{0x902f9e2e}}},
+			WantWarnings: 1,
+		},
+		{
+			Name:         "padded interior packet",
+			Data:         append(append([]byte{}, rawPaddedPLI...), goodbye...),
+			WantPackets:  []Packet{paddedPLI, &Goodbye{Sources: []uint32{0x902f9e2e}}},
+			WantWarnings: 1,
+		},
+		{
+			Name: "stray padding bit on last packet",
+			Data: append(append([]byte{}, goodbye...),
+				0xa0, 0xc9, 0x00, 0x01,
+				0x90, 0x2f, 0x9e, 0x2e,
+			),
+			WantError: ErrWrongPadding,
+		},
+		{
+			Name: "invalid interior packet",
+			Data: append([]byte{
+				// sender report too short for its sender info
+				0xa0, 0xc8, 0x00, 0x01,
+				0x90, 0x2f, 0x9e, 0x2e,
+			}, goodbye...),
+			WantError: ErrWrongPadding,
+		},
+	} {
+		var warnings []error
+		packets, err := UnmarshalWithOptions(test.Data, UnmarshalOptions{
+			Warn: func(err error) { warnings = append(warnings, err) },
+		})
+		assert.ErrorIsf(t, err, test.WantError, "UnmarshalWithOptions %q", test.Name)
+		assert.Truef(t, PacketsEqual(test.WantPackets, packets), "UnmarshalWithOptions %q", test.Name)
+		assert.Lenf(t, warnings, test.WantWarnings, "UnmarshalWithOptions %q", test.Name)
+		for _, warning := range warnings {
+			assert.ErrorIsf(t, warning, ErrPaddingNotLast, "UnmarshalWithOptions %q", test.Name)
+		}
+
+		// Unmarshal tolerates the same packets, without the warnings.
+		packets, err = Unmarshal(test.Data)
+		assert.ErrorIsf(t, err, test.WantError, "Unmarshal %q", test.Name)
+		assert.Truef(t, PacketsEqual(test.WantPackets, packets), "Unmarshal %q", test.Name)
+	}
+}
+
+func TestUnmarshalWithOptions(t *testing.T) {
+	for _, test := range []struct {
+		Name         string
+		Data         []byte
+		WantWarnings []string
+		WantWarning  error
+	}{
+		{"real packet", realPacket(), nil, nil},
+		{
+			"stray padding bit",
+			[]byte{
+				0xa0, 0xc9, 0x00, 0x01, 0x90, 0x2f, 0x9e, 0x2e,
+				0x81, 0xcb, 0x00, 0x01, 0x90, 0x2f, 0x9e, 0x2e,
+			},
+			[]string{"rtcp: only the last packet in a compound may be padded: *rtcp.ReceiverReport at offset 0"},
+			ErrPaddingNotLast,
+		},
+		{
+			"trailing bytes",
+			[]byte{
+				// goodbye with a word after its sources
+				0x81, 0xcb, 0x00, 0x02, 0x90, 0x2f, 0x9e, 0x2e,
+				0x00, 0x00, 0x00, 0x00,
+			},
+			[]string{"rtcp: packet length does not match its contents: *rtcp.Goodbye at offset 0 has length 12 but holds 8 bytes"},
+			ErrPacketLengthMismatch,
+		},
+		{
+			"unknown sdes type",
+			[]byte{
+				0x81, 0xca, 0x00, 0x02, 0x90, 0x2f, 0x9e, 0x2e,
+				0x0c, 0x01, 0x41, 0x00,
+			},
+			[]string{"rtcp: unknown sdes item type: 12 for source 902f9e2e at offset 0"},
+			ErrUnknownSDESType,
+		},
+	} {
+		var warnings []string
+		packets, err := UnmarshalWithOptions(test.Data, UnmarshalOptions{
+			Warn: func(err error) {
+				assert.ErrorIsf(t, err, test.WantWarning, "UnmarshalWithOptions %q warning", test.Name)
+				warnings = append(warnings, err.Error())
+			},
+		})
+		assert.NoErrorf(t, err, "UnmarshalWithOptions %q", test.Name)
+		assert.Equalf(t, test.WantWarnings, warnings, "UnmarshalWithOptions %q", test.Name)
+
+		want, err := Unmarshal(test.Data)
+		assert.NoErrorf(t, err, "Unmarshal %q", test.Name)
+		assert.Truef(t, PacketsEqual(want, packets), "UnmarshalWithOptions %q", test.Name)
+
+		packets, err = UnmarshalWithOptions(test.Data, UnmarshalOptions{})
+		assert.NoErrorf(t, err, "UnmarshalWithOptions %q without Warn", test.Name)
+		assert.Truef(t, PacketsEqual(want, packets), "UnmarshalWithOptions %q without Warn", test.Name)
+	}
+}
+
+func TestUnmarshalMaxPackets(t *testing.T) {
+	receiverReports := func(n int) []byte {
+		var data []byte
+		for i := 0; i < n; i++ {
+			data = append(data, 0x80, 0xc9, 0x00, 0x01, 0x90, 0x2f, 0x9e, 0x2e)
+		}
+
+		return data
+	}
+
+	for _, test := range []struct {
+		Name       string
+		Packets    int
+		MaxPackets int
+		WantError  error
+	}{
+		{"default limit", DefaultMaxPackets, 0, nil},
+		{"over default limit", DefaultMaxPackets + 1, 0, ErrTooManyPackets},
+		{"custom limit", 3, 3, nil},
+		{"over custom limit", 4, 3, ErrTooManyPackets},
+		{"no limit", 1000, -1, nil},
+	} {
+		packets, err := UnmarshalWithOptions(receiverReports(test.Packets), UnmarshalOptions{MaxPackets: test.MaxPackets})
+		assert.ErrorIsf(t, err, test.WantError, "UnmarshalWithOptions %q", test.Name)
+		if test.WantError == nil {
+			assert.Lenf(t, packets, test.Packets, "UnmarshalWithOptions %q", test.Name)
+		}
+	}
+
+	data := receiverReports(DefaultMaxPackets + 1)
+	_, err := Unmarshal(data)
+	assert.ErrorIs(t, err, ErrTooManyPackets, "Unmarshal")
+	_, err = UnmarshalStrict(data)
+	assert.ErrorIs(t, err, ErrTooManyPackets, "UnmarshalStrict")
+	_, err = UnmarshalPartial(data)
+	assert.ErrorIs(t, err, ErrTooManyPackets, "UnmarshalPartial")
+	var compound CompoundPacket
+	assert.ErrorIs(t, compound.UnmarshalReducedSize(data), ErrTooManyPackets, "CompoundPacket.UnmarshalReducedSize")
+
+	// The limit of the strict, partial and compound variants can be lifted.
+	noLimit := UnmarshalOptions{MaxPackets: -1}
+	packets, err := UnmarshalWithOptions(data, UnmarshalOptions{MaxPackets: -1, Strict: true})
+	assert.NoError(t, err, "UnmarshalWithOptions strict")
+	assert.Len(t, packets, DefaultMaxPackets+1, "UnmarshalWithOptions strict")
+	packets, err = UnmarshalWithOptions(data[:len(data)-4], UnmarshalOptions{MaxPackets: -1, Partial: true})
+	assert.ErrorIs(t, err, ErrTruncatedPacket, "UnmarshalWithOptions partial")
+	assert.Len(t, packets, DefaultMaxPackets, "UnmarshalWithOptions partial")
+
+	sdes, err := NewCNAMESourceDescription(0x902f9e2e, "cname").Marshal()
+	assert.NoError(t, err)
+	compoundData := append(append([]byte(nil), data...), sdes...)
+	assert.ErrorIs(t, compound.Unmarshal(compoundData), ErrTooManyPackets, "CompoundPacket.Unmarshal")
+	assert.NoError(t, compound.UnmarshalWithOptions(compoundData, noLimit), "CompoundPacket.UnmarshalWithOptions")
+	assert.Len(t, compound, DefaultMaxPackets+2, "CompoundPacket.UnmarshalWithOptions")
+}
+
+func TestUnmarshalWithOptionsStrictPartial(t *testing.T) {
+	goodbye := []byte{0x81, 0xcb, 0x00, 0x01, 0x90, 0x2f, 0x9e, 0x2e}
+
+	for _, test := range []struct {
+		Name      string
+		Data      []byte
+		Opts      UnmarshalOptions
+		WantLen   int
+		WantError error
+	}{
+		{
+			"strict trailing bytes",
+			append(append([]byte{}, goodbye...), 0x80),
+			UnmarshalOptions{Strict: true}, 0, ErrTrailingData,
+		},
+		{
+			"strict length mismatch",
+			[]byte{0x81, 0xcb, 0x00, 0x02, 0x90, 0x2f, 0x9e, 0x2e, 0x00, 0x00, 0x00, 0x00},
+			UnmarshalOptions{Strict: true}, 0, ErrPacketLengthMismatch,
+		},
+		{
+			"partial truncated",
+			append(append([]byte{}, goodbye...), goodbye[:6]...),
+			UnmarshalOptions{Partial: true}, 1, ErrTruncatedPacket,
+		},
+		{"partial whole", append(append([]byte{}, goodbye...), goodbye...), UnmarshalOptions{Partial: true}, 2, nil},
+	} {
+		packets, err := UnmarshalWithOptions(test.Data, test.Opts)
+		assert.ErrorIsf(t, err, test.WantError, "UnmarshalWithOptions %q", test.Name)
+		assert.Lenf(t, packets, test.WantLen, "UnmarshalWithOptions %q", test.Name)
+	}
+}
+
+func TestUnmarshalNoCopy(t *testing.T) {
+	packets := []Packet{
+		&ReceiverReport{SSRC: 1, ProfileExtensions: []byte{1, 2, 3, 4}},
+		&SenderReport{SSRC: 1, ProfileExtensions: []byte{1, 2, 3, 4}},
+		&ApplicationDefined{SSRC: 1, Name: "NAME", Data: []byte{1, 2, 3, 4}},
+		&ApplicationLayerFeedback{SenderSSRC: 1, MediaSSRC: 2, FCI: []byte{1, 2, 3, 4}},
+		&RawPacket{0x80, 0xd0, 0x00, 0x01, 0x01, 0x02, 0x03, 0x04},
+	}
+	data, err := Marshal(packets)
+	assert.NoError(t, err)
+
+	copied, err := Unmarshal(data)
+	assert.NoError(t, err)
+	aliased, err := UnmarshalNoCopy(data)
+	assert.NoError(t, err)
+	assert.True(t, PacketsEqual(packets, copied))
+	assert.True(t, PacketsEqual(packets, aliased))
+
+	// Every packet ends with the bytes 1, 2, 3, 4.
+	for offset := 0; offset < len(data); offset += 4 {
+		if bytes.Equal(data[offset:offset+4], []byte{1, 2, 3, 4}) {
+			data[offset+3] = 5
+		}
+	}
+	assert.True(t, PacketsEqual(packets, copied), "Unmarshal copies")
+	for i, packet := range aliased {
+		data, err := packet.Marshal()
+		assert.NoError(t, err)
+		assert.Equalf(t, byte(5), data[len(data)-1], "UnmarshalNoCopy %T refers to the input", packets[i])
+	}
+}
+
+func TestUnmarshalPartial(t *testing.T) {
+	want, err := Unmarshal(realPacket())
+	assert.NoError(t, err)
+
+	packets, err := UnmarshalPartial(realPacket())
+	assert.NoError(t, err)
+	assert.Equal(t, want, packets)
+
+	// A receiver report declaring 8 words, of which only 2 arrived.
+	truncated := []byte{
+		0x81, 0xc9, 0x00, 0x07,
+		0x90, 0x2f, 0x9e, 0x2e,
+	}
+
+	for _, test := range []struct {
+		Name        string
+		Data        []byte
+		WantPackets []Packet
+		WantError   error
+	}{
+		{"empty", nil, nil, ErrInvalidHeader},
+		{"truncated first packet", truncated, nil, ErrTruncatedPacket},
+		{"truncated last packet", append(realPacket(), truncated...), want, ErrTruncatedPacket},
+		{"truncated last header", append(realPacket(), 0x81, 0xc9), want, ErrTruncatedPacket},
+		{"invalid last packet", append(realPacket(), 0x41, 0xc9, 0x00, 0x00), nil, ErrBadVersion},
+	} {
+		packets, err := UnmarshalPartial(test.Data)
+		assert.ErrorIsf(t, err, test.WantError, "UnmarshalPartial %q", test.Name)
+		assert.Equalf(t, test.WantPackets, packets, "UnmarshalPartial %q", test.Name)
+
+		// Unmarshal keeps rejecting the whole datagram.
+		packets, err = Unmarshal(test.Data)
+		assert.Errorf(t, err, "Unmarshal %q", test.Name)
+		assert.Nilf(t, packets, "Unmarshal %q", test.Name)
+	}
+
+	_, err = UnmarshalPartial(truncated)
+	assert.ErrorIs(t, err, ErrPacketTooShort, "truncation is also a short packet")
+}
+
+func TestUnmarshalWithTime(t *testing.T) {
+	want, err := Unmarshal(realPacket())
+	assert.NoError(t, err)
+
+	recv := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	received, err := UnmarshalWithTime(realPacket(), recv)
+	assert.NoError(t, err)
+	assert.Equal(t, want, received.Packets())
+	assert.True(t, recv.Equal(received.ReceiveTime()))
+
+	marshaled, err := Marshal(received.Packets())
+	assert.NoError(t, err)
+	assert.Equal(t, realPacket(), marshaled, "receive time is not marshaled")
+
+	received, err = UnmarshalWithTime(nil, recv)
+	assert.ErrorIs(t, err, ErrInvalidHeader)
+	assert.Nil(t, received.Packets())
+	assert.True(t, received.ReceiveTime().IsZero())
+}
+
+func TestPacketCount(t *testing.T) {
+	want, err := Unmarshal(realPacket())
+	assert.NoError(t, err)
+
+	for _, test := range []struct {
+		Name      string
+		Data      []byte
+		WantCount int
+		WantError error
+	}{
+		{"real packet", realPacket(), len(want), nil},
+		{"single packet", realPacket()[:32], 1, nil},
+		{"empty", nil, 0, ErrInvalidHeader},
+		{"truncated header", append(realPacket(), 0x81, 0xc9), 0, ErrPacketTooShort},
+		{"length past end", append(realPacket(), 0x81, 0xc9, 0x00, 0x07, 0x90, 0x2f, 0x9e, 0x2e), 0, ErrPacketTooShort},
+		{"bad version", append(realPacket(), 0x41, 0xc9, 0x00, 0x00), 0, ErrBadVersion},
+	} {
+		count, err := PacketCount(test.Data)
+		assert.ErrorIsf(t, err, test.WantError, "PacketCount %q", test.Name)
+		assert.Equalf(t, test.WantCount, count, "PacketCount %q", test.Name)
+	}
+
+	data := realPacket()
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = PacketCount(data)
+	})
+	assert.Zero(t, allocs)
+}
+
+func TestUnmarshalDirect(t *testing.T) {
+	samples := samplePackets()
+	for _, sample := range samples {
+		data, err := sample.Marshal()
+		assert.NoError(t, err)
+
+		want, err := Unmarshal(data)
+		assert.NoError(t, err)
+
+		// Unmarshaling into the packet type skips the dispatch, but gives the
+		// same packet.
+		packet := reflect.New(reflect.TypeOf(sample).Elem()).Interface().(Packet) //nolint:forcetypeassert
+		assert.NoErrorf(t, packet.Unmarshal(data), "Unmarshal %T", sample)
+		assert.Equalf(t, want, []Packet{packet}, "Unmarshal %T", sample)
+
+		for _, other := range samples {
+			_, raw := packet.(*RawPacket)
+			_, afb := packet.(*ApplicationLayerFeedback)
+			_, remb := other.(*ReceiverEstimatedMaximumBitrate)
+			_, generic := packet.(*GenericFeedback)
+			if reflect.TypeOf(other) == reflect.TypeOf(sample) || raw || afb && remb || generic && isFeedbackType(other.Type()) {
+				continue
+			}
+
+			otherData, err := other.Marshal()
+			assert.NoError(t, err)
+			assert.Errorf(t, packet.Unmarshal(otherData), "Unmarshal %T into %T", other, sample)
+		}
+	}
+}
+
+func TestUnmarshalLengthBoundaries(t *testing.T) {
+	// The samples and the smallest packet of each type, where the zero value
+	// can be marshaled.
+	packets := samplePackets()
+	for _, sample := range samplePackets() {
+		zero := reflect.New(reflect.TypeOf(sample).Elem()).Interface().(Packet) //nolint:forcetypeassert
+		data, err := zero.Marshal()
+		if err == nil && reflect.New(reflect.TypeOf(sample).Elem()).Interface().(Packet).Unmarshal(data) == nil { //nolint:forcetypeassert
+			packets = append(packets, zero)
+		}
+	}
+
+	for _, packet := range packets {
+		if _, raw := packet.(*RawPacket); raw {
+			continue
+		}
+		data, err := packet.Marshal()
+		assert.NoError(t, err)
+
+		newPacket := func() Packet {
+			return reflect.New(reflect.TypeOf(packet).Elem()).Interface().(Packet) //nolint:forcetypeassert
+		}
+		want := newPacket()
+		assert.NoErrorf(t, want.Unmarshal(data), "Unmarshal %T of %d bytes", packet, len(data))
+
+		// Bytes after the length are never parsed as part of the packet.
+		got := newPacket()
+		if err := got.Unmarshal(append(append([]byte(nil), data...), 0xff, 0xff, 0xff, 0xff)); err == nil {
+			assert.Equalf(t, want, got, "Unmarshal %T with a trailing word", packet)
+		}
+
+		// A packet one word shorter than its length is rejected, except by
+		// Goodbye and SourceDescription, which parse what the buffer holds.
+		_, bye := packet.(*Goodbye)
+		_, sdes := packet.(*SourceDescription)
+		if !bye && !sdes {
+			assert.Errorf(t, newPacket().Unmarshal(data[:len(data)-4]), "Unmarshal %T missing a word", packet)
+		}
+		_, err = Unmarshal(data[:len(data)-4])
+		assert.Errorf(t, err, "Unmarshal data for %T missing a word", packet)
+	}
+
+	// A length whose size overflows 16 bits is not mistaken for a short one.
+	remb := []byte{
+		0x8f, 0xce, 0x40, 0x04,
+		0x00, 0x00, 0x00, 0x01,
+		0x00, 0x00, 0x00, 0x00,
+		'R', 'E', 'M', 'B',
+		0x00, 0x00, 0x00, 0x00,
+	}
+	assert.ErrorIs(t, new(ReceiverEstimatedMaximumBitrate).Unmarshal(remb), ErrPacketTooShort)
+
+	tcc := []byte{
+		0x8f, 0xcd, 0x40, 0x04,
+		0x00, 0x00, 0x00, 0x01,
+		0x00, 0x00, 0x00, 0x02,
+		0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
+	}
+	assert.ErrorIs(t, new(TransportLayerCC).Unmarshal(tcc), ErrPacketTooShort)
+
+	// The media SSRC of a PLI must be within its length.
+	pli := []byte{
+		0x81, 0xce, 0x00, 0x01,
+		0x00, 0x00, 0x00, 0x01,
+		0x00, 0x00, 0x00, 0x02,
+	}
+	assert.ErrorIs(t, new(PictureLossIndication).Unmarshal(pli), ErrPacketTooShort)
+}
+
+func TestUnmarshalBadVersion(t *testing.T) {
+	for _, version := range []byte{0, 1, 3} {
+		data := realPacket()
+		data[0] = data[0]&^0xc0 | version<<6
+
+		_, err := Unmarshal(data)
+		assert.ErrorIsf(t, err, ErrBadVersion, "version %d", version)
+	}
 }
 
 func TestInvalidHeaderLength(t *testing.T) {
@@ -135,5 +661,612 @@ func TestInvalidHeaderLength(t *testing.T) {
 	}
 
 	_, err := Unmarshal(invalidPacket)
-	assert.ErrorIs(t, err, errPacketTooShort)
+	assert.ErrorIs(t, err, ErrPacketTooShort)
+}
+
+func TestMarshal(t *testing.T) {
+	packets := samplePackets()
+
+	var want []byte
+	for _, packet := range packets {
+		data, err := packet.Marshal()
+		assert.NoError(t, err)
+		want = append(want, data...)
+	}
+
+	// The packets do not form a valid compound packet, which Marshal does
+	// not check.
+	assert.Error(t, CompoundPacket(packets[3:]).Validate())
+	data, err := Marshal(packets[3:])
+	assert.NoError(t, err)
+	assert.Equal(t, want[len(want)-CompoundPacket(packets[3:]).MarshalSize():], data)
+
+	data, err = Marshal(packets)
+	assert.NoError(t, err)
+	assert.Equal(t, want, data)
+
+	decoded, err := Unmarshal(data)
+	assert.NoError(t, err)
+	assert.True(t, PacketsEqual(packets, decoded))
+
+	_, err = Marshal([]Packet{packets[0], &ApplicationDefined{Name: "TOOLONG"}})
+	assert.ErrorIs(t, err, errAppDefinedInvalidName)
+}
+
+func TestPacketType(t *testing.T) {
+	for _, packet := range samplePackets() {
+		data, err := packet.Marshal()
+		assert.NoError(t, err)
+
+		var header Header
+		assert.NoError(t, header.Unmarshal(data))
+		assert.Equalf(t, header.Type, packet.Type(), "%T", packet)
+
+		format, ok := packet.(interface{ Format() uint8 })
+		switch header.Type {
+		case TypeTransportSpecificFeedback, TypePayloadSpecificFeedback:
+			if _, raw := packet.(*RawPacket); raw {
+				break
+			}
+			if assert.Truef(t, ok, "%T has no Format", packet) {
+				assert.Equalf(t, header.Count, format.Format(), "%T", packet)
+			}
+		default:
+			assert.Falsef(t, ok, "%T has a Format", packet)
+		}
+	}
+
+	assert.Equal(t, TypeSenderReport, CompoundPacket(samplePackets()[1:]).Type())
+	assert.Equal(t, PacketType(0), CompoundPacket{}.Type())
+	assert.Equal(t, PacketType(0), RawPacket{}.Type())
+}
+
+func TestValidate(t *testing.T) {
+	for _, packet := range samplePackets() {
+		v, ok := packet.(interface{ Validate() error })
+		if assert.Truef(t, ok, "%T has no Validate", packet) {
+			assert.NoErrorf(t, v.Validate(), "%T", packet)
+		}
+	}
+
+	for _, test := range []struct {
+		Name      string
+		Packet    Packet
+		WantError error
+	}{
+		{"FIR without entries", &FullIntraRequest{SenderSSRC: 1, MediaSSRC: 2}, errEmptyFCI},
+		{"NACK without pairs", &TransportLayerNack{SenderSSRC: 1, MediaSSRC: 2}, errEmptyFCI},
+		{"SLI without entries", &SliceLossIndication{SenderSSRC: 1, MediaSSRC: 2}, errEmptyFCI},
+		{"SLI field too large", &SliceLossIndication{SLI: []SLIEntry{{Picture: 0x40}}}, errFieldOutOfRange},
+		{"TMMBR without entries", &TemporaryMaximumMediaStreamBitrateRequest{SenderSSRC: 1}, errEmptyFCI},
+		{"TMMBR mantissa too large", &TemporaryMaximumMediaStreamBitrateRequest{
+			Entries: []TMMBREntry{{SSRC: 1, Mantissa: mxtbrMaxMantissa + 1}},
+		}, errInvalidMxTBR},
+		{"TMMBN overhead too large", &TemporaryMaximumMediaStreamBitrateNotification{
+			Entries: []TMMBNEntry{{SSRC: 1, Overhead: mxtbrMaxOverhead + 1}},
+		}, errInvalidMxTBR},
+		{"APP name too short", &ApplicationDefined{Name: "ABC"}, errAppDefinedInvalidName},
+		{"APP subtype too large", &ApplicationDefined{Name: "NAME", SubType: 32}, ErrInvalidHeader},
+		{"AFB FCI not a multiple of 4", &ApplicationLayerFeedback{FCI: []byte{1, 2, 3}}, ErrBadLength},
+		{"RR total lost too large", &ReceiverReport{Reports: []ReceptionReport{{TotalLost: 1 << 24}}}, errInvalidTotalLost},
+		{"SR with too many reports", &SenderReport{Reports: make([]ReceptionReport, 32)}, errTooManyReports},
+		{"SR too large", &SenderReport{ProfileExtensions: make([]byte, 4*(1<<16))}, ErrLengthOverflow},
+		{"SDES item without type", &SourceDescription{Chunks: []SourceDescriptionChunk{{
+			Items: []SourceDescriptionItem{{Text: "text"}},
+		}}}, errSDESMissingType},
+		{"BYE reason too long", &Goodbye{Reason: string(make([]byte, 256))}, errReasonTooLong},
+		{"RPSI payload type too large", &ReferencePictureSelectionIndication{PayloadType: 128}, errInvalidPayloadType},
+		{"REMB negative bitrate", &ReceiverEstimatedMaximumBitrate{Bitrate: -1}, errInvalidBitrate},
+		{"TCC missing delta", &TransportLayerCC{
+			PacketStatusCount: 1,
+			PacketChunks: []PacketStatusChunk{&RunLengthChunk{
+				PacketStatusSymbol: TypeTCCPacketReceivedSmallDelta,
+				RunLength:          1,
+			}},
+		}, errTCCDeltaMismatch},
+		{"CCFB ECN too large", &CCFeedbackReport{ReportBlocks: []CCFeedbackReportBlock{{
+			MetricBlocks: []CCFeedbackMetricBlock{{Received: true, ECN: 4}},
+		}}}, errFieldOutOfRange},
+		{"raw packet with trailing bytes", &RawPacket{0x80, 0xc8, 0x00, 0x00, 0x00}, ErrBadLength},
+	} {
+		v, ok := test.Packet.(interface{ Validate() error })
+		if !assert.Truef(t, ok, "%T has no Validate", test.Packet) {
+			continue
+		}
+		assert.ErrorIsf(t, v.Validate(), test.WantError, "Validate %q", test.Name)
+	}
+}
+
+func TestMarshalStrict(t *testing.T) {
+	packets := samplePackets()
+	want, err := Marshal(packets)
+	assert.NoError(t, err)
+	got, err := MarshalStrict(packets)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	// Marshal accepts a FIR without entries, which RFC 5104 forbids.
+	fir := &FullIntraRequest{SenderSSRC: 1, MediaSSRC: 2}
+	_, err = Marshal([]Packet{packets[0], fir})
+	assert.NoError(t, err)
+	_, err = MarshalStrict([]Packet{packets[0], fir})
+	assert.ErrorIs(t, err, errEmptyFCI)
+	assert.ErrorContains(t, err, "*rtcp.FullIntraRequest at index 1")
+}
+
+func TestMarshalAppend(t *testing.T) {
+	prefix := []byte{0xde, 0xad, 0xbe, 0xef}
+
+	for _, packet := range samplePackets() {
+		want, err := packet.Marshal()
+		assert.NoError(t, err)
+
+		appender, ok := packet.(interface {
+			MarshalAppend(dst []byte) ([]byte, error)
+		})
+		if !assert.Truef(t, ok, "%T has no MarshalAppend", packet) {
+			continue
+		}
+
+		got, err := appender.MarshalAppend(cloneSlice(prefix))
+		assert.NoError(t, err)
+		assert.Equalf(t, append(cloneSlice(prefix), want...), got, "%T", packet)
+	}
+
+	packets := samplePackets()
+	want, err := Marshal(packets)
+	assert.NoError(t, err)
+	got, err := MarshalAppend(cloneSlice(prefix), packets)
+	assert.NoError(t, err)
+	assert.Equal(t, append(cloneSlice(prefix), want...), got)
+
+	// a failed marshal leaves dst as it was
+	dst := append(make([]byte, 0, 64), prefix...)
+	got, err = MarshalAppend(dst, []Packet{&ApplicationDefined{Name: "TOOLONG"}})
+	assert.ErrorIs(t, err, errAppDefinedInvalidName)
+	assert.Equal(t, prefix, got)
+
+	// a buffer with room to spare is reused
+	pli := PictureLossIndication{SenderSSRC: 0x902f9e2e, MediaSSRC: 0x902f9e2e}
+	scratch := make([]byte, 0, 1500)
+	allocs := testing.AllocsPerRun(10, func() {
+		scratch, _ = pli.MarshalAppend(scratch[:0])
+	})
+	assert.Zero(t, allocs)
+	assert.Len(t, scratch, pli.MarshalSize())
+}
+
+func TestMarshalTo(t *testing.T) {
+	packets, err := Unmarshal(realPacket())
+	assert.NoError(t, err)
+
+	packets = append(packets,
+		&SenderReport{
+			SSRC:              0x902f9e2e,
+			NTPTime:           0xda8bd1fcdddda05a,
+			Reports:           []ReceptionReport{{SSRC: 0xbc5e9a40, Jitter: 273}},
+			ProfileExtensions: []byte{0x01, 0x02, 0x03},
+		},
+		&FullIntraRequest{
+			MediaSSRC: 0x4bc4fcb4,
+			FIR:       []FIREntry{{SSRC: 0x12345678, SequenceNumber: 0x42}},
+		},
+		&TransportLayerNack{
+			SenderSSRC: 0x902f9e2e,
+			MediaSSRC:  0x4bc4fcb4,
+			Nacks:      []NackPair{{PacketID: 0xaaaa, LostPackets: 0x5555}},
+		},
+		&ExtendedReport{
+			SenderSSRC: 0x902f9e2e,
+			Reports:    []ReportBlock{&VoIPMetricsReportBlock{SSRC: 0x4bc4fcb4, MOSLQ: 4}},
+		},
+	)
+
+	for _, packet := range packets {
+		want, err := packet.Marshal()
+		assert.NoError(t, err)
+		assert.Len(t, want, packet.MarshalSize())
+
+		// fill the buffer with garbage to make sure every byte is written
+		buf := make([]byte, packet.MarshalSize()+8)
+		for i := range buf {
+			buf[i] = 0xff
+		}
+
+		n, err := packet.MarshalTo(buf)
+		assert.NoError(t, err)
+		assert.Equal(t, want, buf[:n])
+
+		_, err = packet.MarshalTo(buf[:packet.MarshalSize()-1])
+		assert.ErrorIs(t, err, ErrPacketTooShort)
+	}
+
+	buf := make([]byte, CompoundPacket(packets).MarshalSize())
+	n, err := marshalPacketsTo(buf, packets)
+	assert.NoError(t, err)
+
+	want, err := Marshal(packets)
+	assert.NoError(t, err)
+	assert.Equal(t, want, buf[:n])
+}
+
+// samplePackets returns one populated packet of every type.
+func samplePackets() []Packet {
+	return []Packet{
+		&ReceiverReport{
+			SSRC:    0x902f9e2e,
+			Reports: []ReceptionReport{{SSRC: 0xbc5e9a40, Jitter: 273}},
+		},
+		&SenderReport{SSRC: 0x902f9e2e, NTPTime: 0xda8bd1fcdddda05a},
+		NewCNAMESourceDescription(0x902f9e2e, "cname"),
+		&Goodbye{Sources: []uint32{0x902f9e2e}, Reason: "bye"},
+		&ApplicationDefined{SSRC: 0x4baae1ab, Name: "NAME", Data: []byte("ABCD")},
+		&PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2},
+		&RapidResynchronizationRequest{SenderSSRC: 1, MediaSSRC: 2},
+		&SliceLossIndication{SenderSSRC: 1, MediaSSRC: 2, SLI: []SLIEntry{{First: 1, Number: 2, Picture: 3}}},
+		&ReferencePictureSelectionIndication{SenderSSRC: 1, MediaSSRC: 2, PayloadType: 96, BitString: []byte{1, 2}},
+		&FullIntraRequest{MediaSSRC: 2, FIR: []FIREntry{{SSRC: 3, SequenceNumber: 4}}},
+		&ReceiverEstimatedMaximumBitrate{SenderSSRC: 1, Bitrate: 8927168, SSRCs: []uint32{2}},
+		&ApplicationLayerFeedback{SenderSSRC: 1, MediaSSRC: 2, FCI: []byte("ABCD1234")},
+		&TransportLayerNack{SenderSSRC: 1, MediaSSRC: 2, Nacks: []NackPair{{PacketID: 5, LostPackets: 6}}},
+		&TemporaryMaximumMediaStreamBitrateRequest{
+			SenderSSRC: 1,
+			Entries:    []TMMBREntry{{SSRC: 2, Exponent: 3, Mantissa: 4, Overhead: 40}},
+		},
+		&TemporaryMaximumMediaStreamBitrateNotification{SenderSSRC: 1},
+		&TransportLayerCC{
+			Header: Header{
+				Count:  FormatTCC,
+				Type:   TypeTransportSpecificFeedback,
+				Length: 5,
+			},
+			SenderSSRC:         1,
+			MediaSSRC:          2,
+			BaseSequenceNumber: 153,
+			PacketStatusCount:  1,
+			ReferenceTime:      4057090,
+			FbPktCount:         23,
+			PacketChunks: []PacketStatusChunk{
+				&RunLengthChunk{
+					Type:               TypeTCCRunLengthChunk,
+					PacketStatusSymbol: TypeTCCPacketReceivedSmallDelta,
+					RunLength:          1,
+				},
+			},
+			RecvDeltas: []*RecvDelta{{Type: TypeTCCPacketReceivedSmallDelta, Delta: 37000}},
+		},
+		&CCFeedbackReport{
+			SenderSSRC: 1,
+			ReportBlocks: []CCFeedbackReportBlock{{
+				MediaSSRC:     2,
+				BeginSequence: 3,
+				MetricBlocks:  []CCFeedbackMetricBlock{{Received: true, ArrivalTimeOffset: 4}},
+			}},
+			ReportTimestamp: 5,
+		},
+		&ECNFeedback{
+			SenderSSRC:                    1,
+			MediaSSRC:                     2,
+			ExtendedHighestSequenceNumber: 3,
+			ECT0:                          4,
+			ECNCE:                         5,
+			LostPackets:                   6,
+		},
+		&GenericFeedback{
+			PacketType:  TypeTransportSpecificFeedback,
+			MessageType: 9,
+			SenderSSRC:  1,
+			MediaSSRC:   2,
+			FCI:         []byte{1, 2, 3, 4},
+		},
+		&ExtendedReport{
+			SenderSSRC: 1,
+			Reports: []ReportBlock{
+				&LossRLEReportBlock{SSRC: 2, BeginSeq: 1, EndSeq: 10, Chunks: []Chunk{0x4009, 0}},
+				&ReceiverReferenceTimeReportBlock{NTPTimestamp: 0x0102030405060708},
+				&VoIPMetricsReportBlock{SSRC: 2, MOSLQ: 40},
+			},
+		},
+		&RawPacket{0x80, 0xd0, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01},
+	}
+}
+
+func TestMarshalSize(t *testing.T) {
+	sample := samplePackets()
+	compound := CompoundPacket{sample[0], sample[2], sample[3]}
+	packets := append(samplePackets(), &compound)
+
+	// Padded variants of every type that supports padding.
+	for _, packet := range samplePackets() {
+		if padded, ok := packet.(interface{ SetPadding(n uint8) error }); ok {
+			assert.NoError(t, padded.SetPadding(8))
+			packets = append(packets, packet)
+		}
+	}
+
+	for _, packet := range packets {
+		data, err := packet.Marshal()
+		assert.NoError(t, err)
+		assert.Lenf(t, data, packet.MarshalSize(), "MarshalSize %T", packet)
+	}
+}
+
+func TestTargetsSSRC(t *testing.T) {
+	type targeter interface {
+		TargetsSSRC(ssrc uint32) bool
+	}
+
+	// Every feedback packet targets exactly its destination SSRCs.
+	for _, packet := range samplePackets() {
+		target, ok := packet.(targeter)
+		if !isFeedbackType(packet.Type()) {
+			assert.Falsef(t, ok, "%T has TargetsSSRC", packet)
+
+			continue
+		}
+		if !assert.Truef(t, ok, "%T has no TargetsSSRC", packet) {
+			continue
+		}
+
+		for ssrc := uint32(0); ssrc < 8; ssrc++ {
+			want := false
+			for _, dest := range packet.DestinationSSRC() {
+				want = want || dest == ssrc
+			}
+			assert.Equalf(t, want, target.TargetsSSRC(ssrc), "%T TargetsSSRC(%d)", packet, ssrc)
+		}
+	}
+
+	// FIR and REMB match any of their entries, but not the media SSRC that
+	// RFC 5104 leaves unused.
+	fir := &FullIntraRequest{MediaSSRC: 9, FIR: []FIREntry{{SSRC: 1}, {SSRC: 2}}}
+	assert.True(t, fir.TargetsSSRC(2))
+	assert.False(t, fir.TargetsSSRC(9))
+	remb := &ReceiverEstimatedMaximumBitrate{SSRCs: []uint32{1, 2, 3}}
+	assert.True(t, remb.TargetsSSRC(3))
+	assert.False(t, remb.TargetsSSRC(4))
+}
+
+func TestSourceSSRC(t *testing.T) {
+	want := [][]uint32{
+		{0x902f9e2e}, // ReceiverReport
+		{0x902f9e2e}, // SenderReport
+		{0x902f9e2e}, // SourceDescription
+		{0x902f9e2e}, // Goodbye
+		{0x4baae1ab}, // ApplicationDefined
+		{1},          // PictureLossIndication
+		{1},          // RapidResynchronizationRequest
+		{1},          // SliceLossIndication
+		{1},          // ReferencePictureSelectionIndication
+		{0},          // FullIntraRequest
+		{1},          // ReceiverEstimatedMaximumBitrate
+		{1},          // ApplicationLayerFeedback
+		{1},          // TransportLayerNack
+		{1},          // TemporaryMaximumMediaStreamBitrateRequest
+		{1},          // TemporaryMaximumMediaStreamBitrateNotification
+		{1},          // TransportLayerCC
+		{1},          // CCFeedbackReport
+		{1},          // ECNFeedback
+		{1},          // GenericFeedback
+		{1},          // ExtendedReport
+		{1},          // RawPacket
+	}
+
+	packets := samplePackets()
+	assert.Len(t, packets, len(want))
+	for i, packet := range packets {
+		assert.Equalf(t, want[i], packet.SourceSSRC(), "SourceSSRC %T", packet)
+	}
+
+	assert.Equal(t, []uint32{}, (&RawPacket{0x80, 0xd0, 0x00, 0x00}).SourceSSRC())
+	assert.Nil(t, CompoundPacket{}.SourceSSRC())
+	assert.Equal(t, []uint32{0x902f9e2e}, CompoundPacket(packets[:3]).SourceSSRC())
+}
+
+func TestPacketsEqual(t *testing.T) {
+	packets := samplePackets()
+	for _, packet := range packets {
+		data, err := packet.Marshal()
+		assert.NoError(t, err)
+
+		decoded, err := Unmarshal(data)
+		assert.NoError(t, err)
+		assert.Truef(t, PacketsEqual([]Packet{packet}, decoded), "round trip %T", packet)
+	}
+
+	assert.True(t, PacketsEqual(packets, packets))
+	assert.True(t, PacketsEqual(nil, []Packet{}))
+	assert.False(t, PacketsEqual(packets, packets[1:]))
+	assert.False(t, PacketsEqual(packets[:1], packets[1:2]))
+
+	t.Run("nil and empty slices", func(t *testing.T) {
+		a := &ReceiverReport{SSRC: 1, ProfileExtensions: []byte{}}
+		b := &ReceiverReport{SSRC: 1, Reports: []ReceptionReport{}}
+		assert.True(t, a.Equal(b))
+	})
+
+	t.Run("different fields", func(t *testing.T) {
+		assert.False(t, (&Goodbye{Reason: "a"}).Equal(&Goodbye{Reason: "b"}))
+		assert.False(t, (&PictureLossIndication{MediaSSRC: 1}).Equal(&PictureLossIndication{MediaSSRC: 2}))
+		assert.False(t, NewCNAMESourceDescription(1, "a").Equal(NewCNAMESourceDescription(1, "b")))
+		assert.False(t, (&ExtendedReport{Reports: []ReportBlock{&ReceiverReferenceTimeReportBlock{}}}).Equal(
+			&ExtendedReport{Reports: []ReportBlock{&UnknownReportBlock{}}}))
+		assert.False(t, (&PictureLossIndication{}).Equal(&RapidResynchronizationRequest{}))
+		assert.False(t, (&PictureLossIndication{}).Equal((*PictureLossIndication)(nil)))
+	})
+}
+
+func TestClonePacket(t *testing.T) {
+	for _, packet := range samplePackets() {
+		data, err := packet.Marshal()
+		assert.NoError(t, err)
+
+		decoded, err := Unmarshal(data)
+		assert.NoError(t, err)
+		assert.Len(t, decoded, 1)
+
+		clone := ClonePacket(decoded[0])
+		assert.Truef(t, PacketsEqual(decoded, []Packet{clone}), "clone %T", packet)
+
+		// the clone must not alias the buffer it was parsed from
+		want := append([]byte{}, data...)
+		for i := range data {
+			data[i] = 0xff
+		}
+		got, err := clone.Marshal()
+		assert.NoError(t, err)
+		assert.Equalf(t, want, got, "clone %T", packet)
+	}
+
+	t.Run("independent slices", func(t *testing.T) {
+		orig := &ReceiverReport{
+			SSRC:              1,
+			Reports:           []ReceptionReport{{SSRC: 2}},
+			ProfileExtensions: []byte{1, 2, 3, 4},
+		}
+		clone, ok := orig.Clone().(*ReceiverReport)
+		assert.True(t, ok)
+		clone.Reports[0].SSRC = 3
+		clone.ProfileExtensions[0] = 9
+		assert.Equal(t, uint32(2), orig.Reports[0].SSRC)
+		assert.Equal(t, byte(1), orig.ProfileExtensions[0])
+
+		sdes := NewCNAMESourceDescription(1, "a")
+		sdesClone, ok := sdes.Clone().(*SourceDescription)
+		assert.True(t, ok)
+		sdesClone.Chunks[0].Items[0].Text = "b"
+		assert.Equal(t, "a", sdes.Chunks[0].Items[0].Text)
+
+		xr := &ExtendedReport{Reports: []ReportBlock{&DLRRReportBlock{Reports: []DLRRReport{{SSRC: 1}}}}}
+		xrClone, ok := xr.Clone().(*ExtendedReport)
+		assert.True(t, ok)
+		xrClone.Reports[0].(*DLRRReportBlock).Reports[0].SSRC = 2                    //nolint:forcetypeassert
+		assert.Equal(t, uint32(1), xr.Reports[0].(*DLRRReportBlock).Reports[0].SSRC) //nolint:forcetypeassert
+
+		compound := CompoundPacket{orig, sdes}
+		compoundClone, ok := compound.Clone().(*CompoundPacket)
+		assert.True(t, ok)
+		assert.True(t, compound.Equal(compoundClone))
+		assert.NotSame(t, compound[0], (*compoundClone)[0])
+	})
+}
+
+type shortWriter struct{}
+
+func (shortWriter) Write(p []byte) (int, error) {
+	return len(p) / 2, nil
+}
+
+func TestPacketsWriteTo(t *testing.T) {
+	packets, err := Unmarshal(realPacket())
+	assert.NoError(t, err)
+
+	var _ io.WriterTo = Packets(packets)
+
+	var buf bytes.Buffer
+	n, err := Packets(packets).WriteTo(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(realPacket())), n)
+	assert.Equal(t, realPacket(), buf.Bytes())
+
+	n, err = Packets(packets).WriteTo(shortWriter{})
+	assert.ErrorIs(t, err, io.ErrShortWrite)
+	assert.Equal(t, int64(len(realPacket())/2), n)
+
+	buf.Reset()
+	n, err = Packets{&Goodbye{Sources: make([]uint32, countMax+1)}}.WriteTo(&buf)
+	assert.Error(t, err)
+	assert.Zero(t, n)
+	assert.Zero(t, buf.Len(), "nothing is written if marshaling fails")
+}
+
+func TestByType(t *testing.T) {
+	pli1 := &PictureLossIndication{MediaSSRC: 1}
+	pli2 := &PictureLossIndication{MediaSSRC: 2}
+	nack := &TransportLayerNack{MediaSSRC: 3}
+	packets := []Packet{&ReceiverReport{}, pli1, nack, pli2}
+
+	assert.Equal(t, []*PictureLossIndication{pli1, pli2}, ByType[*PictureLossIndication](packets))
+	assert.Equal(t, 2, Count[*PictureLossIndication](packets))
+	assert.Equal(t, []*TransportLayerNack{nack}, ByType[*TransportLayerNack](packets))
+	assert.Equal(t, 1, Count[*TransportLayerNack](packets))
+	assert.Nil(t, ByType[*Goodbye](packets))
+	assert.Equal(t, 0, Count[*Goodbye](packets))
+	assert.Nil(t, ByType[*Goodbye](nil))
+
+	// Interface types select every packet implementing them.
+	assert.Len(t, ByType[Packet](packets), 4)
+	assert.Equal(t, 3, Count[interface {
+		Packet
+		Format() uint8
+	}](packets))
+}
+
+func TestRewriteSSRC(t *testing.T) {
+	want := []int{
+		0, // ReceiverReport
+		0, // SenderReport
+		0, // SourceDescription
+		0, // Goodbye
+		0, // ApplicationDefined
+		1, // PictureLossIndication
+		1, // RapidResynchronizationRequest
+		1, // SliceLossIndication
+		1, // ReferencePictureSelectionIndication
+		1, // FullIntraRequest
+		1, // ReceiverEstimatedMaximumBitrate
+		1, // ApplicationLayerFeedback
+		1, // TransportLayerNack
+		1, // TemporaryMaximumMediaStreamBitrateRequest
+		0, // TemporaryMaximumMediaStreamBitrateNotification
+		1, // TransportLayerCC
+		1, // CCFeedbackReport
+		1, // ECNFeedback
+		1, // GenericFeedback
+		2, // ExtendedReport
+		0, // RawPacket
+	}
+
+	packets := samplePackets()
+	assert.Len(t, packets, len(want))
+	for i, packet := range packets {
+		assert.Equalf(t, want[i], RewriteSSRC([]Packet{packet}, 2, 0x99), "RewriteSSRC %T", packet)
+		assert.Zerof(t, RewriteSSRC([]Packet{packet}, 2, 0x99), "RewriteSSRC %T again", packet)
+		assert.Equalf(t, want[i], RewriteSSRC([]Packet{packet}, 0x99, 2), "RewriteSSRC %T back", packet)
+	}
+	assert.True(t, PacketsEqual(samplePackets(), packets), "rewriting back restores the packets")
+
+	t.Run("every field", func(t *testing.T) {
+		compound := CompoundPacket{
+			&SenderReport{SSRC: 1, Reports: []ReceptionReport{{SSRC: 1}, {SSRC: 2}}},
+			NewCNAMESourceDescription(1, "cname"),
+			&Goodbye{Sources: []uint32{2, 1}},
+			&FullIntraRequest{SenderSSRC: 1, MediaSSRC: 1, FIR: []FIREntry{{SSRC: 1}, {SSRC: 2}}},
+			&ReceiverEstimatedMaximumBitrate{SenderSSRC: 1, SSRCs: []uint32{1, 2}},
+			&TemporaryMaximumMediaStreamBitrateNotification{SenderSSRC: 1, MediaSSRC: 1, Entries: []TMMBNEntry{{SSRC: 1}}},
+			&ExtendedReport{SenderSSRC: 1, Reports: []ReportBlock{
+				&DuplicateRLEReportBlock{SSRC: 1},
+				&PacketReceiptTimesReportBlock{SSRC: 1},
+				&DLRRReportBlock{Reports: []DLRRReport{{SSRC: 1}, {SSRC: 2}}},
+				&StatisticsSummaryReportBlock{SSRC: 1},
+				&UnknownReportBlock{Bytes: []byte{0, 0, 0, 1}},
+			}},
+		}
+		assert.Equal(t, 17, RewriteSSRC([]Packet{&compound}, 1, 3))
+		assert.Equal(t, CompoundPacket{
+			&SenderReport{SSRC: 3, Reports: []ReceptionReport{{SSRC: 3}, {SSRC: 2}}},
+			NewCNAMESourceDescription(3, "cname"),
+			&Goodbye{Sources: []uint32{2, 3}},
+			&FullIntraRequest{SenderSSRC: 3, MediaSSRC: 3, FIR: []FIREntry{{SSRC: 3}, {SSRC: 2}}},
+			&ReceiverEstimatedMaximumBitrate{SenderSSRC: 3, SSRCs: []uint32{3, 2}},
+			&TemporaryMaximumMediaStreamBitrateNotification{SenderSSRC: 3, MediaSSRC: 3, Entries: []TMMBNEntry{{SSRC: 3}}},
+			&ExtendedReport{SenderSSRC: 3, Reports: []ReportBlock{
+				&DuplicateRLEReportBlock{SSRC: 3},
+				&PacketReceiptTimesReportBlock{SSRC: 3},
+				&DLRRReportBlock{Reports: []DLRRReport{{SSRC: 3}, {SSRC: 2}}},
+				&StatisticsSummaryReportBlock{SSRC: 3},
+				&UnknownReportBlock{Bytes: []byte{0, 0, 0, 1}},
+			}},
+		}, compound)
+	})
 }
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import "encoding/binary"
+
+// Packets that support trailing padding store the number of padding octets
+// requested with SetPadding. Marshal appends that many octets after the
+// packet body, the last of which holds the count, sets the P bit and counts
+// the padding in the length field. Unmarshal strips the padding before the
+// body is parsed and records its size.
+
+// packetPadding is embedded in packet types to hold their padding size.
+type packetPadding struct {
+	padding uint8
+}
+
+// SetPadding requests n octets of trailing padding when the packet is
+// marshaled. n must be a multiple of 4 so the packet stays 32-bit aligned;
+// zero removes the padding.
+func (p *packetPadding) SetPadding(n uint8) error {
+	if err := checkPadding(n); err != nil {
+		return err
+	}
+	p.padding = n
+
+	return nil
+}
+
+// Padding returns the number of trailing padding octets the packet is
+// marshaled with, or was unmarshaled with.
+func (p *packetPadding) Padding() uint8 {
+	return p.padding
+}
+
+// checkPadding validates a padding size passed to SetPadding. Padded packets
+// must remain a multiple of 32 bits, so the size must be a multiple of 4.
+func checkPadding(padding uint8) error {
+	if padding%4 != 0 {
+		return errInvalidPadding
+	}
+
+	return nil
+}
+
+// writePadding fills the trailing padding of pkt, which holds a complete
+// marshaled packet, and updates its P bit and length field.
+func writePadding(pkt []byte, padding uint8) {
+	if padding == 0 {
+		return
+	}
+
+	pad := pkt[len(pkt)-int(padding):]
+	for i := range pad {
+		pad[i] = 0
+	}
+	pad[len(pad)-1] = padding
+
+	pkt[0] |= 1 << paddingShift
+	binary.BigEndian.PutUint16(pkt[2:], uint16(len(pkt)/4-1)) //nolint:gosec // G115
+}
+
+// removePadding returns rawPacket without its trailing padding, along with
+// the padding size. A padded packet is copied so that the P bit and length
+// field describe the unpadded body; unpadded packets are returned as is.
+func removePadding(rawPacket []byte) ([]byte, uint8, error) {
+	if len(rawPacket) < headerLength || rawPacket[0]>>paddingShift&paddingMask == 0 {
+		return rawPacket, 0, nil
+	}
+
+	size := (int(binary.BigEndian.Uint16(rawPacket[2:])) + 1) * 4
+	if len(rawPacket) < size {
+		return nil, 0, ErrPacketTooShort
+	}
+
+	padding := rawPacket[size-1]
+	if padding == 0 || padding%4 != 0 || int(padding) > size-headerLength {
+		return nil, 0, ErrWrongPadding
+	}
+
+	out := make([]byte, size-int(padding))
+	copy(out, rawPacket)
+	out[0] &^= 1 << paddingShift
+	binary.BigEndian.PutUint16(out[2:], uint16(len(out)/4-1)) //nolint:gosec // G115
+
+	return out, padding, nil
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaddingRoundTrip(t *testing.T) {
+	for _, packet := range samplePackets() {
+		padded, ok := packet.(interface {
+			SetPadding(n uint8) error
+			Padding() uint8
+		})
+		if !ok {
+			continue
+		}
+
+		unpadded, err := packet.Marshal()
+		assert.NoError(t, err)
+
+		assert.NoError(t, padded.SetPadding(8))
+		data, err := packet.Marshal()
+		assert.NoError(t, err)
+		assert.NoError(t, padded.SetPadding(0))
+
+		assert.Lenf(t, data, len(unpadded)+8, "Marshal %T", packet)
+		assert.Equalf(t, unpadded[headerLength:], data[headerLength:len(unpadded)], "Marshal %T", packet)
+		assert.Equalf(t, []byte{0, 0, 0, 0, 0, 0, 0, 8}, data[len(unpadded):], "Marshal %T", packet)
+		assert.NotZerof(t, data[0]&(1<<paddingShift), "padding bit %T", packet)
+		assert.Equalf(t, uint16(len(data)/4-1), binary.BigEndian.Uint16(data[2:]), "length %T", packet)
+
+		decoded, err := Unmarshal(data)
+		assert.NoErrorf(t, err, "Unmarshal %T", packet)
+		assert.Truef(t, PacketsEqual([]Packet{packet}, decoded), "round trip %T", packet)
+		assert.Equalf(t, uint8(8), decoded[0].(interface{ Padding() uint8 }).Padding(), "Padding %T", packet) //nolint:forcetypeassert
+
+		// re-marshaling keeps the padding that was read
+		again, err := decoded[0].Marshal()
+		assert.NoError(t, err)
+		assert.Equalf(t, data, again, "re-marshal %T", packet)
+	}
+}
+
+func TestSetPadding(t *testing.T) {
+	pli := &PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2}
+	assert.ErrorIs(t, pli.SetPadding(3), errInvalidPadding)
+	assert.Equal(t, uint8(0), pli.Padding())
+
+	assert.NoError(t, pli.SetPadding(4))
+	assert.True(t, pli.Header().Padding)
+	assert.Equal(t, uint16(3), pli.Header().Length)
+	assert.Equal(t, 16, pli.MarshalSize())
+
+	// a padded packet may only end a compound packet
+	assert.ErrorIs(t, CompoundPacket{
+		&ReceiverReport{},
+		NewCNAMESourceDescription(1, "cname"),
+		pli,
+		&Goodbye{},
+	}.Validate(), ErrPaddingNotLast)
+}
+
+func TestUnmarshalPadding(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Data      []byte
+		WantError error
+	}{
+		{
+			Name: "zero padding count",
+			Data: []byte{
+				// v=2, p=1, FMT=1, PSFB, len=3
+				0xa1, 0xce, 0x00, 0x03,
+				0x00, 0x00, 0x00, 0x01,
+				0x00, 0x00, 0x00, 0x02,
+				0x00, 0x00, 0x00, 0x00,
+			},
+			WantError: ErrWrongPadding,
+		},
+		{
+			Name: "unaligned padding count",
+			Data: []byte{
+				0xa1, 0xce, 0x00, 0x03,
+				0x00, 0x00, 0x00, 0x01,
+				0x00, 0x00, 0x00, 0x02,
+				0x00, 0x00, 0x00, 0x03,
+			},
+			WantError: ErrWrongPadding,
+		},
+		{
+			Name: "padding longer than packet",
+			Data: []byte{
+				0xa1, 0xce, 0x00, 0x03,
+				0x00, 0x00, 0x00, 0x01,
+				0x00, 0x00, 0x00, 0x02,
+				0x00, 0x00, 0x00, 0x14,
+			},
+			WantError: ErrWrongPadding,
+		},
+		{
+			Name: "padding strips the body",
+			Data: []byte{
+				0xa1, 0xce, 0x00, 0x03,
+				0x00, 0x00, 0x00, 0x01,
+				0x00, 0x00, 0x00, 0x02,
+				0x00, 0x00, 0x00, 0x08,
+			},
+			WantError: ErrPacketTooShort,
+		},
+	} {
+		var pli PictureLossIndication
+		assert.ErrorIsf(t, pli.Unmarshal(test.Data), test.WantError, "Unmarshal %q", test.Name)
+	}
+}
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import "sync"
+
+// A Parser unmarshals packets like Unmarshal, but takes the most common packet
+// types (SenderReport, ReceiverReport, SourceDescription and Goodbye) from
+// internal pools instead of allocating them for every datagram. Other packet
+// types are allocated as usual.
+//
+// Packets returned by a Parser are only valid until they are passed to
+// Release; after that they may be overwritten by a later call to Unmarshal.
+// Callers that need to keep a packet should copy it with ClonePacket first.
+//
+// The zero value is ready to use, and a Parser is safe for concurrent use.
+type Parser struct {
+	senderReports      sync.Pool
+	receiverReports    sync.Pool
+	sourceDescriptions sync.Pool
+	goodbyes           sync.Pool
+}
+
+// NewParser returns a Parser with empty pools.
+func NewParser() *Parser {
+	return &Parser{}
+}
+
+// Unmarshal takes an entire udp datagram and returns the packets it contains,
+// as the package level Unmarshal does. The packets should be passed to
+// Release once the caller is done with them.
+func (p *Parser) Unmarshal(rawData []byte) ([]Packet, error) {
+	return unmarshalPackets(rawData, unmarshalConfig{newPacket: p.newPacket})
+}
+
+// Release returns pkt to the Parser's pools. pkt must not be used after it is
+// released. Packets of types that are not pooled are left to the garbage
+// collector.
+func (p *Parser) Release(pkt Packet) {
+	switch pkt := pkt.(type) {
+	case *SenderReport:
+		*pkt = SenderReport{}
+		p.senderReports.Put(pkt)
+	case *ReceiverReport:
+		*pkt = ReceiverReport{}
+		p.receiverReports.Put(pkt)
+	case *SourceDescription:
+		*pkt = SourceDescription{}
+		p.sourceDescriptions.Put(pkt)
+	case *Goodbye:
+		*pkt = Goodbye{}
+		p.goodbyes.Put(pkt)
+	case *CompoundPacket:
+		p.ReleaseAll(*pkt)
+	}
+}
+
+// ReleaseAll releases every packet in packets, as returned by Unmarshal.
+func (p *Parser) ReleaseAll(packets []Packet) {
+	for _, pkt := range packets {
+		p.Release(pkt)
+	}
+}
+
+// newPacket takes the pooled packet types from their pools and allocates the
+// rest.
+func (p *Parser) newPacket(header Header, inPacket []byte) Packet {
+	switch header.Type {
+	case TypeSenderReport:
+		if pkt, ok := p.senderReports.Get().(*SenderReport); ok {
+			return pkt
+		}
+
+		return new(SenderReport)
+	case TypeReceiverReport:
+		if pkt, ok := p.receiverReports.Get().(*ReceiverReport); ok {
+			return pkt
+		}
+
+		return new(ReceiverReport)
+	case TypeSourceDescription:
+		if pkt, ok := p.sourceDescriptions.Get().(*SourceDescription); ok {
+			return pkt
+		}
+
+		return new(SourceDescription)
+	case TypeGoodbye:
+		if pkt, ok := p.goodbyes.Get().(*Goodbye); ok {
+			return pkt
+		}
+
+		return new(Goodbye)
+	default:
+		return newPacket(header, inPacket)
+	}
+}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParser(t *testing.T) {
+	parser := NewParser()
+
+	want, err := Unmarshal(realPacket())
+	assert.NoError(t, err)
+
+	// Parse repeatedly so that later rounds reuse released packets.
+	for i := 0; i < 3; i++ {
+		packets, err := parser.Unmarshal(realPacket())
+		assert.NoError(t, err)
+		assert.True(t, PacketsEqual(want, packets), "round %d", i)
+		parser.ReleaseAll(packets)
+	}
+
+	_, err = parser.Unmarshal(nil)
+	assert.ErrorIs(t, err, ErrInvalidHeader)
+
+	_, err = parser.Unmarshal(realPacket()[:20])
+	assert.ErrorIs(t, err, ErrPacketTooShort)
+}
+
+func TestParserRelease(t *testing.T) {
+	parser := NewParser()
+
+	packets, err := parser.Unmarshal([]byte{
+		// Goodbye with a reason
+		0x81, 0xcb, 0x00, 0x02,
+		0x90, 0x2f, 0x9e, 0x2e,
+		0x03, 0x46, 0x4f, 0x4f,
+	})
+	assert.NoError(t, err)
+	bye, ok := packets[0].(*Goodbye)
+	assert.True(t, ok)
+	parser.Release(bye)
+	assert.Equal(t, Goodbye{}, *bye, "released packets are reset")
+
+	// A packet that no longer has a reason must not keep the old one.
+	packets, err = parser.Unmarshal([]byte{
+		0x81, 0xcb, 0x00, 0x01,
+		0x90, 0x2f, 0x9e, 0x2e,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []Packet{&Goodbye{Sources: []uint32{0x902f9e2e}}}, packets)
+
+	// Releasing packet types that are not pooled is a no-op.
+	parser.Release(&PictureLossIndication{})
+	parser.Release(&CompoundPacket{&ReceiverReport{}, &PictureLossIndication{}})
+}
+
+func BenchmarkParser(b *testing.B) {
+	parser := NewParser()
+	data := realPacket()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		packets, err := parser.Unmarshal(data)
+		if err != nil {
+			b.Fatal(err)
+		}
+		parser.ReleaseAll(packets)
+	}
+}
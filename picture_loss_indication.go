@@ -16,44 +16,79 @@ type PictureLossIndication struct {
 
 	// SSRC where the loss was experienced
 	MediaSSRC uint32
+
+	packetPadding
 }
 
 const (
 	pliLength = 2
 )
 
+// NewPictureLossIndication creates a PictureLossIndication sent by sender
+// about the stream media.
+func NewPictureLossIndication(sender, media uint32) *PictureLossIndication {
+	return &PictureLossIndication{
+		SenderSSRC: sender,
+		MediaSSRC:  media,
+	}
+}
+
 // Marshal encodes the PictureLossIndication in binary.
 func (p PictureLossIndication) Marshal() ([]byte, error) {
+	rawPacket := make([]byte, p.MarshalSize())
+	if _, err := p.MarshalTo(rawPacket); err != nil {
+		return nil, err
+	}
+
+	return rawPacket, nil
+}
+
+// MarshalAppend appends the packet in binary to dst and returns the
+// extended slice.
+func (p PictureLossIndication) MarshalAppend(dst []byte) ([]byte, error) {
+	return marshalAppend(dst, p.MarshalSize(), p.MarshalTo)
+}
+
+// MarshalTo encodes the PictureLossIndication in binary into buf and returns the number of bytes written.
+func (p PictureLossIndication) MarshalTo(buf []byte) (int, error) {
 	/*
 	 * PLI does not require parameters.  Therefore, the length field MUST be
 	 * 2, and there MUST NOT be any Feedback Control Information.
 	 *
 	 * The semantics of this FB message is independent of the payload type.
 	 */
-	rawPacket := make([]byte, p.MarshalSize())
-	packetBody := rawPacket[headerLength:]
+	size := p.MarshalSize()
+	if len(buf) < size {
+		return 0, ErrPacketTooShort
+	}
+
+	header := p.Header()
+	if err := header.SetLengthFromBytes(size); err != nil {
+		return 0, err
+	}
+	if _, err := header.MarshalTo(buf); err != nil {
+		return 0, err
+	}
+
+	packetBody := buf[headerLength:]
 
 	binary.BigEndian.PutUint32(packetBody, p.SenderSSRC)
 	binary.BigEndian.PutUint32(packetBody[4:], p.MediaSSRC)
 
-	h := Header{
-		Count:  FormatPLI,
-		Type:   TypePayloadSpecificFeedback,
-		Length: pliLength,
-	}
-	hData, err := h.Marshal()
-	if err != nil {
-		return nil, err
-	}
-	copy(rawPacket, hData)
+	writePadding(buf[:size], p.padding)
 
-	return rawPacket, nil
+	return size, nil
 }
 
 // Unmarshal decodes the PictureLossIndication from binary.
 func (p *PictureLossIndication) Unmarshal(rawPacket []byte) error {
+	rawPacket, padding, err := removePadding(rawPacket)
+	if err != nil {
+		return err
+	}
+
 	if len(rawPacket) < (headerLength + (ssrcLength * 2)) {
-		return errPacketTooShort
+		return ErrPacketTooShort
 	}
 
 	var h Header
@@ -61,35 +96,103 @@ func (p *PictureLossIndication) Unmarshal(rawPacket []byte) error {
 		return err
 	}
 
+	// The media SSRC must be within the length, not just the buffer.
+	end := (int(h.Length) + 1) * 4
+	if end < headerLength+(ssrcLength*2) || len(rawPacket) < end {
+		return ErrPacketTooShort
+	}
+
 	if h.Type != TypePayloadSpecificFeedback || h.Count != FormatPLI {
-		return errWrongType
+		return ErrWrongType
 	}
 
 	p.SenderSSRC = binary.BigEndian.Uint32(rawPacket[headerLength:])
 	p.MediaSSRC = binary.BigEndian.Uint32(rawPacket[headerLength+ssrcLength:])
 
+	p.padding = padding
+
 	return nil
 }
 
 // Header returns the Header associated with this packet.
 func (p *PictureLossIndication) Header() Header {
-	return Header{
-		Count:  FormatPLI,
-		Type:   TypePayloadSpecificFeedback,
-		Length: pliLength,
+	h := Header{
+		Padding: p.padding != 0,
+		Count:   FormatPLI,
+		Type:    TypePayloadSpecificFeedback,
 	}
+	_ = h.SetLengthFromBytes(p.MarshalSize())
+
+	return h
 }
 
 // MarshalSize returns the size of the packet once marshaled.
 func (p *PictureLossIndication) MarshalSize() int {
-	return headerLength + ssrcLength*2
+	return headerLength + ssrcLength*2 + int(p.padding)
 }
 
 func (p *PictureLossIndication) String() string {
 	return fmt.Sprintf("PictureLossIndication %x %x", p.SenderSSRC, p.MediaSSRC)
 }
 
+// Validate returns nil, as a PictureLossIndication has no fields that can be
+// invalid.
+func (p *PictureLossIndication) Validate() error {
+	return nil
+}
+
+// Type returns the packet type, TypePayloadSpecificFeedback.
+func (p *PictureLossIndication) Type() PacketType {
+	return TypePayloadSpecificFeedback
+}
+
+// Format returns the feedback message type, FormatPLI.
+func (p *PictureLossIndication) Format() uint8 {
+	return FormatPLI
+}
+
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
 func (p *PictureLossIndication) DestinationSSRC() []uint32 {
 	return []uint32{p.MediaSSRC}
 }
+
+// TargetsSSRC reports whether the packet is feedback about the media source
+// ssrc, its MediaSSRC.
+func (p *PictureLossIndication) TargetsSSRC(ssrc uint32) bool {
+	return p.MediaSSRC == ssrc
+}
+
+// SourceSSRC returns the SSRC of the sender.
+func (p *PictureLossIndication) SourceSSRC() []uint32 {
+	return []uint32{p.SenderSSRC}
+}
+
+// RewriteSSRC replaces every SSRC in the packet that equals from with to,
+// and returns the number of SSRCs replaced.
+func (p *PictureLossIndication) RewriteSSRC(from, to uint32) int {
+	return rewriteSSRC(&p.SenderSSRC, from, to) + rewriteSSRC(&p.MediaSSRC, from, to)
+}
+
+// Equal reports whether other is a PictureLossIndication with the same contents.
+func (p *PictureLossIndication) Equal(other Packet) bool {
+	o, ok := other.(*PictureLossIndication)
+
+	return ok && o != nil && p.SenderSSRC == o.SenderSSRC && p.MediaSSRC == o.MediaSSRC
+}
+
+// Clone returns a copy of the packet.
+func (p *PictureLossIndication) Clone() Packet {
+	c := *p
+
+	return &c
+}
+
+// MarshalJSON encodes the packet as a JSON object tagged with its type.
+func (p PictureLossIndication) MarshalJSON() ([]byte, error) {
+	return marshalJSON(p)
+}
+
+// UnmarshalJSON decodes a JSON object produced by MarshalJSON.
+func (p *PictureLossIndication) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, p)
+}
@@ -38,7 +38,7 @@ func TestPictureLossIndicationUnmarshal(t *testing.T) {
 			Data: []byte{
 				0x00, 0x00, 0x00, 0x00,
 			},
-			WantError: errPacketTooShort,
+			WantError: ErrPacketTooShort,
 		},
 		{
 			Name: "invalid header",
@@ -47,7 +47,7 @@ func TestPictureLossIndicationUnmarshal(t *testing.T) {
 				0x00, 0x00, 0x00, 0x00,
 				0x00, 0x00, 0x00, 0x00,
 			},
-			WantError: errBadVersion,
+			WantError: ErrBadVersion,
 		},
 		{
 			Name: "wrong type",
@@ -59,7 +59,7 @@ func TestPictureLossIndicationUnmarshal(t *testing.T) {
 				// ssrc=0x4bc4fcb4
 				0x4b, 0xc4, 0xfc, 0xb4,
 			},
-			WantError: errWrongType,
+			WantError: ErrWrongType,
 		},
 		{
 			Name: "wrong fmt",
@@ -71,7 +71,7 @@ func TestPictureLossIndicationUnmarshal(t *testing.T) {
 				// ssrc=0x4bc4fcb4
 				0x4b, 0xc4, 0xfc, 0xb4,
 			},
-			WantError: errWrongType,
+			WantError: ErrWrongType,
 		},
 	} {
 		var pli PictureLossIndication
@@ -152,3 +152,10 @@ func TestPictureLossIndicationUnmarshalHeader(t *testing.T) {
 		assert.Equalf(t, test.Want, pli.Header(), "Unmarshal header %q", test.Name)
 	}
 }
+
+func TestNewPictureLossIndication(t *testing.T) {
+	assert.Equal(t, &PictureLossIndication{
+		SenderSSRC: 0x902f9e2e,
+		MediaSSRC:  0x4bc4fcb4,
+	}, NewPictureLossIndication(0x902f9e2e, 0x4bc4fcb4))
+}
@@ -16,6 +16,8 @@ type RapidResynchronizationRequest struct {
 
 	// SSRC of the media source
 	MediaSSRC uint32
+
+	packetPadding
 }
 
 // RapidResynchronisationRequest is provided as RFC 6051 spells resynchronization with an s.
@@ -23,38 +25,66 @@ type RapidResynchronizationRequest struct {
 type RapidResynchronisationRequest = RapidResynchronizationRequest
 
 const (
-	rrrLength       = 2
 	rrrHeaderLength = ssrcLength * 2
 	rrrMediaOffset  = 4
 )
 
 // Marshal encodes the RapidResynchronizationRequest in binary.
 func (p RapidResynchronizationRequest) Marshal() ([]byte, error) {
+	rawPacket := make([]byte, p.MarshalSize())
+	if _, err := p.MarshalTo(rawPacket); err != nil {
+		return nil, err
+	}
+
+	return rawPacket, nil
+}
+
+// MarshalAppend appends the packet in binary to dst and returns the
+// extended slice.
+func (p RapidResynchronizationRequest) MarshalAppend(dst []byte) ([]byte, error) {
+	return marshalAppend(dst, p.MarshalSize(), p.MarshalTo)
+}
+
+// MarshalTo encodes the RapidResynchronizationRequest in binary into buf and returns the number of bytes written.
+func (p RapidResynchronizationRequest) MarshalTo(buf []byte) (int, error) {
 	/*
 	 * RRR does not require parameters.  Therefore, the length field MUST be
 	 * 2, and there MUST NOT be any Feedback Control Information.
 	 *
 	 * The semantics of this FB message is independent of the payload type.
 	 */
-	rawPacket := make([]byte, p.MarshalSize())
-	packetBody := rawPacket[headerLength:]
+	size := p.MarshalSize()
+	if len(buf) < size {
+		return 0, ErrPacketTooShort
+	}
+
+	header := p.Header()
+	if err := header.SetLengthFromBytes(size); err != nil {
+		return 0, err
+	}
+	if _, err := header.MarshalTo(buf); err != nil {
+		return 0, err
+	}
+
+	packetBody := buf[headerLength:]
 
 	binary.BigEndian.PutUint32(packetBody, p.SenderSSRC)
 	binary.BigEndian.PutUint32(packetBody[rrrMediaOffset:], p.MediaSSRC)
 
-	hData, err := p.Header().Marshal()
-	if err != nil {
-		return nil, err
-	}
-	copy(rawPacket, hData)
+	writePadding(buf[:size], p.padding)
 
-	return rawPacket, nil
+	return size, nil
 }
 
 // Unmarshal decodes the RapidResynchronizationRequest from binary.
 func (p *RapidResynchronizationRequest) Unmarshal(rawPacket []byte) error {
+	rawPacket, padding, err := removePadding(rawPacket)
+	if err != nil {
+		return err
+	}
+
 	if len(rawPacket) < (headerLength + (ssrcLength * 2)) {
-		return errPacketTooShort
+		return ErrPacketTooShort
 	}
 
 	var h Header
@@ -62,28 +92,55 @@ func (p *RapidResynchronizationRequest) Unmarshal(rawPacket []byte) error {
 		return err
 	}
 
+	// The media SSRC must be within the length, not just the buffer.
+	end := (int(h.Length) + 1) * 4
+	if end < headerLength+(ssrcLength*2) || len(rawPacket) < end {
+		return ErrPacketTooShort
+	}
+
 	if h.Type != TypeTransportSpecificFeedback || h.Count != FormatRRR {
-		return errWrongType
+		return ErrWrongType
 	}
 
 	p.SenderSSRC = binary.BigEndian.Uint32(rawPacket[headerLength:])
 	p.MediaSSRC = binary.BigEndian.Uint32(rawPacket[headerLength+ssrcLength:])
 
+	p.padding = padding
+
 	return nil
 }
 
 // MarshalSize returns the size of the packet once marshaled.
 func (p *RapidResynchronizationRequest) MarshalSize() int {
-	return headerLength + rrrHeaderLength
+	return headerLength + rrrHeaderLength + int(p.padding)
 }
 
 // Header returns the Header associated with this packet.
 func (p *RapidResynchronizationRequest) Header() Header {
-	return Header{
-		Count:  FormatRRR,
-		Type:   TypeTransportSpecificFeedback,
-		Length: rrrLength,
+	h := Header{
+		Padding: p.padding != 0,
+		Count:   FormatRRR,
+		Type:    TypeTransportSpecificFeedback,
 	}
+	_ = h.SetLengthFromBytes(p.MarshalSize())
+
+	return h
+}
+
+// Validate returns nil, as a RapidResynchronizationRequest has no fields that
+// can be invalid.
+func (p *RapidResynchronizationRequest) Validate() error {
+	return nil
+}
+
+// Type returns the packet type, TypeTransportSpecificFeedback.
+func (p *RapidResynchronizationRequest) Type() PacketType {
+	return TypeTransportSpecificFeedback
+}
+
+// Format returns the feedback message type, FormatRRR.
+func (p *RapidResynchronizationRequest) Format() uint8 {
+	return FormatRRR
 }
 
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
@@ -91,6 +148,47 @@ func (p *RapidResynchronizationRequest) DestinationSSRC() []uint32 {
 	return []uint32{p.MediaSSRC}
 }
 
+// TargetsSSRC reports whether the packet is feedback about the media source
+// ssrc, its MediaSSRC.
+func (p *RapidResynchronizationRequest) TargetsSSRC(ssrc uint32) bool {
+	return p.MediaSSRC == ssrc
+}
+
+// SourceSSRC returns the SSRC of the sender.
+func (p *RapidResynchronizationRequest) SourceSSRC() []uint32 {
+	return []uint32{p.SenderSSRC}
+}
+
+// RewriteSSRC replaces every SSRC in the packet that equals from with to,
+// and returns the number of SSRCs replaced.
+func (p *RapidResynchronizationRequest) RewriteSSRC(from, to uint32) int {
+	return rewriteSSRC(&p.SenderSSRC, from, to) + rewriteSSRC(&p.MediaSSRC, from, to)
+}
+
+// Equal reports whether other is a RapidResynchronizationRequest with the same contents.
+func (p *RapidResynchronizationRequest) Equal(other Packet) bool {
+	o, ok := other.(*RapidResynchronizationRequest)
+
+	return ok && o != nil && p.SenderSSRC == o.SenderSSRC && p.MediaSSRC == o.MediaSSRC
+}
+
+// Clone returns a copy of the packet.
+func (p *RapidResynchronizationRequest) Clone() Packet {
+	c := *p
+
+	return &c
+}
+
+// MarshalJSON encodes the packet as a JSON object tagged with its type.
+func (p RapidResynchronizationRequest) MarshalJSON() ([]byte, error) {
+	return marshalJSON(p)
+}
+
+// UnmarshalJSON decodes a JSON object produced by MarshalJSON.
+func (p *RapidResynchronizationRequest) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, p)
+}
+
 func (p *RapidResynchronizationRequest) String() string {
 	return fmt.Sprintf("RapidResynchronizationRequest %x %x", p.SenderSSRC, p.MediaSSRC)
 }
@@ -41,7 +41,7 @@ func TestRapidResynchronizationRequestUnmarshal(t *testing.T) {
 				0x90, 0x2f, 0x9e, 0x2e,
 				// report ends early
 			},
-			WantError: errPacketTooShort,
+			WantError: ErrPacketTooShort,
 		},
 		{
 			Name: "wrong type",
@@ -63,12 +63,12 @@ func TestRapidResynchronizationRequestUnmarshal(t *testing.T) {
 				// delay=150137
 				0x0, 0x2, 0x4a, 0x79,
 			},
-			WantError: errWrongType,
+			WantError: ErrWrongType,
 		},
 		{
 			Name:      "nil",
 			Data:      nil,
-			WantError: errPacketTooShort,
+			WantError: ErrPacketTooShort,
 		},
 	} {
 		var rrr RapidResynchronizationRequest
@@ -3,7 +3,13 @@
 
 package rtcp
 
-import "fmt"
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
 
 // RawPacket represents an unparsed RTCP packet. It's returned by Unmarshal when
 // a packet with an unknown type is encountered.
@@ -14,18 +20,45 @@ func (r RawPacket) Marshal() ([]byte, error) {
 	return r, nil
 }
 
-// Unmarshal decodes the packet from binary.
+// MarshalAppend appends the packet in binary to dst and returns the
+// extended slice.
+func (r RawPacket) MarshalAppend(dst []byte) ([]byte, error) {
+	return marshalAppend(dst, r.MarshalSize(), r.MarshalTo)
+}
+
+// MarshalTo copies the packet into buf and returns the number of bytes written.
+func (r RawPacket) MarshalTo(buf []byte) (int, error) {
+	if len(buf) < len(r) {
+		return 0, ErrPacketTooShort
+	}
+
+	return copy(buf, r), nil
+}
+
+// Unmarshal decodes the packet from binary. The bytes are copied so the
+// caller may reuse b once Unmarshal returns.
 func (r *RawPacket) Unmarshal(b []byte) error {
 	if len(b) < (headerLength) {
-		return errPacketTooShort
+		return ErrPacketTooShort
 	}
-	*r = b
+	*r = append(RawPacket(nil), b...)
 
 	var h Header
 
 	return h.Unmarshal(b)
 }
 
+// unmarshalNoCopy is like Unmarshal, but makes r refer to b instead of a copy.
+func (r *RawPacket) unmarshalNoCopy(b []byte) error {
+	var h Header
+	if err := h.Unmarshal(b); err != nil {
+		return err
+	}
+	*r = b
+
+	return nil
+}
+
 // Header returns the Header associated with this packet.
 func (r RawPacket) Header() Header {
 	var h Header
@@ -36,11 +69,86 @@ func (r RawPacket) Header() Header {
 	return h
 }
 
+// Validate checks that the RawPacket holds a single packet: that it starts
+// with a valid header whose length covers exactly all of its bytes.
+func (r RawPacket) Validate() error {
+	var h Header
+	if err := h.Unmarshal(r); err != nil {
+		return err
+	}
+	if (int(h.Length)+1)*4 != len(r) {
+		return ErrBadLength
+	}
+
+	return nil
+}
+
+// Type returns the packet type read from the packet's header, or 0 if it has
+// no valid header.
+func (r RawPacket) Type() PacketType {
+	return r.Header().Type
+}
+
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
 func (r *RawPacket) DestinationSSRC() []uint32 {
 	return []uint32{}
 }
 
+// SourceSSRC returns the SSRC following the header, which every RTCP packet
+// type defined so far uses for its sender. It is empty if the packet is too
+// short to hold one.
+func (r *RawPacket) SourceSSRC() []uint32 {
+	if len(*r) < headerLength+ssrcLength {
+		return []uint32{}
+	}
+
+	return []uint32{binary.BigEndian.Uint32((*r)[headerLength:])}
+}
+
+// RewriteSSRC does nothing, since the fields of a RawPacket are not known,
+// and returns 0.
+func (r *RawPacket) RewriteSSRC(_, _ uint32) int {
+	return 0
+}
+
+// Equal reports whether other is a RawPacket with the same bytes.
+func (r *RawPacket) Equal(other Packet) bool {
+	o, ok := other.(*RawPacket)
+
+	return ok && o != nil && bytes.Equal(*r, *o)
+}
+
+// Clone returns a copy of the packet that shares no memory with r.
+func (r *RawPacket) Clone() Packet {
+	c := RawPacket(cloneSlice(*r))
+
+	return &c
+}
+
+type rawPacketJSON struct {
+	Type string `json:"type"`
+	Data []byte `json:"Data"`
+}
+
+// MarshalJSON encodes the packet as a JSON object tagged with its type.
+func (r RawPacket) MarshalJSON() ([]byte, error) {
+	return json.Marshal(rawPacketJSON{Type: jsonTypeNames[reflect.TypeOf(r)], Data: r})
+}
+
+// UnmarshalJSON decodes a JSON object produced by MarshalJSON.
+func (r *RawPacket) UnmarshalJSON(data []byte) error {
+	var v rawPacketJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if want := jsonTypeNames[reflect.TypeOf(*r)]; v.Type != want {
+		return fmt.Errorf("%w: expected(%s) actual(%s)", errWrongJSONType, want, v.Type)
+	}
+	*r = v.Data
+
+	return nil
+}
+
 func (r RawPacket) String() string {
 	out := fmt.Sprintf("RawPacket: %v", ([]byte)(r))
 
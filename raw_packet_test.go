@@ -32,7 +32,7 @@ func TestRawPacketRoundTrip(t *testing.T) {
 		{
 			Name:               "short header",
 			Packet:             RawPacket([]byte{0x00}),
-			WantUnmarshalError: errPacketTooShort,
+			WantUnmarshalError: ErrPacketTooShort,
 		},
 		{
 			Name: "invalid header",
@@ -40,7 +40,7 @@ func TestRawPacketRoundTrip(t *testing.T) {
 				// v=0, p=0, count=0, RR, len=4
 				0x00, 0xc9, 0x00, 0x04,
 			}),
-			WantUnmarshalError: errBadVersion,
+			WantUnmarshalError: ErrBadVersion,
 		},
 	} {
 		data, err := test.Packet.Marshal()
@@ -59,3 +59,47 @@ func TestRawPacketRoundTrip(t *testing.T) {
 		assert.Equalf(t, test.Packet, decoded, "Unmarshal %q", test.Name)
 	}
 }
+
+func TestUnmarshalUnknownPackets(t *testing.T) {
+	data := []byte{
+		// v=2, p=0, count=0, PT=210 (unassigned), len=1
+		0x80, 0xd2, 0x00, 0x01,
+		0x01, 0x02, 0x03, 0x04,
+		// v=2, p=0, FMT=9 (unknown), PSFB, len=2
+		0x89, 0xce, 0x00, 0x02,
+		// sender=0x902f9e2e
+		0x90, 0x2f, 0x9e, 0x2e,
+		// media=0x902f9e2e
+		0x90, 0x2f, 0x9e, 0x2e,
+		// Picture Loss Indication
+		0x81, 0xce, 0x00, 0x02,
+		// sender=0x902f9e2e
+		0x90, 0x2f, 0x9e, 0x2e,
+		// media=0x902f9e2e
+		0x90, 0x2f, 0x9e, 0x2e,
+	}
+	original := append([]byte(nil), data...)
+
+	packets, err := Unmarshal(data)
+	assert.NoError(t, err)
+	assert.Len(t, packets, 3)
+
+	assert.Equal(t, &RawPacket{0x80, 0xd2, 0x00, 0x01, 0x01, 0x02, 0x03, 0x04}, packets[0])
+	assert.Equal(t, &GenericFeedback{
+		PacketType:  TypePayloadSpecificFeedback,
+		MessageType: 9,
+		SenderSSRC:  0x902f9e2e,
+		MediaSSRC:   0x902f9e2e,
+		FCI:         []byte{},
+	}, packets[1])
+	assert.IsType(t, &PictureLossIndication{}, packets[2])
+
+	// the unknown packets must not alias the input buffer
+	for i := range data {
+		data[i] = 0
+	}
+
+	marshaled, err := Marshal(packets)
+	assert.NoError(t, err)
+	assert.Equal(t, original, marshaled)
+}
@@ -21,6 +21,8 @@ type ReceiverEstimatedMaximumBitrate struct {
 
 	// SSRC entries which this packet applies to
 	SSRCs []uint32
+
+	packetPadding
 }
 
 // Marshal serializes the packet and returns a byte slice.
@@ -44,7 +46,13 @@ func (p ReceiverEstimatedMaximumBitrate) Marshal() (buf []byte, err error) {
 
 // MarshalSize returns the size of the packet once marshaled.
 func (p ReceiverEstimatedMaximumBitrate) MarshalSize() int {
-	return 20 + 4*len(p.SSRCs)
+	return 20 + 4*len(p.SSRCs) + int(p.padding)
+}
+
+// MarshalAppend appends the packet in binary to dst and returns the
+// extended slice.
+func (p ReceiverEstimatedMaximumBitrate) MarshalAppend(dst []byte) ([]byte, error) {
+	return marshalAppend(dst, p.MarshalSize(), p.MarshalTo)
 }
 
 // MarshalTo serializes the packet to the given byte slice.
@@ -71,15 +79,16 @@ func (p ReceiverEstimatedMaximumBitrate) MarshalTo(buf []byte) (n int, err error
 
 	size := p.MarshalSize()
 	if len(buf) < size {
-		return 0, errPacketTooShort
+		return 0, ErrPacketTooShort
 	}
 
-	buf[0] = 143 // v=2, p=0, fmt=15
-	buf[1] = 206
-
-	// Length of this packet in 32-bit words minus one.
-	length := uint16((p.MarshalSize() / 4) - 1) //nolint:gosec // G115
-	binary.BigEndian.PutUint16(buf[2:4], length)
+	header := p.Header()
+	if err := header.SetLengthFromBytes(size); err != nil {
+		return 0, err
+	}
+	if _, err := header.MarshalTo(buf); err != nil {
+		return 0, err
+	}
 
 	binary.BigEndian.PutUint32(buf[4:8], p.SenderSSRC)
 	binary.BigEndian.PutUint32(buf[8:12], 0) // always zero
@@ -129,7 +138,9 @@ func (p ReceiverEstimatedMaximumBitrate) MarshalTo(buf []byte) (n int, err error
 		n += 4
 	}
 
-	return n, nil
+	writePadding(buf[:size], p.padding)
+
+	return size, nil
 }
 
 // Unmarshal reads a REMB packet from the given byte slice.
@@ -156,21 +167,20 @@ func (p *ReceiverEstimatedMaximumBitrate) Unmarshal(buf []byte) (err error) {
 	   |  ...                                                          |
 	*/
 
+	buf, padding, err := removePadding(buf)
+	if err != nil {
+		return err
+	}
+
 	// 20 bytes is the size of the packet with no SSRCs
 	if len(buf) < 20 {
-		return errPacketTooShort
+		return ErrPacketTooShort
 	}
 
 	// version  must be 2
 	version := buf[0] >> 6
 	if version != 2 {
-		return fmt.Errorf("%w expected(2) actual(%d)", errBadVersion, version)
-	}
-
-	// padding must be unset
-	padding := (buf[0] >> 5) & 1
-	if padding != 0 {
-		return fmt.Errorf("%w expected(0) actual(%d)", errWrongPadding, padding)
+		return fmt.Errorf("%w expected(2) actual(%d)", ErrBadVersion, version)
 	}
 
 	// fmt must be 15
@@ -186,7 +196,7 @@ func (p *ReceiverEstimatedMaximumBitrate) Unmarshal(buf []byte) (err error) {
 
 	// length is the number of 32-bit words, minus 1
 	length := binary.BigEndian.Uint16(buf[2:4])
-	size := int((length + 1) * 4)
+	size := (int(length) + 1) * 4
 
 	// There's not way this could be legit
 	if size < 20 {
@@ -195,7 +205,7 @@ func (p *ReceiverEstimatedMaximumBitrate) Unmarshal(buf []byte) (err error) {
 
 	// Make sure the buffer is large enough.
 	if len(buf) < size {
-		return errPacketTooShort
+		return ErrPacketTooShort
 	}
 
 	// The sender SSRC is 32-bits
@@ -249,16 +259,27 @@ func (p *ReceiverEstimatedMaximumBitrate) Unmarshal(buf []byte) (err error) {
 		p.SSRCs = append(p.SSRCs, ssrc)
 	}
 
+	p.padding = padding
+
 	return nil
 }
 
+// hasREMBIdentifier reports whether an application layer feedback
+// packet carries the 'REMB' unique identifier.
+func hasREMBIdentifier(buf []byte) bool {
+	return len(buf) >= 16 && bytes.Equal(buf[12:16], []byte{'R', 'E', 'M', 'B'})
+}
+
 // Header returns the Header associated with this packet.
 func (p *ReceiverEstimatedMaximumBitrate) Header() Header {
-	return Header{
-		Count:  FormatREMB,
-		Type:   TypePayloadSpecificFeedback,
-		Length: uint16((p.MarshalSize() / 4) - 1), //nolint:gosec // G115
+	h := Header{
+		Padding: p.padding != 0,
+		Count:   FormatREMB,
+		Type:    TypePayloadSpecificFeedback,
 	}
+	_ = h.SetLengthFromBytes(p.MarshalSize())
+
+	return h
 }
 
 // String prints the REMB packet in a human-readable format.
@@ -281,7 +302,88 @@ func (p *ReceiverEstimatedMaximumBitrate) String() string {
 	return fmt.Sprintf("ReceiverEstimatedMaximumBitrate %x %.2f %s/s", p.SenderSSRC, bitrate, unit)
 }
 
+// Validate checks that the ReceiverEstimatedMaximumBitrate can be sent: that
+// its bitrate is not negative or NaN, and that it lists at most 255 SSRCs.
+func (p *ReceiverEstimatedMaximumBitrate) Validate() error {
+	if !(p.Bitrate >= 0) {
+		return errInvalidBitrate
+	}
+	if len(p.SSRCs) > math.MaxUint8 {
+		return errTooManySources
+	}
+
+	return nil
+}
+
+// Type returns the packet type, TypePayloadSpecificFeedback.
+func (p *ReceiverEstimatedMaximumBitrate) Type() PacketType {
+	return TypePayloadSpecificFeedback
+}
+
+// Format returns the feedback message type, FormatREMB.
+func (p *ReceiverEstimatedMaximumBitrate) Format() uint8 {
+	return FormatREMB
+}
+
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
 func (p *ReceiverEstimatedMaximumBitrate) DestinationSSRC() []uint32 {
 	return p.SSRCs
 }
+
+// TargetsSSRC reports whether the packet is feedback about the media source
+// ssrc, one of the SSRCs the estimate applies to.
+func (p *ReceiverEstimatedMaximumBitrate) TargetsSSRC(ssrc uint32) bool {
+	for _, s := range p.SSRCs {
+		if s == ssrc {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SourceSSRC returns the SSRC of the sender.
+func (p *ReceiverEstimatedMaximumBitrate) SourceSSRC() []uint32 {
+	return []uint32{p.SenderSSRC}
+}
+
+// RewriteSSRC replaces every SSRC in the packet that equals from with to,
+// and returns the number of SSRCs replaced.
+func (p *ReceiverEstimatedMaximumBitrate) RewriteSSRC(from, to uint32) int {
+	n := rewriteSSRC(&p.SenderSSRC, from, to)
+	for i := range p.SSRCs {
+		n += rewriteSSRC(&p.SSRCs[i], from, to)
+	}
+
+	return n
+}
+
+// Equal reports whether other is a ReceiverEstimatedMaximumBitrate with the same contents.
+func (p *ReceiverEstimatedMaximumBitrate) Equal(other Packet) bool {
+	o, ok := other.(*ReceiverEstimatedMaximumBitrate)
+	if !ok || o == nil {
+		return false
+	}
+
+	return p.SenderSSRC == o.SenderSSRC &&
+		p.Bitrate == o.Bitrate &&
+		slicesEqual(p.SSRCs, o.SSRCs)
+}
+
+// Clone returns a deep copy of the packet that shares no memory with p.
+func (p *ReceiverEstimatedMaximumBitrate) Clone() Packet {
+	c := *p
+	c.SSRCs = cloneSlice(p.SSRCs)
+
+	return &c
+}
+
+// MarshalJSON encodes the packet as a JSON object tagged with its type.
+func (p ReceiverEstimatedMaximumBitrate) MarshalJSON() ([]byte, error) {
+	return marshalJSON(p)
+}
+
+// UnmarshalJSON decodes a JSON object produced by MarshalJSON.
+func (p *ReceiverEstimatedMaximumBitrate) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, p)
+}
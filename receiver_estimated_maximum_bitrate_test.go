@@ -128,3 +128,25 @@ func TestReceiverEstimatedMaximumBitrateOverflow(t *testing.T) {
 	assert.NoError(err)
 	assert.Equal(math.Float32frombits(0x62800000), packet.Bitrate)
 }
+
+func TestReceiverEstimatedMaximumBitrateDispatch(t *testing.T) {
+	assert := assert.New(t)
+
+	input := []byte{143, 206, 0, 5, 0, 0, 0, 1, 0, 0, 0, 0, 82, 69, 77, 66, 1, 26, 32, 223, 72, 116, 237, 22}
+	packets, err := Unmarshal(input)
+	assert.NoError(err)
+	assert.Equal([]Packet{&ReceiverEstimatedMaximumBitrate{
+		SenderSSRC: 1,
+		Bitrate:    8927168,
+		SSRCs:      []uint32{1215622422},
+	}}, packets)
+
+	// Application layer feedback with another identifier is left undecoded
+	other := []byte{143, 206, 0, 4, 0, 0, 0, 1, 0, 0, 0, 0, 'A', 'B', 'C', 'D', 1, 2, 3, 4}
+	packets, err = Unmarshal(other)
+	assert.NoError(err)
+	assert.Equal([]Packet{&ApplicationLayerFeedback{
+		SenderSSRC: 1,
+		FCI:        []byte{'A', 'B', 'C', 'D', 1, 2, 3, 4},
+	}}, packets)
+}
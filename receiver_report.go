@@ -4,6 +4,7 @@
 package rtcp
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 )
@@ -18,8 +19,11 @@ type ReceiverReport struct {
 	// single synchronization source.
 	Reports []ReceptionReport
 	// Extension contains additional, payload-specific information that needs to
-	// be reported regularly about the receiver.
+	// be reported regularly about the receiver. It follows the report blocks
+	// and is padded with zeros to a multiple of 4 bytes when marshaled.
 	ProfileExtensions []byte
+
+	packetPadding
 }
 
 const (
@@ -30,6 +34,22 @@ const (
 
 // Marshal encodes the ReceiverReport in binary.
 func (r ReceiverReport) Marshal() ([]byte, error) {
+	rawPacket := make([]byte, r.MarshalSize())
+	if _, err := r.MarshalTo(rawPacket); err != nil {
+		return nil, err
+	}
+
+	return rawPacket, nil
+}
+
+// MarshalAppend appends the packet in binary to dst and returns the
+// extended slice.
+func (r ReceiverReport) MarshalAppend(dst []byte) ([]byte, error) {
+	return marshalAppend(dst, r.MarshalSize(), r.MarshalTo)
+}
+
+// MarshalTo encodes the ReceiverReport in binary into buf and returns the number of bytes written.
+func (r ReceiverReport) MarshalTo(buf []byte) (int, error) {
 	/*
 	 *         0                   1                   2                   3
 	 *         0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
@@ -58,46 +78,63 @@ func (r ReceiverReport) Marshal() ([]byte, error) {
 	 *        +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
 	 */
 
-	rawPacket := make([]byte, r.MarshalSize())
-	packetBody := rawPacket[headerLength:]
+	size := r.MarshalSize()
+	if len(buf) < size {
+		return 0, ErrPacketTooShort
+	}
+
+	if len(r.Reports) > countMax {
+		return 0, errTooManyReports
+	}
+
+	header := r.Header()
+	if err := header.SetLengthFromBytes(size); err != nil {
+		return 0, err
+	}
+	if _, err := header.MarshalTo(buf); err != nil {
+		return 0, err
+	}
+
+	packetBody := buf[headerLength:size]
 
 	binary.BigEndian.PutUint32(packetBody, r.SSRC)
 
-	for i, rp := range r.Reports {
-		data, err := rp.Marshal()
+	offset := ssrcLength
+	for _, rp := range r.Reports {
+		n, err := rp.marshalTo(packetBody[offset:])
 		if err != nil {
-			return nil, err
+			return 0, err
 		}
-		offset := ssrcLength + receptionReportLength*i
-		copy(packetBody[offset:], data)
-	}
-
-	if len(r.Reports) > countMax {
-		return nil, errTooManyReports
+		offset += n
 	}
 
-	pe := make([]byte, len(r.ProfileExtensions))
-	copy(pe, r.ProfileExtensions)
+	offset += copy(packetBody[offset:], r.ProfileExtensions)
 
 	// if the length of the profile extensions isn't devisible
 	// by 4, we need to pad the end.
-	for (len(pe) & 0x3) != 0 {
-		pe = append(pe, 0) //nolint:makezero
+	for ; offset < len(packetBody); offset++ {
+		packetBody[offset] = 0
 	}
 
-	rawPacket = append(rawPacket, pe...) //nolint:makezero
+	writePadding(buf[:size], r.padding)
 
-	hData, err := r.Header().Marshal()
-	if err != nil {
-		return nil, err
+	return size, nil
+}
+
+// Unmarshal decodes the ReceiverReport from binary. The profile extensions are
+// copied so the caller may reuse rawPacket once Unmarshal returns.
+func (r *ReceiverReport) Unmarshal(rawPacket []byte) error {
+	if err := r.unmarshalNoCopy(rawPacket); err != nil {
+		return err
 	}
-	copy(rawPacket, hData)
+	r.ProfileExtensions = cloneSlice(r.ProfileExtensions)
 
-	return rawPacket, nil
+	return nil
 }
 
-// Unmarshal decodes the ReceiverReport from binary.
-func (r *ReceiverReport) Unmarshal(rawPacket []byte) error {
+// unmarshalNoCopy implements Unmarshal, leaving r.ProfileExtensions pointing
+// into rawPacket.
+func (r *ReceiverReport) unmarshalNoCopy(rawPacket []byte) error {
 	/*
 	 *         0                   1                   2                   3
 	 *         0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
@@ -126,8 +163,13 @@ func (r *ReceiverReport) Unmarshal(rawPacket []byte) error {
 	 *        +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
 	 */
 
+	rawPacket, padding, err := removePadding(rawPacket)
+	if err != nil {
+		return err
+	}
+
 	if len(rawPacket) < (headerLength + ssrcLength) {
-		return errPacketTooShort
+		return ErrPacketTooShort
 	}
 
 	var header Header
@@ -136,24 +178,36 @@ func (r *ReceiverReport) Unmarshal(rawPacket []byte) error {
 	}
 
 	if header.Type != TypeReceiverReport {
-		return errWrongType
+		return ErrWrongType
+	}
+
+	// The profile extensions run to the end of the packet as given by its
+	// length, not of the buffer.
+	end := (int(header.Length) + 1) * 4
+	if end < headerLength+ssrcLength || len(rawPacket) < end {
+		return ErrPacketTooShort
 	}
+	rawPacket = rawPacket[:end]
 
 	r.SSRC = binary.BigEndian.Uint32(rawPacket[rrSSRCOffset:])
 
-	for i := rrReportOffset; i < len(rawPacket) && len(r.Reports) < int(header.Count); i += receptionReportLength {
+	if err := checkReportCount(header.Count, len(rawPacket)-rrReportOffset); err != nil {
+		return err
+	}
+
+	r.Reports = nil
+	offset := rrReportOffset
+	for i := 0; i < int(header.Count); i++ {
 		var rr ReceptionReport
-		if err := rr.Unmarshal(rawPacket[i:]); err != nil {
+		if err := rr.Unmarshal(rawPacket[offset:]); err != nil {
 			return err
 		}
 		r.Reports = append(r.Reports, rr)
+		offset += receptionReportLength
 	}
-	r.ProfileExtensions = rawPacket[rrReportOffset+(len(r.Reports)*receptionReportLength):]
+	r.ProfileExtensions = rawPacket[offset:]
 
-	//nolint:gosec // G115
-	if uint8(len(r.Reports)) != header.Count {
-		return errInvalidHeader
-	}
+	r.padding = padding
 
 	return nil
 }
@@ -165,16 +219,41 @@ func (r *ReceiverReport) MarshalSize() int {
 		repsLength += rep.len()
 	}
 
-	return headerLength + ssrcLength + repsLength
+	peLength := len(r.ProfileExtensions) + getPadding(len(r.ProfileExtensions))
+
+	return headerLength + ssrcLength + repsLength + peLength + int(r.padding)
 }
 
 // Header returns the Header associated with this packet.
 func (r *ReceiverReport) Header() Header {
-	return Header{
-		Count:  uint8(len(r.Reports)), //nolint:gosec // G115
-		Type:   TypeReceiverReport,
-		Length: uint16((r.MarshalSize()/4)-1) + uint16(getPadding(len(r.ProfileExtensions))), //nolint:gosec // G115
+	h := Header{
+		Padding: r.padding != 0,
+		Count:   uint8(len(r.Reports)), //nolint:gosec // G115
+		Type:    TypeReceiverReport,
+	}
+	_ = h.SetLengthFromBytes(r.MarshalSize())
+
+	return h
+}
+
+// Validate checks that the ReceiverReport can be sent: that it carries at most
+// 31 report blocks, whose cumulative numbers of packets lost fit in 24 bits.
+func (r *ReceiverReport) Validate() error {
+	if len(r.Reports) > countMax {
+		return errTooManyReports
+	}
+	for _, report := range r.Reports {
+		if err := report.validate(); err != nil {
+			return err
+		}
 	}
+
+	return checkPacketSize(r.MarshalSize())
+}
+
+// Type returns the packet type, TypeReceiverReport.
+func (r *ReceiverReport) Type() PacketType {
+	return TypeReceiverReport
 }
 
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
@@ -187,6 +266,123 @@ func (r *ReceiverReport) DestinationSSRC() []uint32 {
 	return out
 }
 
+// SourceSSRC returns the SSRC of the receiver that sent the report.
+func (r *ReceiverReport) SourceSSRC() []uint32 {
+	return []uint32{r.SSRC}
+}
+
+// RewriteSSRC replaces every SSRC in the packet that equals from with to,
+// and returns the number of SSRCs replaced.
+func (r *ReceiverReport) RewriteSSRC(from, to uint32) int {
+	n := rewriteSSRC(&r.SSRC, from, to)
+	for i := range r.Reports {
+		n += rewriteSSRC(&r.Reports[i].SSRC, from, to)
+	}
+
+	return n
+}
+
+// Equal reports whether other is a ReceiverReport with the same contents.
+// Nil and empty slices are considered equal.
+func (r *ReceiverReport) Equal(other Packet) bool {
+	o, ok := other.(*ReceiverReport)
+	if !ok || o == nil {
+		return false
+	}
+
+	return r.SSRC == o.SSRC &&
+		slicesEqual(r.Reports, o.Reports) &&
+		bytes.Equal(r.ProfileExtensions, o.ProfileExtensions)
+}
+
+// Clone returns a deep copy of the packet that shares no memory with r.
+func (r *ReceiverReport) Clone() Packet {
+	c := *r
+	c.Reports = cloneSlice(r.Reports)
+	c.ProfileExtensions = cloneSlice(r.ProfileExtensions)
+
+	return &c
+}
+
+// Split partitions the report blocks of r into ReceiverReports from the same
+// SSRC that each carry at most the 31 blocks the 5-bit count field allows,
+// for r holding more. The profile extensions stay on the first. A report
+// with 31 blocks or fewer is returned as a single copy. The reports share no
+// memory with r.
+func (r *ReceiverReport) Split() []*ReceiverReport {
+	n := len(r.Reports)
+	if n > countMax {
+		n = countMax
+	}
+
+	first := *r
+	first.Reports = cloneSlice(r.Reports[:n])
+	first.ProfileExtensions = cloneSlice(r.ProfileExtensions)
+
+	return append([]*ReceiverReport{&first}, splitReports(r.SSRC, r.Reports[n:])...)
+}
+
+// splitReports returns ReceiverReports from ssrc carrying copies of reports,
+// at most countMax in each.
+func splitReports(ssrc uint32, reports []ReceptionReport) []*ReceiverReport {
+	var rrs []*ReceiverReport
+	for len(reports) > 0 {
+		n := len(reports)
+		if n > countMax {
+			n = countMax
+		}
+		rrs = append(rrs, &ReceiverReport{SSRC: ssrc, Reports: cloneSlice(reports[:n])})
+		reports = reports[n:]
+	}
+
+	return rrs
+}
+
+// MergeReceiverReports consolidates the report blocks of receiver reports
+// collected over an interval into a single ReceiverReport. Blocks are keyed by
+// the SSRC of the report carrying them and the SSRC they report on, and of
+// blocks with the same key only the one with the highest LastSequenceNumber
+// is kept, the later one on a tie. The blocks keep the order in which their
+// keys were first seen. The merged report has the SSRC of the first report
+// and no profile extensions; it may hold more than 31 blocks, which Split
+// spreads over several reports. It returns nil if reports is empty.
+func MergeReceiverReports(reports ...*ReceiverReport) *ReceiverReport {
+	if len(reports) == 0 {
+		return nil
+	}
+
+	type key struct {
+		reporter, source uint32
+	}
+	merged := &ReceiverReport{SSRC: reports[0].SSRC}
+	index := map[key]int{}
+	for _, rr := range reports {
+		for _, block := range rr.Reports {
+			k := key{rr.SSRC, block.SSRC}
+			i, ok := index[k]
+			switch {
+			case !ok:
+				index[k] = len(merged.Reports)
+				merged.Reports = append(merged.Reports, block)
+			case block.LastSequenceNumber >= merged.Reports[i].LastSequenceNumber:
+				merged.Reports[i] = block
+			}
+		}
+	}
+
+	return merged
+}
+
+// MarshalJSON encodes the packet as a JSON object tagged with its type.
+func (r ReceiverReport) MarshalJSON() ([]byte, error) {
+	return marshalJSON(r)
+}
+
+// UnmarshalJSON decodes a JSON object produced by MarshalJSON.
+func (r *ReceiverReport) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, r)
+}
+
 func (r ReceiverReport) String() string {
 	out := fmt.Sprintf("ReceiverReport from %x\n", r.SSRC)
 	out += "\tSSRC    \tLost\tLastSequence\n"
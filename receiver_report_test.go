@@ -103,7 +103,7 @@ func TestReceiverReportUnmarshal(t *testing.T) {
 				0x00, 0x00, 0x00, 0x00,
 				// report ends early
 			},
-			WantError: errPacketTooShort,
+			WantError: ErrPacketTooShort,
 		},
 		{
 			Name: "wrong type",
@@ -125,7 +125,7 @@ func TestReceiverReportUnmarshal(t *testing.T) {
 				// delay=150137
 				0x0, 0x2, 0x4a, 0x79,
 			},
-			WantError: errWrongType,
+			WantError: ErrWrongType,
 		},
 		{
 			Name: "bad count in header",
@@ -147,12 +147,58 @@ func TestReceiverReportUnmarshal(t *testing.T) {
 				// delay=150137
 				0x0, 0x2, 0x4a, 0x79,
 			},
-			WantError: errInvalidHeader,
+			WantError: ErrInvalidHeader,
+		},
+		{
+			Name: "padded",
+			Data: []byte{
+				// v=2, p=1, count=1, RR, len=9
+				0xa1, 0xc9, 0x0, 0x9,
+				// ssrc=0x902f9e2e
+				0x90, 0x2f, 0x9e, 0x2e,
+				// ssrc=0xbc5e9a40
+				0xbc, 0x5e, 0x9a, 0x40,
+				// fracLost=0, totalLost=0
+				0x0, 0x0, 0x0, 0x0,
+				// lastSeq=0x46e1
+				0x0, 0x0, 0x46, 0xe1,
+				// jitter=273
+				0x0, 0x0, 0x1, 0x11,
+				// lsr=0x9f36432
+				0x9, 0xf3, 0x64, 0x32,
+				// delay=150137
+				0x0, 0x2, 0x4a, 0x79,
+				// padding, whose contents other than the count are arbitrary
+				0xde, 0xad, 0xbe, 0xef,
+				0x0, 0x0, 0x0, 0x8,
+			},
+			Want: ReceiverReport{
+				SSRC: 0x902f9e2e,
+				Reports: []ReceptionReport{{
+					SSRC:               0xbc5e9a40,
+					LastSequenceNumber: 0x46e1,
+					Jitter:             273,
+					LastSenderReport:   0x9f36432,
+					Delay:              150137,
+				}},
+				ProfileExtensions: []byte{},
+				packetPadding:     packetPadding{padding: 8},
+			},
+		},
+		{
+			Name: "padding longer than the packet",
+			Data: []byte{
+				// v=2, p=1, count=0, RR, len=1
+				0xa0, 0xc9, 0x0, 0x1,
+				// ssrc=0x902f9e2e with a padding count of 0x2c
+				0x90, 0x2f, 0x9e, 0x2c,
+			},
+			WantError: ErrWrongPadding,
 		},
 		{
 			Name:      "nil",
 			Data:      nil,
-			WantError: errPacketTooShort,
+			WantError: ErrPacketTooShort,
 		},
 	} {
 		var rr ReceiverReport
@@ -259,3 +305,135 @@ func TestReceiverReportRoundTrip(t *testing.T) {
 		assert.Equalf(t, test.Report, decoded, "%s rr round trip mismatch", test.Name)
 	}
 }
+
+func TestReceiverReportTruncatedReports(t *testing.T) {
+	reports := make([]ReceptionReport, 31)
+	for i := range reports {
+		reports[i] = ReceptionReport{SSRC: uint32(i), Jitter: 273}
+	}
+	full, err := ReceiverReport{SSRC: 0x902f9e2e, Reports: reports}.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(0x9f), full[0], "count=31")
+
+	for _, reportsPresent := range []int{0, 2, 30} {
+		data := append([]byte(nil), full[:rrReportOffset+reportsPresent*receptionReportLength]...)
+		// fix up the length so only the count is wrong
+		data[3] = uint8(len(data)/4 - 1)
+
+		var rr ReceiverReport
+		err := rr.Unmarshal(data)
+		assert.ErrorIsf(t, err, ErrPacketTooShort, "%d of 31 reports", reportsPresent)
+		assert.ErrorIsf(t, err, ErrInvalidHeader, "%d of 31 reports", reportsPresent)
+	}
+
+	var rr ReceiverReport
+	assert.NoError(t, rr.Unmarshal(full))
+	assert.Equal(t, reports, rr.Reports)
+}
+
+func TestReceiverReportProfileExtensions(t *testing.T) {
+	rr := ReceiverReport{
+		SSRC:              0x902f9e2e,
+		Reports:           []ReceptionReport{{SSRC: 0xbc5e9a40, LastSequenceNumber: 0x46e1}},
+		ProfileExtensions: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06},
+	}
+
+	data, err := rr.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{
+		// v=2, p=0, count=1, RR, len=9
+		0x81, 0xc9, 0x00, 0x09,
+		// ssrc=0x902f9e2e
+		0x90, 0x2f, 0x9e, 0x2e,
+		// ssrc=0xbc5e9a40
+		0xbc, 0x5e, 0x9a, 0x40,
+		// fracLost=0, totalLost=0
+		0x00, 0x00, 0x00, 0x00,
+		// lastSeq=0x46e1
+		0x00, 0x00, 0x46, 0xe1,
+		// jitter=0
+		0x00, 0x00, 0x00, 0x00,
+		// lsr=0
+		0x00, 0x00, 0x00, 0x00,
+		// delay=0
+		0x00, 0x00, 0x00, 0x00,
+		// profile-specific extension, zero padded to 32 bits
+		0x01, 0x02, 0x03, 0x04,
+		0x05, 0x06, 0x00, 0x00,
+	}, data)
+
+	// Data after the packet's length is not part of the extension.
+	var decoded ReceiverReport
+	assert.NoError(t, decoded.Unmarshal(append(data, 0x81, 0xca, 0x00, 0x00)))
+	assert.Equal(t, rr.Reports, decoded.Reports)
+	assert.Equal(t, []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x00, 0x00}, decoded.ProfileExtensions)
+
+	assert.ErrorIs(t, decoded.Unmarshal(data[:len(data)-4]), ErrPacketTooShort)
+}
+
+func TestReceiverReportSplit(t *testing.T) {
+	rr := &ReceiverReport{SSRC: 0x902f9e2e, ProfileExtensions: []byte{0x01, 0x02, 0x03, 0x04}}
+	for i := 0; i < 50; i++ {
+		rr.Reports = append(rr.Reports, ReceptionReport{SSRC: uint32(i)})
+	}
+
+	_, err := rr.Marshal()
+	assert.ErrorIs(t, err, errTooManyReports)
+
+	rrs := rr.Split()
+	assert.Equal(t, []*ReceiverReport{
+		{SSRC: rr.SSRC, Reports: rr.Reports[:31], ProfileExtensions: rr.ProfileExtensions},
+		{SSRC: rr.SSRC, Reports: rr.Reports[31:]},
+	}, rrs)
+
+	packets := make([]Packet, 0, len(rrs))
+	for _, split := range rrs {
+		assert.NoError(t, split.Validate())
+		packets = append(packets, split)
+	}
+	_, err = Marshal(packets)
+	assert.NoError(t, err)
+
+	// The reports share no memory with the report they came from.
+	rrs[1].Reports[0].SSRC = 0xffffffff
+	assert.Equal(t, uint32(31), rr.Reports[31].SSRC)
+
+	small := &ReceiverReport{SSRC: 1, Reports: []ReceptionReport{{SSRC: 2}}}
+	split := small.Split()
+	assert.Equal(t, []*ReceiverReport{small}, split)
+	assert.NotSame(t, small, split[0])
+
+	assert.Equal(t, []*ReceiverReport{{SSRC: 1}}, (&ReceiverReport{SSRC: 1}).Split())
+}
+
+func TestMergeReceiverReports(t *testing.T) {
+	assert.Nil(t, MergeReceiverReports())
+
+	merged := MergeReceiverReports(
+		&ReceiverReport{SSRC: 1, Reports: []ReceptionReport{
+			{SSRC: 10, LastSequenceNumber: 100, Jitter: 1},
+			{SSRC: 20, LastSequenceNumber: 0x10005, Jitter: 2},
+		}, ProfileExtensions: []byte{1, 2, 3, 4}},
+		&ReceiverReport{SSRC: 2, Reports: []ReceptionReport{
+			// another reporter's block on the same source is kept
+			{SSRC: 10, LastSequenceNumber: 50, Jitter: 3},
+		}},
+		&ReceiverReport{SSRC: 1, Reports: []ReceptionReport{
+			// newer than the first block on 10
+			{SSRC: 10, LastSequenceNumber: 150, Jitter: 4},
+			// older than the first block on 20, across a wraparound
+			{SSRC: 20, LastSequenceNumber: 0xfff0, Jitter: 5},
+			{SSRC: 30, LastSequenceNumber: 7, Jitter: 6},
+		}},
+		&ReceiverReport{SSRC: 1, Reports: []ReceptionReport{
+			// a tie keeps the later block
+			{SSRC: 30, LastSequenceNumber: 7, Jitter: 7},
+		}},
+	)
+	assert.Equal(t, &ReceiverReport{SSRC: 1, Reports: []ReceptionReport{
+		{SSRC: 10, LastSequenceNumber: 150, Jitter: 4},
+		{SSRC: 20, LastSequenceNumber: 0x10005, Jitter: 2},
+		{SSRC: 10, LastSequenceNumber: 50, Jitter: 3},
+		{SSRC: 30, LastSequenceNumber: 7, Jitter: 7},
+	}}, merged)
+}
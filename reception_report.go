@@ -3,7 +3,11 @@
 
 package rtcp
 
-import "encoding/binary"
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
 
 // A ReceptionReport block conveys statistics on the reception of RTP packets
 // from a single synchronization source.
@@ -49,6 +53,16 @@ const (
 
 // Marshal encodes the ReceptionReport in binary.
 func (r ReceptionReport) Marshal() ([]byte, error) {
+	rawPacket := make([]byte, receptionReportLength)
+	if _, err := r.marshalTo(rawPacket); err != nil {
+		return nil, err
+	}
+
+	return rawPacket, nil
+}
+
+// marshalTo encodes the ReceptionReport into buf and returns the number of bytes written.
+func (r ReceptionReport) marshalTo(buf []byte) (int, error) {
 	/*
 	 *  0                   1                   2                   3
 	 *  0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
@@ -67,33 +81,45 @@ func (r ReceptionReport) Marshal() ([]byte, error) {
 	 * +=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+
 	 */
 
-	rawPacket := make([]byte, receptionReportLength)
+	if len(buf) < receptionReportLength {
+		return 0, ErrPacketTooShort
+	}
 
-	binary.BigEndian.PutUint32(rawPacket, r.SSRC)
+	binary.BigEndian.PutUint32(buf, r.SSRC)
 
-	rawPacket[fractionLostOffset] = r.FractionLost
+	buf[fractionLostOffset] = r.FractionLost
 
 	// pack TotalLost into 24 bits
 	if r.TotalLost >= (1 << 25) {
-		return nil, errInvalidTotalLost
+		return 0, errInvalidTotalLost
 	}
-	tlBytes := rawPacket[totalLostOffset:]
+	tlBytes := buf[totalLostOffset:]
 	tlBytes[0] = byte(r.TotalLost >> 16)
 	tlBytes[1] = byte(r.TotalLost >> 8)
 	tlBytes[2] = byte(r.TotalLost)
 
-	binary.BigEndian.PutUint32(rawPacket[lastSeqOffset:], r.LastSequenceNumber)
-	binary.BigEndian.PutUint32(rawPacket[jitterOffset:], r.Jitter)
-	binary.BigEndian.PutUint32(rawPacket[lastSROffset:], r.LastSenderReport)
-	binary.BigEndian.PutUint32(rawPacket[delayOffset:], r.Delay)
+	binary.BigEndian.PutUint32(buf[lastSeqOffset:], r.LastSequenceNumber)
+	binary.BigEndian.PutUint32(buf[jitterOffset:], r.Jitter)
+	binary.BigEndian.PutUint32(buf[lastSROffset:], r.LastSenderReport)
+	binary.BigEndian.PutUint32(buf[delayOffset:], r.Delay)
 
-	return rawPacket, nil
+	return receptionReportLength, nil
+}
+
+// validate checks that TotalLost fits in its 24 bits. marshalTo accepts
+// somewhat larger values, which it truncates.
+func (r ReceptionReport) validate() error {
+	if r.TotalLost > 0xFFFFFF {
+		return errInvalidTotalLost
+	}
+
+	return nil
 }
 
 // Unmarshal decodes the ReceptionReport from binary.
 func (r *ReceptionReport) Unmarshal(rawPacket []byte) error {
 	if len(rawPacket) < receptionReportLength {
-		return errPacketTooShort
+		return ErrPacketTooShort
 	}
 
 	/*
@@ -128,6 +154,83 @@ func (r *ReceptionReport) Unmarshal(rawPacket []byte) error {
 	return nil
 }
 
+// FractionLostPercent returns FractionLost as a percentage between 0 and 100.
+func (r ReceptionReport) FractionLostPercent() float64 {
+	return float64(r.FractionLost) * 100 / 256
+}
+
+// SetFractionLost sets FractionLost to the fraction f of packets lost, in
+// the 8-bit fixed point of RFC 3550: the integer part of f * 256. f is
+// clamped to [0, 1], so 1 is encoded as 255; NaN is taken as 0.
+func (r *ReceptionReport) SetFractionLost(f float64) {
+	switch {
+	case !(f > 0): // also NaN
+		r.FractionLost = 0
+	case f >= 1:
+		r.FractionLost = 0xFF
+	default:
+		r.FractionLost = uint8(f * 256)
+	}
+}
+
+// GetFractionLost returns FractionLost as a fraction between 0 and 255/256.
+func (r ReceptionReport) GetFractionLost() float64 {
+	return float64(r.FractionLost) / 256
+}
+
+// SignedTotalLost interprets the low 24 bits of TotalLost as the signed
+// cumulative loss defined by RFC 3550. The count is negative when more
+// packets arrived than were expected, for example because of duplicates.
+func (r ReceptionReport) SignedTotalLost() int32 {
+	return int32(r.TotalLost<<8) >> 8 //nolint:gosec // G115, sign extension
+}
+
+// JitterSeconds returns Jitter, which is in RTP timestamp units, in seconds
+// for a stream with the given RTP clock rate in Hz, e.g. 90000 for video. It
+// returns 0 if clockRate is 0.
+func (r ReceptionReport) JitterSeconds(clockRate uint32) float64 {
+	if clockRate == 0 {
+		return 0
+	}
+
+	return float64(r.Jitter) / float64(clockRate)
+}
+
+// CalculateRTT returns the round-trip time between us and the sender of the
+// report, which was received at received, following RFC 3550 Section 6.4.1:
+// the time since the sender report named by LastSenderReport was sent, less
+// the Delay the reporter held it for. LastSenderReport must be the middle 32
+// bits of the NTP timestamp of a sender report we sent.
+//
+// The second return value is false if the RTT is unavailable, because the
+// reporter has not received a sender report yet (LastSenderReport or Delay
+// is zero) or because the result would be negative.
+func (r ReceptionReport) CalculateRTT(received time.Time) (time.Duration, bool) {
+	if r.LastSenderReport == 0 || r.Delay == 0 {
+		return 0, false
+	}
+
+	// All three values are in units of 1/65536 seconds, and wrap around.
+	now := uint32(toNTPTime(received) >> 16)         //nolint:gosec // G115
+	rtt := int32(now - r.LastSenderReport - r.Delay) //nolint:gosec // G115
+	if rtt < 0 {
+		return 0, false
+	}
+
+	return time.Duration(rtt) * time.Second / 65536, true
+}
+
+// checkReportCount checks that available bytes hold the count reception
+// reports declared by a report's header, before they are indexed.
+func checkReportCount(count uint8, available int) error {
+	if int(count)*receptionReportLength > available {
+		return fmt.Errorf("%w: %d reception reports declared in %d bytes: %w",
+			ErrInvalidHeader, count, available, ErrPacketTooShort)
+	}
+
+	return nil
+}
+
 func (r *ReceptionReport) len() int {
 	return receptionReportLength
 }
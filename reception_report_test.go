@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceptionReportFractionLostPercent(t *testing.T) {
+	for _, test := range []struct {
+		FractionLost uint8
+		Want         float64
+	}{
+		{0, 0},
+		{64, 25},
+		{128, 50},
+		{255, 99.609375},
+	} {
+		report := ReceptionReport{FractionLost: test.FractionLost}
+		assert.Equalf(t, test.Want, report.FractionLostPercent(), "FractionLost %d", test.FractionLost)
+	}
+}
+
+func TestReceptionReportSetFractionLost(t *testing.T) {
+	for _, test := range []struct {
+		Fraction float64
+		Want     uint8
+	}{
+		{0, 0},
+		{0.25, 64},
+		{0.5, 128},
+		// 1/3 * 256 = 85.33, truncated rather than rounded
+		{1.0 / 3, 85},
+		{255.0 / 256, 255},
+		{0.999, 255},
+		{1, 255},
+		{2, 255},
+		{-0.5, 0},
+		{math.NaN(), 0},
+		{math.Inf(1), 255},
+	} {
+		var report ReceptionReport
+		report.SetFractionLost(test.Fraction)
+		assert.Equalf(t, test.Want, report.FractionLost, "SetFractionLost(%v)", test.Fraction)
+	}
+
+	// The encoding round trips.
+	for i := 0; i < 256; i++ {
+		report := ReceptionReport{FractionLost: uint8(i)} //nolint:gosec // G115
+		assert.Equal(t, float64(i)/256, report.GetFractionLost())
+		var again ReceptionReport
+		again.SetFractionLost(report.GetFractionLost())
+		assert.Equalf(t, report.FractionLost, again.FractionLost, "FractionLost %d", i)
+	}
+}
+
+func TestReceptionReportSignedTotalLost(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		TotalLost uint32
+		Want      int32
+	}{
+		{"zero", 0, 0},
+		{"positive", 0x000100, 256},
+		{"largest positive", 0x7fffff, 8388607},
+		{"minus one", 0xffffff, -1},
+		{"minus two", 0xfffffe, -2},
+		{"smallest negative", 0x800000, -8388608},
+		{"bits above 24 ignored", 0x1000005, 5},
+	} {
+		report := ReceptionReport{TotalLost: test.TotalLost}
+		assert.Equalf(t, test.Want, report.SignedTotalLost(), "SignedTotalLost %q", test.Name)
+	}
+
+	// Duplicates encoded on the wire as a 24-bit two's complement value.
+	var report ReceptionReport
+	assert.NoError(t, report.Unmarshal([]byte{
+		0x90, 0x2f, 0x9e, 0x2e,
+		0x00, 0xff, 0xff, 0xfd,
+		0x00, 0x00, 0x46, 0xe1,
+		0x00, 0x00, 0x01, 0x11,
+		0x09, 0xf3, 0x64, 0x32,
+		0x00, 0x02, 0x4a, 0x79,
+	}))
+	assert.Equal(t, int32(-3), report.SignedTotalLost())
+}
+
+func TestReceptionReportCalculateRTT(t *testing.T) {
+	// The example of RFC 3550 Section 6.4.1
+	received := fromNTPTime(0x0000b710_80000000)
+	report := ReceptionReport{LastSenderReport: 0xb7052000, Delay: 0x00054000}
+	rtt, ok := report.CalculateRTT(received)
+	assert.True(t, ok)
+	assert.Equal(t, 6125*time.Millisecond, rtt)
+
+	// A sender report sent now, held for half a second, and the report
+	// received 1.6 seconds later.
+	sent := time.Now()
+	report = ReceptionReport{
+		LastSenderReport: uint32(toNTPTime(sent) >> 16),
+		Delay:            65536 / 2,
+	}
+	rtt, ok = report.CalculateRTT(sent.Add(1600 * time.Millisecond))
+	assert.True(t, ok)
+	assert.InDelta(t, 1100*time.Millisecond, rtt, float64(time.Millisecond))
+
+	// The middle 32 bits of the NTP time wrap around every 18 hours.
+	report = ReceptionReport{LastSenderReport: 0xffff8000, Delay: 0x00004000}
+	rtt, ok = report.CalculateRTT(fromNTPTime(0x00010000_40000000))
+	assert.True(t, ok)
+	assert.Equal(t, 500*time.Millisecond, rtt)
+
+	for _, test := range []struct {
+		Name   string
+		Report ReceptionReport
+	}{
+		{"no sender report", ReceptionReport{}},
+		{"no last sender report", ReceptionReport{Delay: 0x00054000}},
+		{"no delay", ReceptionReport{LastSenderReport: 0xb7052000}},
+		{"negative", ReceptionReport{LastSenderReport: 0xb7108000, Delay: 0x00010000}},
+	} {
+		rtt, ok := test.Report.CalculateRTT(received)
+		assert.Falsef(t, ok, "CalculateRTT %q", test.Name)
+		assert.Zerof(t, rtt, "CalculateRTT %q", test.Name)
+	}
+}
+
+func TestReceptionReportJitterSeconds(t *testing.T) {
+	for _, test := range []struct {
+		Jitter    uint32
+		ClockRate uint32
+		Want      float64
+	}{
+		{0, 90000, 0},
+		{900, 90000, 0.01},
+		{480, 48000, 0.01},
+		{273, 8000, 0.034125},
+		{273, 0, 0},
+	} {
+		report := ReceptionReport{Jitter: test.Jitter}
+		assert.InDeltaf(t, test.Want, report.JitterSeconds(test.ClockRate), 1e-9,
+			"JitterSeconds(%d) of %d", test.ClockRate, test.Jitter)
+	}
+}
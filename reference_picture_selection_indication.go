@@ -0,0 +1,247 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// The ReferencePictureSelectionIndication packet tells an encoder which
+// previously decoded picture it may use as a reference. The bit string is
+// defined by the codec identified by PayloadType.
+// See RFC 4585 Section 6.3.3.
+type ReferencePictureSelectionIndication struct {
+	// SSRC of sender
+	SenderSSRC uint32
+
+	// SSRC of the media source
+	MediaSSRC uint32
+
+	// RTP payload type of the codec the bit string belongs to
+	PayloadType uint8
+
+	// Native RPSI bit string, without the trailing padding
+	BitString []byte
+
+	packetPadding
+}
+
+const (
+	rpsiOffset       = 8
+	rpsiHeaderLength = 2
+	rpsiMaxPT        = 0x7F
+)
+
+var _ Packet = (*ReferencePictureSelectionIndication)(nil)
+
+// Marshal encodes the ReferencePictureSelectionIndication in binary.
+func (p ReferencePictureSelectionIndication) Marshal() ([]byte, error) {
+	rawPacket := make([]byte, p.MarshalSize())
+	if _, err := p.MarshalTo(rawPacket); err != nil {
+		return nil, err
+	}
+
+	return rawPacket, nil
+}
+
+// MarshalAppend appends the packet in binary to dst and returns the
+// extended slice.
+func (p ReferencePictureSelectionIndication) MarshalAppend(dst []byte) ([]byte, error) {
+	return marshalAppend(dst, p.MarshalSize(), p.MarshalTo)
+}
+
+// MarshalTo encodes the ReferencePictureSelectionIndication in binary into
+// buf and returns the number of bytes written.
+func (p ReferencePictureSelectionIndication) MarshalTo(buf []byte) (int, error) {
+	/*
+	 *  0                   1                   2                   3
+	 *  0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * |      PB       |0| Payload Type|    Native RPSI bit string     |
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 * |   defined per codec          ...                | Padding (0) |
+	 * +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 */
+	if p.PayloadType > rpsiMaxPT {
+		return 0, errInvalidPayloadType
+	}
+
+	size := p.MarshalSize()
+	if len(buf) < size {
+		return 0, ErrPacketTooShort
+	}
+
+	header := p.Header()
+	if err := header.SetLengthFromBytes(size); err != nil {
+		return 0, err
+	}
+	if _, err := header.MarshalTo(buf); err != nil {
+		return 0, err
+	}
+
+	packetBody := buf[headerLength : size-int(p.padding)]
+
+	binary.BigEndian.PutUint32(packetBody, p.SenderSSRC)
+	binary.BigEndian.PutUint32(packetBody[4:], p.MediaSSRC)
+
+	fci := packetBody[rpsiOffset:]
+	padding := len(fci) - rpsiHeaderLength - len(p.BitString)
+	fci[0] = uint8(padding * 8) //nolint:gosec // G115
+	fci[1] = p.PayloadType
+	n := copy(fci[rpsiHeaderLength:], p.BitString)
+	for i := rpsiHeaderLength + n; i < len(fci); i++ {
+		fci[i] = 0
+	}
+
+	writePadding(buf[:size], p.padding)
+
+	return size, nil
+}
+
+// Unmarshal decodes the ReferencePictureSelectionIndication from binary.
+func (p *ReferencePictureSelectionIndication) Unmarshal(rawPacket []byte) error {
+	rawPacket, trailing, err := removePadding(rawPacket)
+	if err != nil {
+		return err
+	}
+
+	if len(rawPacket) < (headerLength + (ssrcLength * 2)) {
+		return ErrPacketTooShort
+	}
+
+	var header Header
+	if err := header.Unmarshal(rawPacket); err != nil {
+		return err
+	}
+
+	length := 4 * int(header.Length)
+	if len(rawPacket) < headerLength+length {
+		return ErrPacketTooShort
+	}
+
+	if header.Type != TypePayloadSpecificFeedback || header.Count != FormatRPSI {
+		return ErrWrongType
+	}
+
+	if length < rpsiOffset+rpsiHeaderLength {
+		return ErrBadLength
+	}
+
+	fci := rawPacket[headerLength+rpsiOffset : headerLength+length]
+
+	// PB counts padding bits; whole padding bytes are dropped, while a
+	// partially used last byte is left for the codec to interpret.
+	padding := int(fci[0]) / 8
+	if rpsiHeaderLength+padding > len(fci) {
+		return ErrBadLength
+	}
+
+	p.SenderSSRC = binary.BigEndian.Uint32(rawPacket[headerLength:])
+	p.MediaSSRC = binary.BigEndian.Uint32(rawPacket[headerLength+ssrcLength:])
+	p.PayloadType = fci[1] & rpsiMaxPT
+	p.BitString = append([]byte(nil), fci[rpsiHeaderLength:len(fci)-padding]...)
+
+	p.padding = trailing
+
+	return nil
+}
+
+// Header returns the Header associated with this packet.
+func (p *ReferencePictureSelectionIndication) Header() Header {
+	h := Header{
+		Padding: p.padding != 0,
+		Count:   FormatRPSI,
+		Type:    TypePayloadSpecificFeedback,
+	}
+	_ = h.SetLengthFromBytes(p.MarshalSize())
+
+	return h
+}
+
+// MarshalSize returns the size of the packet once marshaled.
+func (p *ReferencePictureSelectionIndication) MarshalSize() int {
+	fciLength := rpsiHeaderLength + len(p.BitString)
+
+	return headerLength + rpsiOffset + fciLength + getPadding(fciLength) + int(p.padding)
+}
+
+func (p *ReferencePictureSelectionIndication) String() string {
+	return fmt.Sprintf("ReferencePictureSelectionIndication %x %x pt=%d %x",
+		p.SenderSSRC, p.MediaSSRC, p.PayloadType, p.BitString)
+}
+
+// Validate checks that the ReferencePictureSelectionIndication can be sent:
+// that its payload type fits in 7 bits.
+func (p *ReferencePictureSelectionIndication) Validate() error {
+	if p.PayloadType > rpsiMaxPT {
+		return errInvalidPayloadType
+	}
+
+	return checkPacketSize(p.MarshalSize())
+}
+
+// Type returns the packet type, TypePayloadSpecificFeedback.
+func (p *ReferencePictureSelectionIndication) Type() PacketType {
+	return TypePayloadSpecificFeedback
+}
+
+// Format returns the feedback message type, FormatRPSI.
+func (p *ReferencePictureSelectionIndication) Format() uint8 {
+	return FormatRPSI
+}
+
+// DestinationSSRC returns an array of SSRC values that this packet refers to.
+func (p *ReferencePictureSelectionIndication) DestinationSSRC() []uint32 {
+	return []uint32{p.MediaSSRC}
+}
+
+// TargetsSSRC reports whether the packet is feedback about the media source
+// ssrc, its MediaSSRC.
+func (p *ReferencePictureSelectionIndication) TargetsSSRC(ssrc uint32) bool {
+	return p.MediaSSRC == ssrc
+}
+
+// SourceSSRC returns the SSRC of the sender.
+func (p *ReferencePictureSelectionIndication) SourceSSRC() []uint32 {
+	return []uint32{p.SenderSSRC}
+}
+
+// RewriteSSRC replaces every SSRC in the packet that equals from with to,
+// and returns the number of SSRCs replaced.
+func (p *ReferencePictureSelectionIndication) RewriteSSRC(from, to uint32) int {
+	return rewriteSSRC(&p.SenderSSRC, from, to) + rewriteSSRC(&p.MediaSSRC, from, to)
+}
+
+// Equal reports whether other is a ReferencePictureSelectionIndication with the same contents.
+func (p *ReferencePictureSelectionIndication) Equal(other Packet) bool {
+	o, ok := other.(*ReferencePictureSelectionIndication)
+	if !ok || o == nil {
+		return false
+	}
+
+	return p.SenderSSRC == o.SenderSSRC &&
+		p.MediaSSRC == o.MediaSSRC &&
+		p.PayloadType == o.PayloadType &&
+		bytes.Equal(p.BitString, o.BitString)
+}
+
+// Clone returns a deep copy of the packet that shares no memory with p.
+func (p *ReferencePictureSelectionIndication) Clone() Packet {
+	c := *p
+	c.BitString = cloneSlice(p.BitString)
+
+	return &c
+}
+
+// MarshalJSON encodes the packet as a JSON object tagged with its type.
+func (p ReferencePictureSelectionIndication) MarshalJSON() ([]byte, error) {
+	return marshalJSON(p)
+}
+
+// UnmarshalJSON decodes a JSON object produced by MarshalJSON.
+func (p *ReferencePictureSelectionIndication) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, p)
+}
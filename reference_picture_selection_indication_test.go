@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Packet = (*ReferencePictureSelectionIndication)(nil) // assert is a Packet
+
+func TestReferencePictureSelectionIndicationUnmarshal(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Data      []byte
+		Want      ReferencePictureSelectionIndication
+		WantError error
+	}{
+		{
+			Name: "valid",
+			Data: []byte{
+				// v=2, p=0, FMT=3, PSFB, len=4
+				0x83, 0xce, 0x00, 0x04,
+				// sender=0x902f9e2e
+				0x90, 0x2f, 0x9e, 0x2e,
+				// media=0x4bc4fcb4
+				0x4b, 0xc4, 0xfc, 0xb4,
+				// PB=24, PT=96, bit string
+				0x18, 0x60, 0xab, 0xcd,
+				0xef, 0x00, 0x00, 0x00,
+			},
+			Want: ReferencePictureSelectionIndication{
+				SenderSSRC:  0x902f9e2e,
+				MediaSSRC:   0x4bc4fcb4,
+				PayloadType: 96,
+				BitString:   []byte{0xab, 0xcd, 0xef},
+			},
+		},
+		{
+			Name: "partial padding byte",
+			Data: []byte{
+				// v=2, p=0, FMT=3, PSFB, len=3
+				0x83, 0xce, 0x00, 0x03,
+				0x90, 0x2f, 0x9e, 0x2e,
+				0x4b, 0xc4, 0xfc, 0xb4,
+				// PB=4, PT=96, bit string
+				0x04, 0x60, 0xab, 0xc0,
+			},
+			Want: ReferencePictureSelectionIndication{
+				SenderSSRC:  0x902f9e2e,
+				MediaSSRC:   0x4bc4fcb4,
+				PayloadType: 96,
+				BitString:   []byte{0xab, 0xc0},
+			},
+		},
+		{
+			Name: "padding exceeds bit string",
+			Data: []byte{
+				// v=2, p=0, FMT=3, PSFB, len=3
+				0x83, 0xce, 0x00, 0x03,
+				0x90, 0x2f, 0x9e, 0x2e,
+				0x4b, 0xc4, 0xfc, 0xb4,
+				// PB=24, PT=96
+				0x18, 0x60, 0x00, 0x00,
+			},
+			WantError: ErrBadLength,
+		},
+		{
+			Name: "missing fci",
+			Data: []byte{
+				// v=2, p=0, FMT=3, PSFB, len=2
+				0x83, 0xce, 0x00, 0x02,
+				0x90, 0x2f, 0x9e, 0x2e,
+				0x4b, 0xc4, 0xfc, 0xb4,
+			},
+			WantError: ErrBadLength,
+		},
+		{
+			Name: "wrong fmt",
+			Data: []byte{
+				// v=2, p=0, FMT=1, PSFB, len=3
+				0x81, 0xce, 0x00, 0x03,
+				0x90, 0x2f, 0x9e, 0x2e,
+				0x4b, 0xc4, 0xfc, 0xb4,
+				0x00, 0x60, 0xab, 0xcd,
+			},
+			WantError: ErrWrongType,
+		},
+		{
+			Name:      "nil",
+			Data:      nil,
+			WantError: ErrPacketTooShort,
+		},
+	} {
+		var rpsi ReferencePictureSelectionIndication
+		err := rpsi.Unmarshal(test.Data)
+		assert.ErrorIsf(t, err, test.WantError, "Unmarshal %q", test.Name)
+		if err != nil {
+			continue
+		}
+
+		assert.Equalf(t, test.Want, rpsi, "Unmarshal %q", test.Name)
+
+		packets, err := Unmarshal(test.Data)
+		assert.NoErrorf(t, err, "Unmarshal %q", test.Name)
+		assert.Equalf(t, []Packet{&test.Want}, packets, "Unmarshal %q", test.Name)
+	}
+}
+
+func TestReferencePictureSelectionIndicationRoundTrip(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Packet    ReferencePictureSelectionIndication
+		WantError error
+	}{
+		{
+			Name: "aligned",
+			Packet: ReferencePictureSelectionIndication{
+				SenderSSRC:  1,
+				MediaSSRC:   2,
+				PayloadType: 100,
+				BitString:   []byte{0x01, 0x02},
+			},
+		},
+		{
+			Name: "padded",
+			Packet: ReferencePictureSelectionIndication{
+				SenderSSRC:  1,
+				MediaSSRC:   2,
+				PayloadType: 100,
+				BitString:   []byte{0x01, 0x02, 0x03, 0x04, 0x05},
+			},
+		},
+		{
+			Name: "empty bit string",
+			Packet: ReferencePictureSelectionIndication{
+				SenderSSRC:  1,
+				MediaSSRC:   2,
+				PayloadType: 100,
+			},
+		},
+		{
+			Name: "bad payload type",
+			Packet: ReferencePictureSelectionIndication{
+				PayloadType: 128,
+			},
+			WantError: errInvalidPayloadType,
+		},
+	} {
+		data, err := test.Packet.Marshal()
+		assert.ErrorIsf(t, err, test.WantError, "Marshal %q", test.Name)
+		if err != nil {
+			continue
+		}
+		assert.Zerof(t, len(data)%4, "Marshal %q", test.Name)
+
+		var decoded ReferencePictureSelectionIndication
+		assert.NoErrorf(t, decoded.Unmarshal(data), "Unmarshal %q", test.Name)
+		assert.Equalf(t, test.Packet, decoded, "%q rpsi round trip mismatch", test.Name)
+	}
+}
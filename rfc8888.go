@@ -100,6 +100,36 @@ type CCFeedbackReport struct {
 
 	// Basetime
 	ReportTimestamp uint32
+
+	packetPadding
+}
+
+// Validate checks that the CCFeedbackReport can be sent: that each report
+// block has at most 16384 metric blocks, whose ECN and arrival time offset
+// fit in their 2 and 13 bits.
+func (b *CCFeedbackReport) Validate() error {
+	for _, block := range b.ReportBlocks {
+		if len(block.MetricBlocks) > maxMetricBlocks {
+			return errTooManyReports
+		}
+		for _, metric := range block.MetricBlocks {
+			if metric.ECN > ECNCE || metric.ArrivalTimeOffset > 0x1FFF {
+				return errFieldOutOfRange
+			}
+		}
+	}
+
+	return checkPacketSize(b.MarshalSize())
+}
+
+// Type returns the packet type, TypeTransportSpecificFeedback.
+func (b *CCFeedbackReport) Type() PacketType {
+	return TypeTransportSpecificFeedback
+}
+
+// Format returns the feedback message type, FormatCCFB.
+func (b *CCFeedbackReport) Format() uint8 {
+	return FormatCCFB
 }
 
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
@@ -112,6 +142,77 @@ func (b CCFeedbackReport) DestinationSSRC() []uint32 {
 	return ssrcs
 }
 
+// TargetsSSRC reports whether the packet is feedback about the media source
+// ssrc, the media SSRC of one of its report blocks.
+func (b CCFeedbackReport) TargetsSSRC(ssrc uint32) bool {
+	for _, block := range b.ReportBlocks {
+		if block.MediaSSRC == ssrc {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SourceSSRC returns the SSRC of the sender.
+func (b CCFeedbackReport) SourceSSRC() []uint32 {
+	return []uint32{b.SenderSSRC}
+}
+
+// RewriteSSRC replaces every SSRC in the packet that equals from with to,
+// and returns the number of SSRCs replaced.
+func (b *CCFeedbackReport) RewriteSSRC(from, to uint32) int {
+	n := rewriteSSRC(&b.SenderSSRC, from, to)
+	for i := range b.ReportBlocks {
+		n += rewriteSSRC(&b.ReportBlocks[i].MediaSSRC, from, to)
+	}
+
+	return n
+}
+
+// Equal reports whether other is a CCFeedbackReport with the same contents.
+// Nil and empty slices are considered equal.
+func (b CCFeedbackReport) Equal(other Packet) bool {
+	o, ok := other.(*CCFeedbackReport)
+	if !ok || o == nil ||
+		b.SenderSSRC != o.SenderSSRC ||
+		b.ReportTimestamp != o.ReportTimestamp ||
+		len(b.ReportBlocks) != len(o.ReportBlocks) {
+		return false
+	}
+
+	for i := range b.ReportBlocks {
+		if b.ReportBlocks[i].MediaSSRC != o.ReportBlocks[i].MediaSSRC ||
+			b.ReportBlocks[i].BeginSequence != o.ReportBlocks[i].BeginSequence ||
+			!slicesEqual(b.ReportBlocks[i].MetricBlocks, o.ReportBlocks[i].MetricBlocks) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Clone returns a deep copy of the packet that shares no memory with b.
+func (b CCFeedbackReport) Clone() Packet {
+	blocks := cloneSlice(b.ReportBlocks)
+	for i := range blocks {
+		blocks[i].MetricBlocks = cloneSlice(b.ReportBlocks[i].MetricBlocks)
+	}
+	b.ReportBlocks = blocks
+
+	return &b
+}
+
+// MarshalJSON encodes the packet as a JSON object tagged with its type.
+func (b CCFeedbackReport) MarshalJSON() ([]byte, error) {
+	return marshalJSON(b)
+}
+
+// UnmarshalJSON decodes a JSON object produced by MarshalJSON.
+func (b *CCFeedbackReport) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, b)
+}
+
 // Len returns the length of the report in bytes.
 func (b *CCFeedbackReport) Len() int {
 	return b.MarshalSize()
@@ -124,43 +225,67 @@ func (b *CCFeedbackReport) MarshalSize() int {
 		n += block.len()
 	}
 
-	return reportBlockOffset + n + reportTimestampLength
+	return reportBlockOffset + n + reportTimestampLength + int(b.padding)
 }
 
 // Header returns the Header associated with this packet.
 func (b *CCFeedbackReport) Header() Header {
-	return Header{
-		Padding: false,
+	h := Header{
+		Padding: b.padding != 0,
 		Count:   FormatCCFB,
 		Type:    TypeTransportSpecificFeedback,
-		Length:  uint16(b.MarshalSize()/4 - 1), //nolint:gosec // G115
 	}
+	_ = h.SetLengthFromBytes(b.MarshalSize())
+
+	return h
 }
 
 // Marshal encodes the Congestion Control Feedback Report in binary.
 func (b CCFeedbackReport) Marshal() ([]byte, error) {
-	header := b.Header()
-	headerBuf, err := header.Marshal()
-	if err != nil {
+	buf := make([]byte, b.MarshalSize())
+	if _, err := b.MarshalTo(buf); err != nil {
 		return nil, err
 	}
-	length := 4 * (header.Length + 1)
-	buf := make([]byte, length)
-	copy(buf[:headerLength], headerBuf)
+
+	return buf, nil
+}
+
+// MarshalAppend appends the packet in binary to dst and returns the
+// extended slice.
+func (b CCFeedbackReport) MarshalAppend(dst []byte) ([]byte, error) {
+	return marshalAppend(dst, b.MarshalSize(), b.MarshalTo)
+}
+
+// MarshalTo encodes the Congestion Control Feedback Report in binary into buf
+// and returns the number of bytes written.
+func (b CCFeedbackReport) MarshalTo(buf []byte) (int, error) {
+	size := b.MarshalSize()
+	if len(buf) < size {
+		return 0, ErrPacketTooShort
+	}
+
+	header := b.Header()
+	if err := header.SetLengthFromBytes(size); err != nil {
+		return 0, err
+	}
+	if _, err := header.MarshalTo(buf); err != nil {
+		return 0, err
+	}
 	binary.BigEndian.PutUint32(buf[headerLength:], b.SenderSSRC)
 	offset := reportBlockOffset
 	for _, block := range b.ReportBlocks {
-		b, err := block.marshal()
+		n, err := block.marshalTo(buf[offset:])
 		if err != nil {
-			return nil, err
+			return 0, err
 		}
-		copy(buf[offset:], b)
-		offset += block.len()
+		offset += n
 	}
 
 	binary.BigEndian.PutUint32(buf[offset:], b.ReportTimestamp)
 
-	return buf, nil
+	writePadding(buf[:size], b.padding)
+
+	return size, nil
 }
 
 func (b CCFeedbackReport) String() string {
@@ -178,17 +303,29 @@ func (b CCFeedbackReport) String() string {
 
 // Unmarshal decodes the Congestion Control Feedback Report from binary.
 func (b *CCFeedbackReport) Unmarshal(rawPacket []byte) error {
+	rawPacket, padding, err := removePadding(rawPacket)
+	if err != nil {
+		return err
+	}
+
 	if len(rawPacket) < headerLength+ssrcLength+reportTimestampLength {
-		return errPacketTooShort
+		return ErrPacketTooShort
 	}
 
 	var h Header
 	if err := h.Unmarshal(rawPacket); err != nil {
 		return err
 	}
-	if h.Type != TypeTransportSpecificFeedback {
-		return errWrongType
+	if h.Type != TypeTransportSpecificFeedback || h.Count != FormatCCFB {
+		return ErrWrongType
+	}
+
+	// The report timestamp ends the packet, where the length field says.
+	end := (int(h.Length) + 1) * 4
+	if end < headerLength+ssrcLength+reportTimestampLength || len(rawPacket) < end {
+		return ErrPacketTooShort
 	}
+	rawPacket = rawPacket[:end]
 
 	b.SenderSSRC = binary.BigEndian.Uint32(rawPacket[headerLength:])
 
@@ -199,13 +336,16 @@ func (b *CCFeedbackReport) Unmarshal(rawPacket []byte) error {
 	b.ReportBlocks = []CCFeedbackReportBlock{}
 	for offset < reportTimestampOffset {
 		var block CCFeedbackReportBlock
-		if err := block.unmarshal(rawPacket[offset:]); err != nil {
+		// Report blocks must not run into the report timestamp
+		if err := block.unmarshal(rawPacket[offset:reportTimestampOffset]); err != nil {
 			return err
 		}
 		b.ReportBlocks = append(b.ReportBlocks, block)
 		offset += block.len()
 	}
 
+	b.padding = padding
+
 	return nil
 }
 
@@ -257,11 +397,26 @@ func (b CCFeedbackReportBlock) String() string {
 
 // marshal encodes the Congestion Control Feedback Report Block in binary.
 func (b CCFeedbackReportBlock) marshal() ([]byte, error) {
+	buf := make([]byte, b.len())
+	if _, err := b.marshalTo(buf); err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+// marshalTo encodes the Congestion Control Feedback Report Block into buf
+// and returns the number of bytes written.
+func (b CCFeedbackReportBlock) marshalTo(buf []byte) (int, error) {
 	if len(b.MetricBlocks) > maxMetricBlocks {
-		return nil, errTooManyReports
+		return 0, errTooManyReports
+	}
+
+	size := b.len()
+	if len(buf) < size {
+		return 0, ErrPacketTooShort
 	}
 
-	buf := make([]byte, b.len())
 	binary.BigEndian.PutUint32(buf[ssrcOffset:], b.MediaSSRC)
 	binary.BigEndian.PutUint16(buf[beginSequenceOffset:], b.BeginSequence)
 
@@ -270,14 +425,17 @@ func (b CCFeedbackReportBlock) marshal() ([]byte, error) {
 	binary.BigEndian.PutUint16(buf[numReportsOffset:], length)
 
 	for i, block := range b.MetricBlocks {
-		b, err := block.marshal()
-		if err != nil {
-			return nil, err
+		if err := block.marshalTo(buf[reportsOffset+i*2:]); err != nil {
+			return 0, err
 		}
-		copy(buf[reportsOffset+i*2:], b)
 	}
 
-	return buf, nil
+	// pad to a 32-bit boundary if there is an odd number of metric blocks
+	for i := reportsOffset + 2*len(b.MetricBlocks); i < size; i++ {
+		buf[i] = 0
+	}
+
+	return size, nil
 }
 
 // Unmarshal decodes the Congestion Control Feedback Report Block from binary.
@@ -328,27 +486,39 @@ type CCFeedbackMetricBlock struct {
 
 // Marshal encodes the Congestion Control Feedback Metric Block in binary.
 func (b CCFeedbackMetricBlock) marshal() ([]byte, error) {
-	buf := make([]byte, 2)
+	buf := make([]byte, metricBlockLength)
+	if err := b.marshalTo(buf); err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+// marshalTo encodes the Congestion Control Feedback Metric Block into buf.
+func (b CCFeedbackMetricBlock) marshalTo(buf []byte) error {
+	if len(buf) < metricBlockLength {
+		return errMetricBlockLength
+	}
 	r := uint16(0)
 	if b.Received {
 		r = 1
 	}
 	dst, err := setNBitsOfUint16(0, 1, 0, r)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	dst, err = setNBitsOfUint16(dst, 2, 1, uint16(b.ECN))
 	if err != nil {
-		return nil, err
+		return err
 	}
 	dst, err = setNBitsOfUint16(dst, 13, 3, b.ArrivalTimeOffset)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	binary.BigEndian.PutUint16(buf, dst)
 
-	return buf, nil
+	return nil
 }
 
 // Unmarshal decodes the Congestion Control Feedback Metric Block from binary.
@@ -434,14 +434,28 @@ func TestCCFeedbackOverflow(t *testing.T) {
 	p := &CCFeedbackReport{}
 	err := p.Unmarshal(append([]byte{
 		// Header
-		0b10000000, // V = 2
+		0b10001011, // V = 2, FMT = 11
 		205,        // h.Type = TypeTransportSpecificFeedback
-		0, 0,       // h.Length (unused)
+		0x40, 0x03, // h.Length
 		// SSRC
 		0, 0, 0, 0,
 		// CCFeedbackReportBlock
 		0, 0, 0, 0, 0, 0,
 		0x7F, 0xFB, // numReportsField
-	}, bytes.Repeat([]byte{0, 0}, 0x7FFF)...))
+	}, bytes.Repeat([]byte{0, 0}, 0x8000)...))
 	assert.ErrorIs(t, err, errReportBlockLength)
 }
+
+func TestCCFeedbackReportBlockOverrunsTimestamp(t *testing.T) {
+	p := &CCFeedbackReport{}
+	err := p.Unmarshal([]byte{
+		0x8B, 0xCD, 0x00, 0x04, // V=2, P=0, FMT=11, PT=205, Length=4
+		0x00, 0x00, 0x00, 0x01, // Sender SSRC=1
+
+		0x00, 0x00, 0x00, 0x01, // SSRC=1
+		0x00, 0x02, 0x00, 0x02, // begin_seq, num_reports=2
+
+		0x9F, 0xFD, 0x9F, 0xFC, // Report Timestamp
+	})
+	assert.ErrorIs(t, err, errIncorrectNumReports)
+}
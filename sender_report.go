@@ -4,8 +4,10 @@
 package rtcp
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
+	"time"
 )
 
 // A SenderReport (SR) packet provides reception quality feedback for an RTP stream.
@@ -38,8 +40,11 @@ type SenderReport struct {
 	// single synchronization source.
 	Reports []ReceptionReport
 	// ProfileExtensions contains additional, payload-specific information that needs to
-	// be reported regularly about the sender.
+	// be reported regularly about the sender. It follows the report blocks and
+	// is padded with zeros to a multiple of 4 bytes when marshaled.
 	ProfileExtensions []byte
+
+	packetPadding
 }
 
 const (
@@ -58,6 +63,22 @@ const (
 
 // Marshal encodes the SenderReport in binary.
 func (r SenderReport) Marshal() ([]byte, error) {
+	rawPacket := make([]byte, r.MarshalSize())
+	if _, err := r.MarshalTo(rawPacket); err != nil {
+		return nil, err
+	}
+
+	return rawPacket, nil
+}
+
+// MarshalAppend appends the packet in binary to dst and returns the
+// extended slice.
+func (r SenderReport) MarshalAppend(dst []byte) ([]byte, error) {
+	return marshalAppend(dst, r.MarshalSize(), r.MarshalTo)
+}
+
+// MarshalTo encodes the SenderReport in binary into buf and returns the number of bytes written.
+func (r SenderReport) MarshalTo(buf []byte) (int, error) {
 	/*
 	 *         0                   1                   2                   3
 	 *         0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
@@ -96,8 +117,24 @@ func (r SenderReport) Marshal() ([]byte, error) {
 	 *        +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
 	 */
 
-	rawPacket := make([]byte, r.MarshalSize())
-	packetBody := rawPacket[headerLength:]
+	size := r.MarshalSize()
+	if len(buf) < size {
+		return 0, ErrPacketTooShort
+	}
+
+	if len(r.Reports) > countMax {
+		return 0, errTooManyReports
+	}
+
+	header := r.Header()
+	if err := header.SetLengthFromBytes(size); err != nil {
+		return 0, err
+	}
+	if _, err := header.MarshalTo(buf); err != nil {
+		return 0, err
+	}
+
+	packetBody := buf[headerLength:size]
 
 	binary.BigEndian.PutUint32(packetBody[srSSRCOffset:], r.SSRC)
 	binary.BigEndian.PutUint64(packetBody[srNTPOffset:], r.NTPTime)
@@ -107,31 +144,39 @@ func (r SenderReport) Marshal() ([]byte, error) {
 
 	offset := srHeaderLength
 	for _, rp := range r.Reports {
-		data, err := rp.Marshal()
+		n, err := rp.marshalTo(packetBody[offset:])
 		if err != nil {
-			return nil, err
+			return 0, err
 		}
-		copy(packetBody[offset:], data)
-		offset += receptionReportLength
+		offset += n
 	}
 
-	if len(r.Reports) > countMax {
-		return nil, errTooManyReports
+	offset += copy(packetBody[offset:], r.ProfileExtensions)
+
+	// pad the profile extensions to a 32-bit boundary
+	for ; offset < len(packetBody); offset++ {
+		packetBody[offset] = 0
 	}
 
-	copy(packetBody[offset:], r.ProfileExtensions)
+	writePadding(buf[:size], r.padding)
 
-	hData, err := r.Header().Marshal()
-	if err != nil {
-		return nil, err
+	return size, nil
+}
+
+// Unmarshal decodes the SenderReport from binary. The profile extensions are
+// copied so the caller may reuse rawPacket once Unmarshal returns.
+func (r *SenderReport) Unmarshal(rawPacket []byte) error {
+	if err := r.unmarshalNoCopy(rawPacket); err != nil {
+		return err
 	}
-	copy(rawPacket, hData)
+	r.ProfileExtensions = cloneSlice(r.ProfileExtensions)
 
-	return rawPacket, nil
+	return nil
 }
 
-// Unmarshal decodes the SenderReport from binary.
-func (r *SenderReport) Unmarshal(rawPacket []byte) error {
+// unmarshalNoCopy implements Unmarshal, leaving r.ProfileExtensions pointing
+// into rawPacket.
+func (r *SenderReport) unmarshalNoCopy(rawPacket []byte) error {
 	/*
 	 *         0                   1                   2                   3
 	 *         0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
@@ -170,8 +215,13 @@ func (r *SenderReport) Unmarshal(rawPacket []byte) error {
 	 *        +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
 	 */
 
+	rawPacket, padding, err := removePadding(rawPacket)
+	if err != nil {
+		return err
+	}
+
 	if len(rawPacket) < (headerLength + srHeaderLength) {
-		return errPacketTooShort
+		return ErrPacketTooShort
 	}
 
 	var header Header
@@ -180,10 +230,17 @@ func (r *SenderReport) Unmarshal(rawPacket []byte) error {
 	}
 
 	if header.Type != TypeSenderReport {
-		return errWrongType
+		return ErrWrongType
+	}
+
+	// The profile extensions run to the end of the packet as given by its
+	// length, not of the buffer.
+	end := (int(header.Length) + 1) * 4
+	if end < headerLength+srHeaderLength || len(rawPacket) < end {
+		return ErrPacketTooShort
 	}
 
-	packetBody := rawPacket[headerLength:]
+	packetBody := rawPacket[headerLength:end]
 
 	r.SSRC = binary.BigEndian.Uint32(packetBody[srSSRCOffset:])
 	r.NTPTime = binary.BigEndian.Uint64(packetBody[srNTPOffset:])
@@ -191,14 +248,17 @@ func (r *SenderReport) Unmarshal(rawPacket []byte) error {
 	r.PacketCount = binary.BigEndian.Uint32(packetBody[srPacketCountOffset:])
 	r.OctetCount = binary.BigEndian.Uint32(packetBody[srOctetCountOffset:])
 
+	if err := checkReportCount(header.Count, len(packetBody)-srReportOffset); err != nil {
+		return err
+	}
+
+	r.Reports = nil
+	r.ProfileExtensions = nil
+
 	offset := srReportOffset
 	for i := 0; i < int(header.Count); i++ {
-		rrEnd := offset + receptionReportLength
-		if rrEnd > len(packetBody) {
-			return errPacketTooShort
-		}
 		rrBody := packetBody[offset : offset+receptionReportLength]
-		offset = rrEnd
+		offset += receptionReportLength
 
 		var rr ReceptionReport
 		if err := rr.Unmarshal(rrBody); err != nil {
@@ -211,13 +271,195 @@ func (r *SenderReport) Unmarshal(rawPacket []byte) error {
 		r.ProfileExtensions = packetBody[offset:]
 	}
 
-	if uint8(len(r.Reports)) != header.Count { //nolint:gosec // G115
-		return errInvalidHeader
-	}
+	r.padding = padding
 
 	return nil
 }
 
+// SendRates returns the bitrate in bits per second and the packet rate in
+// packets per second at which the source of r sent RTP data since prev, an
+// earlier report from the same source. elapsed is the time between the two
+// reports, such as the difference of their arrival times; if it is zero the
+// difference of their NTP timestamps is used instead. The packet and octet
+// counters may have wrapped around once in between.
+//
+// ok is false if the reports are from different sources or elapsed is not
+// positive.
+func (r SenderReport) SendRates(prev SenderReport, elapsed time.Duration) (bitrate, packetRate float64, ok bool) {
+	if r.SSRC != prev.SSRC {
+		return 0, 0, false
+	}
+
+	var seconds float64
+	if elapsed != 0 {
+		seconds = elapsed.Seconds()
+	} else {
+		seconds = float64(int64(r.NTPTime-prev.NTPTime)) / (1 << 32) //nolint:gosec // G115
+	}
+	if seconds <= 0 {
+		return 0, 0, false
+	}
+
+	octets := r.OctetCount - prev.OctetCount
+	packets := r.PacketCount - prev.PacketCount
+
+	return float64(octets) * 8 / seconds, float64(packets) / seconds, true
+}
+
+// RTPTimeToNTP returns the 64-bit NTP timestamp at which the RTP timestamp
+// rtpTime of the source of r was sampled, estimated from the NTPTime and
+// RTPTime pair of r and clockRate, the RTP clock rate of the media in Hz, for
+// synchronizing streams as RFC 3550 Section 6.4.1 describes. rtpTime may be
+// before or after the RTPTime of r and its 32-bit timestamp may have wrapped
+// around in between, as long as they are less than 2^31 ticks apart.
+//
+// ok is false if clockRate is zero.
+func (r SenderReport) RTPTimeToNTP(rtpTime, clockRate uint32) (ntpTime uint64, ok bool) {
+	if clockRate == 0 {
+		return 0, false
+	}
+
+	ticks := int64(int32(rtpTime - r.RTPTime)) //nolint:gosec // G115, signed distance across wraparound
+	offset := (ticks << 32) / int64(clockRate)
+
+	return r.NTPTime + uint64(offset), true //nolint:gosec // G115, two's complement
+}
+
+// RTPTimeToTime is like RTPTimeToNTP, but returns the wall-clock time.
+func (r SenderReport) RTPTimeToTime(rtpTime, clockRate uint32) (time.Time, bool) {
+	ntpTime, ok := r.RTPTimeToNTP(rtpTime, clockRate)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return fromNTPTime(ntpTime), true
+}
+
+// ToReceiverReport returns a ReceiverReport with the SSRC and reception report
+// blocks of r, for forwarding its reports without the sender info, or once
+// the source has stopped sending. Profile extensions, which describe the
+// sender, are dropped. The report blocks are copied, so r can be modified
+// afterwards.
+func (r *SenderReport) ToReceiverReport() *ReceiverReport {
+	return &ReceiverReport{
+		SSRC:    r.SSRC,
+		Reports: cloneSlice(r.Reports),
+	}
+}
+
+// Split returns r as packets that can be marshaled, for r holding more than
+// the 31 reception report blocks a SenderReport can carry: a SenderReport
+// with the sender info, profile extensions and first 31 report blocks of r,
+// followed by ReceiverReports from the same SSRC carrying the rest, as RFC
+// 3550 Section 6.4.2 describes. The packets share no memory with r.
+func (r *SenderReport) Split() []Packet {
+	n := len(r.Reports)
+	if n > countMax {
+		n = countMax
+	}
+
+	first := *r
+	first.Reports = cloneSlice(r.Reports[:n])
+	first.ProfileExtensions = cloneSlice(r.ProfileExtensions)
+
+	packets := []Packet{&first}
+	for _, rr := range splitReports(r.SSRC, r.Reports[n:]) {
+		packets = append(packets, rr)
+	}
+
+	return packets
+}
+
+// A SenderReportBuilder assembles a SenderReport field by field, e.g.
+//
+//	sr, err := rtcp.NewSenderReportBuilder(ssrc).
+//		WithNTPTime(now).
+//		WithRTPTime(rtpTime).
+//		WithCounts(packets, octets).
+//		AddReport(report).
+//		Build()
+type SenderReportBuilder struct {
+	report SenderReport
+}
+
+// NewSenderReportBuilder returns a SenderReportBuilder for a SenderReport
+// sent by ssrc.
+func NewSenderReportBuilder(ssrc uint32) *SenderReportBuilder {
+	return &SenderReportBuilder{report: SenderReport{SSRC: ssrc}}
+}
+
+// WithNTPTime sets the wallclock time the report is sent at, converted to an
+// NTP timestamp.
+func (b *SenderReportBuilder) WithNTPTime(t time.Time) *SenderReportBuilder {
+	b.report.NTPTime = toNTPTime(t)
+
+	return b
+}
+
+// WithRTPTime sets the RTP timestamp corresponding to the NTP time.
+func (b *SenderReportBuilder) WithRTPTime(rtpTime uint32) *SenderReportBuilder {
+	b.report.RTPTime = rtpTime
+
+	return b
+}
+
+// WithCounts sets the number of RTP packets and payload octets sent so far.
+func (b *SenderReportBuilder) WithCounts(packets, octets uint32) *SenderReportBuilder {
+	b.report.PacketCount = packets
+	b.report.OctetCount = octets
+
+	return b
+}
+
+// AddReport appends a reception report block.
+func (b *SenderReportBuilder) AddReport(report ReceptionReport) *SenderReportBuilder {
+	b.report.Reports = append(b.report.Reports, report)
+
+	return b
+}
+
+// Build returns the SenderReport. It fails if the report could not be
+// marshaled, because it has too many report blocks or one of them is
+// invalid. The builder may be reused; later changes do not affect the
+// reports already built.
+func (b *SenderReportBuilder) Build() (*SenderReport, error) {
+	if len(b.report.Reports) > countMax {
+		return nil, errTooManyReports
+	}
+
+	var buf [receptionReportLength]byte
+	for _, report := range b.report.Reports {
+		if _, err := report.marshalTo(buf[:]); err != nil {
+			return nil, err
+		}
+	}
+
+	report := b.report
+	report.Reports = cloneSlice(b.report.Reports)
+
+	return &report, nil
+}
+
+// Validate checks that the SenderReport can be sent: that it carries at most
+// 31 report blocks, whose cumulative numbers of packets lost fit in 24 bits.
+func (r *SenderReport) Validate() error {
+	if len(r.Reports) > countMax {
+		return errTooManyReports
+	}
+	for _, report := range r.Reports {
+		if err := report.validate(); err != nil {
+			return err
+		}
+	}
+
+	return checkPacketSize(r.MarshalSize())
+}
+
+// Type returns the packet type, TypeSenderReport.
+func (r *SenderReport) Type() PacketType {
+	return TypeSenderReport
+}
+
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
 func (r *SenderReport) DestinationSSRC() []uint32 {
 	out := make([]uint32, len(r.Reports)+1)
@@ -229,6 +471,58 @@ func (r *SenderReport) DestinationSSRC() []uint32 {
 	return out
 }
 
+// SourceSSRC returns the SSRC of the sender.
+func (r *SenderReport) SourceSSRC() []uint32 {
+	return []uint32{r.SSRC}
+}
+
+// RewriteSSRC replaces every SSRC in the packet that equals from with to,
+// and returns the number of SSRCs replaced.
+func (r *SenderReport) RewriteSSRC(from, to uint32) int {
+	n := rewriteSSRC(&r.SSRC, from, to)
+	for i := range r.Reports {
+		n += rewriteSSRC(&r.Reports[i].SSRC, from, to)
+	}
+
+	return n
+}
+
+// Equal reports whether other is a SenderReport with the same contents.
+// Nil and empty slices are considered equal.
+func (r *SenderReport) Equal(other Packet) bool {
+	o, ok := other.(*SenderReport)
+	if !ok || o == nil {
+		return false
+	}
+
+	return r.SSRC == o.SSRC &&
+		r.NTPTime == o.NTPTime &&
+		r.RTPTime == o.RTPTime &&
+		r.PacketCount == o.PacketCount &&
+		r.OctetCount == o.OctetCount &&
+		slicesEqual(r.Reports, o.Reports) &&
+		bytes.Equal(r.ProfileExtensions, o.ProfileExtensions)
+}
+
+// Clone returns a deep copy of the packet that shares no memory with r.
+func (r *SenderReport) Clone() Packet {
+	c := *r
+	c.Reports = cloneSlice(r.Reports)
+	c.ProfileExtensions = cloneSlice(r.ProfileExtensions)
+
+	return &c
+}
+
+// MarshalJSON encodes the packet as a JSON object tagged with its type.
+func (r SenderReport) MarshalJSON() ([]byte, error) {
+	return marshalJSON(r)
+}
+
+// UnmarshalJSON decodes a JSON object produced by MarshalJSON.
+func (r *SenderReport) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, r)
+}
+
 // MarshalSize returns the size of the packet once marshaled.
 func (r *SenderReport) MarshalSize() int {
 	repsLength := 0
@@ -236,16 +530,21 @@ func (r *SenderReport) MarshalSize() int {
 		repsLength += rep.len()
 	}
 
-	return headerLength + srHeaderLength + repsLength + len(r.ProfileExtensions)
+	peLength := len(r.ProfileExtensions) + getPadding(len(r.ProfileExtensions))
+
+	return headerLength + srHeaderLength + repsLength + peLength + int(r.padding)
 }
 
 // Header returns the Header associated with this packet.
 func (r *SenderReport) Header() Header {
-	return Header{
-		Count:  uint8(len(r.Reports)), //nolint:gosec // G115
-		Type:   TypeSenderReport,
-		Length: uint16((r.MarshalSize() / 4) - 1), //nolint:gosec // G115
+	h := Header{
+		Padding: r.padding != 0,
+		Count:   uint8(len(r.Reports)), //nolint:gosec // G115
+		Type:    TypeSenderReport,
 	}
+	_ = h.SetLengthFromBytes(r.MarshalSize())
+
+	return h
 }
 
 func (r SenderReport) String() string {
@@ -5,6 +5,7 @@ package rtcp
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -21,13 +22,13 @@ func TestSenderReportUnmarshal(t *testing.T) {
 		{
 			Name:      "nil",
 			Data:      nil,
-			WantError: errPacketTooShort,
+			WantError: ErrPacketTooShort,
 		},
 		{
 			Name: "valid",
 			Data: []byte{
-				// v=2, p=0, count=1, SR, len=7
-				0x81, 0xc8, 0x0, 0x7,
+				// v=2, p=0, count=1, SR, len=12
+				0x81, 0xc8, 0x0, 0xc,
 				// ssrc=0x902f9e2e
 				0x90, 0x2f, 0x9e, 0x2e,
 				// ntp=0xda8bd1fcdddda05a
@@ -98,7 +99,7 @@ func TestSenderReportUnmarshal(t *testing.T) {
 				// delay=150137
 				0x0, 0x2, 0x4a, 0x79,
 			},
-			WantError: errWrongType,
+			WantError: ErrWrongType,
 		},
 		{
 			Name: "bad count in header",
@@ -129,13 +130,13 @@ func TestSenderReportUnmarshal(t *testing.T) {
 				// delay=150137
 				0x0, 0x2, 0x4a, 0x79,
 			},
-			WantError: errPacketTooShort,
+			WantError: ErrPacketTooShort,
 		},
 		{
 			Name: "with extension", // issue #447
 			Data: []byte{
-				// v=2, p=0, count=0, SR, len=6
-				0x80, 0xc8, 0x0, 0x6,
+				// v=2, p=0, count=0, SR, len=13
+				0x80, 0xc8, 0x0, 0xd,
 				// ssrc=0x2b7ec0c5
 				0x2b, 0x7e, 0xc0, 0xc5,
 				// ntp=0xe020a2a952a53fc0
@@ -282,3 +283,305 @@ func TestSenderReportRoundTrip(t *testing.T) {
 		assert.Equalf(t, test.Report, decoded, "%q sr round trip", test.Name)
 	}
 }
+
+func TestSenderReportTruncatedReports(t *testing.T) {
+	reports := make([]ReceptionReport, 31)
+	for i := range reports {
+		reports[i] = ReceptionReport{SSRC: uint32(i), Jitter: 273}
+	}
+	full, err := SenderReport{SSRC: 0x902f9e2e, NTPTime: 0xda8bd1fcdddda05a, Reports: reports}.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(0x9f), full[0], "count=31")
+
+	for _, reportsPresent := range []int{0, 2, 30} {
+		data := append([]byte(nil), full[:headerLength+srReportOffset+reportsPresent*receptionReportLength]...)
+		// fix up the length so only the count is wrong
+		data[3] = uint8(len(data)/4 - 1)
+
+		var sr SenderReport
+		err := sr.Unmarshal(data)
+		assert.ErrorIsf(t, err, ErrPacketTooShort, "%d of 31 reports", reportsPresent)
+		assert.ErrorIsf(t, err, ErrInvalidHeader, "%d of 31 reports", reportsPresent)
+	}
+
+	var sr SenderReport
+	assert.NoError(t, sr.Unmarshal(full))
+	assert.Equal(t, reports, sr.Reports)
+}
+
+func TestSenderReportProfileExtensions(t *testing.T) {
+	sr := SenderReport{
+		SSRC:              0x902f9e2e,
+		NTPTime:           0xda8bd1fcdddda05a,
+		RTPTime:           0xaaf4edd5,
+		Reports:           []ReceptionReport{{SSRC: 0xbc5e9a40, LastSequenceNumber: 0x46e1}},
+		ProfileExtensions: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+	}
+
+	data, err := sr.Marshal()
+	assert.NoError(t, err)
+	assert.Len(t, data, headerLength+srHeaderLength+receptionReportLength+8)
+	assert.Equal(t, []byte{0x81, 0xc8, 0x00, 0x0e}, data[:headerLength])
+
+	// Data after the packet's length is not part of the extension.
+	var decoded SenderReport
+	assert.NoError(t, decoded.Unmarshal(append(data, 0x81, 0xca, 0x00, 0x00)))
+	assert.Equal(t, sr.Reports, decoded.Reports)
+	assert.Equal(t, sr.ProfileExtensions, decoded.ProfileExtensions)
+
+	assert.ErrorIs(t, decoded.Unmarshal(data[:len(data)-4]), ErrPacketTooShort)
+}
+
+func TestSenderReportSendRates(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	prev := SenderReport{
+		SSRC:        0x902f9e2e,
+		NTPTime:     toNTPTime(start),
+		PacketCount: 100,
+		OctetCount:  100000,
+	}
+
+	for _, test := range []struct {
+		Name           string
+		Prev           *SenderReport
+		Report         SenderReport
+		Elapsed        time.Duration
+		WantBitrate    float64
+		WantPacketRate float64
+		WantOK         bool
+	}{
+		{
+			Name: "ntp timestamps",
+			Report: SenderReport{
+				SSRC:        0x902f9e2e,
+				NTPTime:     toNTPTime(start.Add(2 * time.Second)),
+				PacketCount: 300,
+				OctetCount:  350000,
+			},
+			WantBitrate:    1000000,
+			WantPacketRate: 100,
+			WantOK:         true,
+		},
+		{
+			Name: "elapsed",
+			Report: SenderReport{
+				SSRC:        0x902f9e2e,
+				NTPTime:     toNTPTime(start.Add(time.Hour)),
+				PacketCount: 150,
+				OctetCount:  162500,
+			},
+			Elapsed:        500 * time.Millisecond,
+			WantBitrate:    1000000,
+			WantPacketRate: 100,
+			WantOK:         true,
+		},
+		{
+			Name: "wrapped counters",
+			Prev: &SenderReport{
+				SSRC:        0x902f9e2e,
+				NTPTime:     toNTPTime(start),
+				PacketCount: 0xffffffff - 9,
+				OctetCount:  0xffffffff - 12499,
+			},
+			Report: SenderReport{
+				SSRC:        0x902f9e2e,
+				NTPTime:     toNTPTime(start.Add(time.Second)),
+				PacketCount: 40,
+				OctetCount:  50000,
+			},
+			WantBitrate:    500000,
+			WantPacketRate: 50,
+			WantOK:         true,
+		},
+		{
+			Name:   "other source",
+			Report: SenderReport{SSRC: 1, NTPTime: toNTPTime(start.Add(time.Second))},
+		},
+		{
+			Name:   "same time",
+			Report: prev,
+		},
+		{
+			Name:   "reordered",
+			Report: SenderReport{SSRC: 0x902f9e2e, NTPTime: toNTPTime(start.Add(-time.Second))},
+		},
+	} {
+		from := prev
+		if test.Prev != nil {
+			from = *test.Prev
+		}
+		bitrate, packetRate, ok := test.Report.SendRates(from, test.Elapsed)
+		assert.Equalf(t, test.WantOK, ok, "SendRates %q", test.Name)
+		assert.InDeltaf(t, test.WantBitrate, bitrate, 1, "SendRates %q", test.Name)
+		assert.InDeltaf(t, test.WantPacketRate, packetRate, 0.01, "SendRates %q", test.Name)
+	}
+}
+
+func TestSenderReportRTPTimeToNTP(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	for _, test := range []struct {
+		Name      string
+		RTPTime   uint32
+		Target    uint32
+		ClockRate uint32
+		WantTime  time.Time
+		WantOK    bool
+	}{
+		{"same", 1000, 1000, 90000, start, true},
+		{"after", 1000, 1000 + 90000, 90000, start.Add(time.Second), true},
+		{"before", 10000, 10000 - 4000, 8000, start.Add(-500 * time.Millisecond), true},
+		{"wrapped forward", 0xFFFFFF00, 48000*2 - 0x100, 48000, start.Add(2 * time.Second), true},
+		{"wrapped backward", 0x00000100, 0xFFFFFFFF - 90000*3 + 0x101, 90000, start.Add(-3 * time.Second), true},
+		{"no clock rate", 1000, 2000, 0, time.Time{}, false},
+	} {
+		sr := SenderReport{NTPTime: toNTPTime(start), RTPTime: test.RTPTime}
+		got, ok := sr.RTPTimeToTime(test.Target, test.ClockRate)
+		assert.Equalf(t, test.WantOK, ok, "RTPTimeToTime %q", test.Name)
+		if test.WantOK {
+			assert.WithinDurationf(t, test.WantTime, got, time.Microsecond, "RTPTimeToTime %q", test.Name)
+		}
+
+		ntpTime, ok := sr.RTPTimeToNTP(test.Target, test.ClockRate)
+		assert.Equalf(t, test.WantOK, ok, "RTPTimeToNTP %q", test.Name)
+		if test.WantOK {
+			assert.WithinDurationf(t, test.WantTime, fromNTPTime(ntpTime), time.Microsecond, "RTPTimeToNTP %q", test.Name)
+		}
+	}
+}
+
+func TestSenderReportToReceiverReport(t *testing.T) {
+	sr := &SenderReport{
+		SSRC:              0x902f9e2e,
+		NTPTime:           0xda8bd1fcdddda05a,
+		RTPTime:           0xaaf4edd5,
+		PacketCount:       1,
+		OctetCount:        2,
+		Reports:           []ReceptionReport{{SSRC: 0xbc5e9a40, Jitter: 273}},
+		ProfileExtensions: []byte{1, 2, 3, 4},
+	}
+
+	rr := sr.ToReceiverReport()
+	assert.Equal(t, &ReceiverReport{
+		SSRC:    0x902f9e2e,
+		Reports: []ReceptionReport{{SSRC: 0xbc5e9a40, Jitter: 273}},
+	}, rr)
+
+	data, err := rr.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{
+		// v=2, p=0, count=1, RR, len=7
+		0x81, 0xc9, 0x0, 0x7,
+		// ssrc=0x902f9e2e
+		0x90, 0x2f, 0x9e, 0x2e,
+		// ssrc=0xbc5e9a40
+		0xbc, 0x5e, 0x9a, 0x40,
+		// fracLost=0, totalLost=0
+		0x0, 0x0, 0x0, 0x0,
+		// lastSeq=0
+		0x0, 0x0, 0x0, 0x0,
+		// jitter=273
+		0x0, 0x0, 0x1, 0x11,
+		// lsr=0
+		0x0, 0x0, 0x0, 0x0,
+		// delay=0
+		0x0, 0x0, 0x0, 0x0,
+	}, data)
+
+	// The report blocks are not shared.
+	rr.Reports[0].Jitter = 0
+	assert.Equal(t, uint32(273), sr.Reports[0].Jitter)
+
+	assert.Equal(t, &ReceiverReport{SSRC: 1}, (&SenderReport{SSRC: 1}).ToReceiverReport())
+}
+
+func TestSenderReportBuilder(t *testing.T) {
+	sent := time.Unix(1700000000, 500000000)
+	builder := NewSenderReportBuilder(0x902f9e2e).
+		WithNTPTime(sent).
+		WithRTPTime(0xaaf4edd5).
+		WithCounts(1, 2).
+		AddReport(ReceptionReport{SSRC: 0xbc5e9a40, Jitter: 273})
+
+	sr, err := builder.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, &SenderReport{
+		SSRC:        0x902f9e2e,
+		NTPTime:     toNTPTime(sent),
+		RTPTime:     0xaaf4edd5,
+		PacketCount: 1,
+		OctetCount:  2,
+		Reports:     []ReceptionReport{{SSRC: 0xbc5e9a40, Jitter: 273}},
+	}, sr)
+	assert.True(t, sent.Equal(fromNTPTime(sr.NTPTime)))
+
+	// Reports built earlier are not affected by later changes.
+	second, err := builder.AddReport(ReceptionReport{SSRC: 1}).Build()
+	assert.NoError(t, err)
+	assert.Len(t, sr.Reports, 1)
+	assert.Len(t, second.Reports, 2)
+
+	_, err = NewSenderReportBuilder(1).AddReport(ReceptionReport{TotalLost: 1 << 25}).Build()
+	assert.ErrorIs(t, err, errInvalidTotalLost)
+
+	builder = NewSenderReportBuilder(1)
+	for i := 0; i <= countMax; i++ {
+		builder.AddReport(ReceptionReport{SSRC: uint32(i)})
+	}
+	_, err = builder.Build()
+	assert.ErrorIs(t, err, errTooManyReports)
+}
+
+func TestSenderReportSplit(t *testing.T) {
+	sr := &SenderReport{
+		SSRC:              0x902f9e2e,
+		NTPTime:           0xda8bd1fcdddda05a,
+		ProfileExtensions: []byte{0x01, 0x02, 0x03, 0x04},
+	}
+	for i := 0; i < 70; i++ {
+		sr.Reports = append(sr.Reports, ReceptionReport{SSRC: uint32(i)})
+	}
+
+	_, err := sr.Marshal()
+	assert.ErrorIs(t, err, errTooManyReports)
+
+	packets := sr.Split()
+	if !assert.Len(t, packets, 3) {
+		return
+	}
+	first, ok := packets[0].(*SenderReport)
+	assert.True(t, ok)
+	assert.Equal(t, sr.NTPTime, first.NTPTime)
+	assert.Equal(t, sr.ProfileExtensions, first.ProfileExtensions)
+	assert.Equal(t, sr.Reports[:31], first.Reports)
+	assert.Equal(t, &ReceiverReport{SSRC: sr.SSRC, Reports: sr.Reports[31:62]}, packets[1])
+	assert.Equal(t, &ReceiverReport{SSRC: sr.SSRC, Reports: sr.Reports[62:]}, packets[2])
+
+	data, err := Marshal(packets)
+	assert.NoError(t, err)
+	decoded, err := Unmarshal(data)
+	assert.NoError(t, err)
+	assert.True(t, PacketsEqual(packets, decoded))
+
+	// The packets share no memory with the report they came from.
+	first.Reports[0].SSRC = 0xffffffff
+	first.ProfileExtensions[0] = 0xff
+	assert.Equal(t, uint32(0), sr.Reports[0].SSRC)
+	assert.Equal(t, byte(0x01), sr.ProfileExtensions[0])
+
+	assert.Equal(t, []Packet{&SenderReport{SSRC: 1}}, (&SenderReport{SSRC: 1}).Split())
+}
+
+func TestSenderReportLengthOverflow(t *testing.T) {
+	sr := SenderReport{SSRC: 1, ProfileExtensions: make([]byte, 4*(1<<16))}
+
+	_, err := sr.Marshal()
+	assert.ErrorIs(t, err, ErrLengthOverflow)
+
+	_, err = Marshal([]Packet{&sr})
+	assert.ErrorIs(t, err, ErrLengthOverflow)
+
+	sr.ProfileExtensions = sr.ProfileExtensions[:4*(1<<16)-srHeaderLength-headerLength]
+	data, err := sr.Marshal()
+	assert.NoError(t, err)
+	assert.Len(t, data, 4*(1<<16))
+}
@@ -23,6 +23,7 @@ type SLIEntry struct {
 }
 
 // The SliceLossIndication packet informs the encoder about the loss of a picture slice.
+// See RFC 4585 Section 6.3.2.
 type SliceLossIndication struct {
 	// SSRC of sender
 	SenderSSRC uint32
@@ -31,6 +32,8 @@ type SliceLossIndication struct {
 	MediaSSRC uint32
 
 	SLI []SLIEntry
+
+	packetPadding
 }
 
 const (
@@ -40,31 +43,64 @@ const (
 
 // Marshal encodes the SliceLossIndication in binary.
 func (p SliceLossIndication) Marshal() ([]byte, error) {
+	rawPacket := make([]byte, p.MarshalSize())
+	if _, err := p.MarshalTo(rawPacket); err != nil {
+		return nil, err
+	}
+
+	return rawPacket, nil
+}
+
+// MarshalAppend appends the packet in binary to dst and returns the
+// extended slice.
+func (p SliceLossIndication) MarshalAppend(dst []byte) ([]byte, error) {
+	return marshalAppend(dst, p.MarshalSize(), p.MarshalTo)
+}
+
+// MarshalTo encodes the SliceLossIndication in binary into buf and returns the number of bytes written.
+func (p SliceLossIndication) MarshalTo(buf []byte) (int, error) {
 	if len(p.SLI)+sliLength > math.MaxUint8 {
-		return nil, errTooManyReports
+		return 0, errTooManyReports
 	}
 
-	rawPacket := make([]byte, sliOffset+(len(p.SLI)*4))
-	binary.BigEndian.PutUint32(rawPacket, p.SenderSSRC)
-	binary.BigEndian.PutUint32(rawPacket[4:], p.MediaSSRC)
+	size := p.MarshalSize()
+	if len(buf) < size {
+		return 0, ErrPacketTooShort
+	}
+
+	header := p.Header()
+	if err := header.SetLengthFromBytes(size); err != nil {
+		return 0, err
+	}
+	if _, err := header.MarshalTo(buf); err != nil {
+		return 0, err
+	}
+
+	packetBody := buf[headerLength:]
+
+	binary.BigEndian.PutUint32(packetBody, p.SenderSSRC)
+	binary.BigEndian.PutUint32(packetBody[4:], p.MediaSSRC)
 	for i, s := range p.SLI {
 		sli := ((uint32(s.First) & 0x1FFF) << 19) |
 			((uint32(s.Number) & 0x1FFF) << 6) |
 			(uint32(s.Picture) & 0x3F)
-		binary.BigEndian.PutUint32(rawPacket[sliOffset+(4*i):], sli)
-	}
-	hData, err := p.Header().Marshal()
-	if err != nil {
-		return nil, err
+		binary.BigEndian.PutUint32(packetBody[sliOffset+(4*i):], sli)
 	}
 
-	return append(hData, rawPacket...), nil
+	writePadding(buf[:size], p.padding)
+
+	return size, nil
 }
 
 // Unmarshal decodes the SliceLossIndication from binary.
 func (p *SliceLossIndication) Unmarshal(rawPacket []byte) error {
+	rawPacket, padding, err := removePadding(rawPacket)
+	if err != nil {
+		return err
+	}
+
 	if len(rawPacket) < (headerLength + ssrcLength) {
-		return errPacketTooShort
+		return ErrPacketTooShort
 	}
 
 	var header Header
@@ -72,17 +108,23 @@ func (p *SliceLossIndication) Unmarshal(rawPacket []byte) error {
 		return err
 	}
 
-	if len(rawPacket) < (headerLength + int(4*header.Length)) {
-		return errPacketTooShort
+	if len(rawPacket) < (headerLength + 4*int(header.Length)) {
+		return ErrPacketTooShort
 	}
 
-	if header.Type != TypeTransportSpecificFeedback || header.Count != FormatSLI {
-		return errWrongType
+	if header.Type != TypePayloadSpecificFeedback || header.Count != FormatSLI {
+		return ErrWrongType
+	}
+
+	// The FCI field MUST contain at least one SLI entry
+	if int(header.Length)*4 <= sliOffset {
+		return ErrBadLength
 	}
 
 	p.SenderSSRC = binary.BigEndian.Uint32(rawPacket[headerLength:])
 	p.MediaSSRC = binary.BigEndian.Uint32(rawPacket[headerLength+ssrcLength:])
-	for i := headerLength + sliOffset; i < (headerLength + int(header.Length*4)); i += 4 {
+	p.SLI = nil
+	for i := headerLength + sliOffset; i < (headerLength + 4*int(header.Length)); i += 4 {
 		sli := binary.BigEndian.Uint32(rawPacket[i:])
 		p.SLI = append(p.SLI, SLIEntry{
 			First:   uint16((sli >> 19) & 0x1FFF), //nolint:gosec // G115
@@ -91,28 +133,114 @@ func (p *SliceLossIndication) Unmarshal(rawPacket []byte) error {
 		})
 	}
 
+	p.padding = padding
+
 	return nil
 }
 
 // MarshalSize returns the size of the packet once marshaled.
 func (p *SliceLossIndication) MarshalSize() int {
-	return headerLength + sliOffset + (len(p.SLI) * 4)
+	return headerLength + sliOffset + (len(p.SLI) * 4) + int(p.padding)
 }
 
 // Header returns the Header associated with this packet.
 func (p *SliceLossIndication) Header() Header {
-	return Header{
-		Count:  FormatSLI,
-		Type:   TypeTransportSpecificFeedback,
-		Length: uint16((p.MarshalSize() / 4) - 1), //nolint:gosec // G115
+	h := Header{
+		Padding: p.padding != 0,
+		Count:   FormatSLI,
+		Type:    TypePayloadSpecificFeedback,
 	}
+	_ = h.SetLengthFromBytes(p.MarshalSize())
+
+	return h
 }
 
 func (p *SliceLossIndication) String() string {
-	return fmt.Sprintf("SliceLossIndication %x %x %+v", p.SenderSSRC, p.MediaSSRC, p.SLI)
+	out := fmt.Sprintf("SliceLossIndication %x %x", p.SenderSSRC, p.MediaSSRC)
+	for _, e := range p.SLI {
+		out += fmt.Sprintf(" (first=%d number=%d picture=%d)", e.First, e.Number, e.Picture)
+	}
+
+	return out
+}
+
+// Validate checks that the SliceLossIndication can be sent: RFC 4585 Section
+// 6.3.2 requires at least one SLI entry, and the fields of each must fit in
+// their 13, 13 and 6 bits.
+func (p *SliceLossIndication) Validate() error {
+	if len(p.SLI) == 0 {
+		return errEmptyFCI
+	}
+	if len(p.SLI)+sliLength > math.MaxUint8 {
+		return errTooManyReports
+	}
+	for _, s := range p.SLI {
+		if s.First > 0x1FFF || s.Number > 0x1FFF || s.Picture > 0x3F {
+			return errFieldOutOfRange
+		}
+	}
+
+	return nil
+}
+
+// Type returns the packet type, TypePayloadSpecificFeedback.
+func (p *SliceLossIndication) Type() PacketType {
+	return TypePayloadSpecificFeedback
+}
+
+// Format returns the feedback message type, FormatSLI.
+func (p *SliceLossIndication) Format() uint8 {
+	return FormatSLI
 }
 
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
 func (p *SliceLossIndication) DestinationSSRC() []uint32 {
 	return []uint32{p.MediaSSRC}
 }
+
+// TargetsSSRC reports whether the packet is feedback about the media source
+// ssrc, its MediaSSRC.
+func (p *SliceLossIndication) TargetsSSRC(ssrc uint32) bool {
+	return p.MediaSSRC == ssrc
+}
+
+// SourceSSRC returns the SSRC of the sender.
+func (p *SliceLossIndication) SourceSSRC() []uint32 {
+	return []uint32{p.SenderSSRC}
+}
+
+// RewriteSSRC replaces every SSRC in the packet that equals from with to,
+// and returns the number of SSRCs replaced.
+func (p *SliceLossIndication) RewriteSSRC(from, to uint32) int {
+	return rewriteSSRC(&p.SenderSSRC, from, to) + rewriteSSRC(&p.MediaSSRC, from, to)
+}
+
+// Equal reports whether other is a SliceLossIndication with the same contents.
+func (p *SliceLossIndication) Equal(other Packet) bool {
+	o, ok := other.(*SliceLossIndication)
+	if !ok || o == nil {
+		return false
+	}
+
+	return p.SenderSSRC == o.SenderSSRC &&
+		p.MediaSSRC == o.MediaSSRC &&
+		slicesEqual(p.SLI, o.SLI)
+}
+
+// Clone returns a deep copy of the packet that shares no memory with p.
+func (p *SliceLossIndication) Clone() Packet {
+	c := *p
+	c.SLI = cloneSlice(p.SLI)
+
+	return &c
+}
+
+// MarshalJSON encodes the packet as a JSON object tagged with its type.
+func (p SliceLossIndication) MarshalJSON() ([]byte, error) {
+	return marshalJSON(p)
+}
+
+// UnmarshalJSON decodes a JSON object produced by MarshalJSON.
+func (p *SliceLossIndication) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, p)
+}
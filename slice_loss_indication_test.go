@@ -21,8 +21,8 @@ func TestSliceLossIndicationUnmarshal(t *testing.T) {
 		{
 			Name: "valid",
 			Data: []byte{
-				// SliceLossIndication
-				0x82, 0xcd, 0x0, 0x3,
+				// v=2, p=0, FMT=2, PSFB, len=3
+				0x82, 0xce, 0x0, 0x3,
 				// sender=0x902f9e2e
 				0x90, 0x2f, 0x9e, 0x2e,
 				// media=0x902f9e2e
@@ -44,7 +44,28 @@ func TestSliceLossIndicationUnmarshal(t *testing.T) {
 				0x90, 0x2f, 0x9e, 0x2e,
 				// report ends early
 			},
-			WantError: errPacketTooShort,
+			WantError: ErrPacketTooShort,
+		},
+		{
+			Name: "transport layer feedback",
+			Data: []byte{
+				// v=2, p=0, FMT=2, RTPFB, len=3
+				0x82, 0xcd, 0x0, 0x3,
+				0x90, 0x2f, 0x9e, 0x2e,
+				0x90, 0x2f, 0x9e, 0x2e,
+				0x55, 0x50, 0x00, 0x2C,
+			},
+			WantError: ErrWrongType,
+		},
+		{
+			Name: "no entries",
+			Data: []byte{
+				// v=2, p=0, FMT=2, PSFB, len=2
+				0x82, 0xce, 0x0, 0x2,
+				0x90, 0x2f, 0x9e, 0x2e,
+				0x90, 0x2f, 0x9e, 0x2e,
+			},
+			WantError: ErrBadLength,
 		},
 		{
 			Name: "wrong type",
@@ -66,12 +87,12 @@ func TestSliceLossIndicationUnmarshal(t *testing.T) {
 				// delay=150137
 				0x0, 0x2, 0x4a, 0x79,
 			},
-			WantError: errWrongType,
+			WantError: ErrWrongType,
 		},
 		{
 			Name:      "nil",
 			Data:      nil,
-			WantError: errPacketTooShort,
+			WantError: ErrPacketTooShort,
 		},
 	} {
 		var sli SliceLossIndication
@@ -111,3 +132,20 @@ func TestSliceLossIndicationRoundTrip(t *testing.T) {
 		assert.Equalf(t, test.Report, decoded, "%q sli round trip mismatch", test.Name)
 	}
 }
+
+func TestSliceLossIndicationDispatch(t *testing.T) {
+	packet := &SliceLossIndication{
+		SenderSSRC: 0x902f9e2e,
+		MediaSSRC:  0x902f9e2e,
+		SLI:        []SLIEntry{{First: 0xaaa, Number: 3, Picture: 0x2C}},
+	}
+
+	data, err := packet.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, byte(TypePayloadSpecificFeedback), data[1])
+
+	packets, err := Unmarshal(data)
+	assert.NoError(t, err)
+	assert.Equal(t, []Packet{packet}, packets)
+	assert.Equal(t, "SliceLossIndication 902f9e2e 902f9e2e (first=2730 number=3 picture=44)", packet.String())
+}
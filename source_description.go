@@ -6,6 +6,7 @@ package rtcp
 import (
 	"encoding/binary"
 	"fmt"
+	"sort"
 )
 
 // SDESType is the item type used in the RTCP SDES control packet.
@@ -23,7 +24,7 @@ const (
 	SDESLocation                 // geographic user location        RFC 3550, 6.5.5
 	SDESTool                     // name of application or tool     RFC 3550, 6.5.6
 	SDESNote                     // notice about the source         RFC 3550, 6.5.7
-	SDESPrivate                  // private extensions              RFC 3550, 6.5.8  (not implemented)
+	SDESPrivate                  // private extensions              RFC 3550, 6.5.8
 )
 
 //nolint:cyclop
@@ -60,11 +61,14 @@ const (
 	sdesOctetCountOffset = 1
 	sdesMaxOctetCount    = (1 << 8) - 1
 	sdesTextOffset       = 2
+	sdesPrefixLenLen     = 1
 )
 
 // A SourceDescription (SDES) packet describes the sources in an RTP stream.
 type SourceDescription struct {
 	Chunks []SourceDescriptionChunk
+
+	packetPadding
 }
 
 // NewCNAMESourceDescription creates a new SourceDescription with a single CNAME item.
@@ -80,8 +84,95 @@ func NewCNAMESourceDescription(ssrc uint32, cname string) *SourceDescription {
 	}
 }
 
+// NewEmailSourceDescription creates a new SourceDescription with a single EMAIL item.
+func NewEmailSourceDescription(ssrc uint32, email string) *SourceDescription {
+	return &SourceDescription{
+		Chunks: []SourceDescriptionChunk{{
+			Source: ssrc,
+			Items: []SourceDescriptionItem{{
+				Type: SDESEmail,
+				Text: email,
+			}},
+		}},
+	}
+}
+
+// NewSourceDescription creates a new SourceDescription with a chunk for each
+// source in chunks, holding that source's items. The chunks are ordered by
+// SSRC so that the encoding is deterministic.
+func NewSourceDescription(chunks map[uint32][]SourceDescriptionItem) *SourceDescription {
+	sources := make([]uint32, 0, len(chunks))
+	for ssrc := range chunks {
+		sources = append(sources, ssrc)
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i] < sources[j] })
+
+	s := &SourceDescription{
+		Chunks: make([]SourceDescriptionChunk, 0, len(sources)),
+	}
+	for _, ssrc := range sources {
+		s.Chunks = append(s.Chunks, SourceDescriptionChunk{
+			Source: ssrc,
+			Items:  chunks[ssrc],
+		})
+	}
+
+	return s
+}
+
+// FindCNAME returns the CNAME of the source ssrc from the first
+// SourceDescription in packets that has one, looking into CompoundPackets as
+// well. It returns false if no CNAME for ssrc is found.
+func FindCNAME(packets []Packet, ssrc uint32) (string, bool) {
+	for _, pkt := range packets {
+		switch pkt := pkt.(type) {
+		case *SourceDescription:
+			if cname, ok := pkt.cname(ssrc); ok {
+				return cname, true
+			}
+		case *CompoundPacket:
+			if cname, ok := FindCNAME(*pkt, ssrc); ok {
+				return cname, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+func (s *SourceDescription) cname(ssrc uint32) (string, bool) {
+	for _, chunk := range s.Chunks {
+		if chunk.Source != ssrc {
+			continue
+		}
+		for _, item := range chunk.Items {
+			if item.Type == SDESCNAME {
+				return item.Text, true
+			}
+		}
+	}
+
+	return "", false
+}
+
 // Marshal encodes the SourceDescription in binary.
 func (s SourceDescription) Marshal() ([]byte, error) {
+	rawPacket := make([]byte, s.MarshalSize())
+	if _, err := s.MarshalTo(rawPacket); err != nil {
+		return nil, err
+	}
+
+	return rawPacket, nil
+}
+
+// MarshalAppend appends the packet in binary to dst and returns the
+// extended slice.
+func (s SourceDescription) MarshalAppend(dst []byte) ([]byte, error) {
+	return marshalAppend(dst, s.MarshalSize(), s.MarshalTo)
+}
+
+// MarshalTo encodes the SourceDescription in binary into buf and returns the number of bytes written.
+func (s SourceDescription) MarshalTo(buf []byte) (int, error) {
 	/*
 	 *         0                   1                   2                   3
 	 *         0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
@@ -100,33 +191,42 @@ func (s SourceDescription) Marshal() ([]byte, error) {
 	 *        +=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+
 	 */
 
-	rawPacket := make([]byte, s.MarshalSize())
-	packetBody := rawPacket[headerLength:]
-
-	chunkOffset := 0
-	for _, c := range s.Chunks {
-		data, err := c.Marshal()
-		if err != nil {
-			return nil, err
-		}
-		copy(packetBody[chunkOffset:], data)
-		chunkOffset += len(data)
+	size := s.MarshalSize()
+	if len(buf) < size {
+		return 0, ErrPacketTooShort
 	}
 
 	if len(s.Chunks) > countMax {
-		return nil, errTooManyChunks
+		return 0, errTooManyChunks
 	}
 
-	hData, err := s.Header().Marshal()
-	if err != nil {
-		return nil, err
+	header := s.Header()
+	if err := header.SetLengthFromBytes(size); err != nil {
+		return 0, err
+	}
+	if _, err := header.MarshalTo(buf); err != nil {
+		return 0, err
 	}
-	copy(rawPacket, hData)
 
-	return rawPacket, nil
+	offset := headerLength
+	for _, c := range s.Chunks {
+		n, err := c.marshalTo(buf[offset:size])
+		if err != nil {
+			return 0, err
+		}
+		offset += n
+	}
+
+	writePadding(buf[:size], s.padding)
+
+	return size, nil
 }
 
-// Unmarshal decodes the SourceDescription from binary.
+// Unmarshal decodes the SourceDescription from binary. A source count of zero
+// with no chunks is valid; otherwise the number of chunks within the length
+// field must match the source count, or Unmarshal fails with an error
+// matching both ErrBadLength and ErrInvalidHeader. Bytes after the length
+// field's end are not parsed as chunks.
 func (s *SourceDescription) Unmarshal(rawPacket []byte) error {
 	/*
 	 *         0                   1                   2                   3
@@ -146,15 +246,25 @@ func (s *SourceDescription) Unmarshal(rawPacket []byte) error {
 	 *        +=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+
 	 */
 
+	rawPacket, padding, err := removePadding(rawPacket)
+	if err != nil {
+		return err
+	}
+
 	var header Header
 	if err := header.Unmarshal(rawPacket); err != nil {
 		return err
 	}
 
 	if header.Type != TypeSourceDescription {
-		return errWrongType
+		return ErrWrongType
+	}
+
+	if end := (int(header.Length) + 1) * 4; end < len(rawPacket) {
+		rawPacket = rawPacket[:end]
 	}
 
+	s.Chunks = nil
 	for i := headerLength; i < len(rawPacket); {
 		var chunk SourceDescriptionChunk
 		if err := chunk.Unmarshal(rawPacket[i:]); err != nil {
@@ -166,9 +276,12 @@ func (s *SourceDescription) Unmarshal(rawPacket []byte) error {
 	}
 
 	if len(s.Chunks) != int(header.Count) {
-		return errInvalidHeader
+		return fmt.Errorf("%w: source count %d, but %d chunks: %w",
+			ErrBadLength, header.Count, len(s.Chunks), ErrInvalidHeader)
 	}
 
+	s.padding = padding
+
 	return nil
 }
 
@@ -179,16 +292,19 @@ func (s *SourceDescription) MarshalSize() int {
 		chunksLength += c.len()
 	}
 
-	return headerLength + chunksLength
+	return headerLength + chunksLength + int(s.padding)
 }
 
 // Header returns the Header associated with this packet.
 func (s *SourceDescription) Header() Header {
-	return Header{
-		Count:  uint8(len(s.Chunks)), //nolint:gosec // G115
-		Type:   TypeSourceDescription,
-		Length: uint16((s.MarshalSize() / 4) - 1), //nolint:gosec // G115
+	h := Header{
+		Padding: s.padding != 0,
+		Count:   uint8(len(s.Chunks)), //nolint:gosec // G115
+		Type:    TypeSourceDescription,
 	}
+	_ = h.SetLengthFromBytes(s.MarshalSize())
+
+	return h
 }
 
 // A SourceDescriptionChunk contains items describing a single RTP source.
@@ -200,6 +316,16 @@ type SourceDescriptionChunk struct {
 
 // Marshal encodes the SourceDescriptionChunk in binary.
 func (s SourceDescriptionChunk) Marshal() ([]byte, error) {
+	rawPacket := make([]byte, s.len())
+	if _, err := s.marshalTo(rawPacket); err != nil {
+		return nil, err
+	}
+
+	return rawPacket, nil
+}
+
+// marshalTo encodes the SourceDescriptionChunk into buf and returns the number of bytes written.
+func (s SourceDescriptionChunk) marshalTo(buf []byte) (int, error) {
 	/*
 	 *  +=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+
 	 *  |                          SSRC/CSRC_1                          |
@@ -209,24 +335,30 @@ func (s SourceDescriptionChunk) Marshal() ([]byte, error) {
 	 *  +=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+=+
 	 */
 
-	rawPacket := make([]byte, sdesSourceLen)
-	binary.BigEndian.PutUint32(rawPacket, s.Source)
+	chunkLen := s.len()
+	if len(buf) < chunkLen {
+		return 0, ErrPacketTooShort
+	}
+
+	binary.BigEndian.PutUint32(buf, s.Source)
 
+	offset := sdesSourceLen
 	for _, it := range s.Items {
-		data, err := it.Marshal()
+		n, err := it.marshalTo(buf[offset:])
 		if err != nil {
-			return nil, err
+			return 0, err
 		}
-		rawPacket = append(rawPacket, data...) //nolint:makezero
+		offset += n
 	}
 
-	// The list of items in each chunk MUST be terminated by one or more null octets
-	rawPacket = append(rawPacket, uint8(SDESEnd)) //nolint:makezero
-
-	// additional null octets MUST be included if needed to pad until the next 32-bit boundary
-	rawPacket = append(rawPacket, make([]byte, getPadding(len(rawPacket)))...) //nolint:makezero
+	// The list of items in each chunk MUST be terminated by one or more null octets,
+	// and additional null octets MUST be included if needed to pad until the next
+	// 32-bit boundary
+	for ; offset < chunkLen; offset++ {
+		buf[offset] = uint8(SDESEnd)
+	}
 
-	return rawPacket, nil
+	return chunkLen, nil
 }
 
 // Unmarshal decodes the SourceDescriptionChunk from binary.
@@ -241,7 +373,7 @@ func (s *SourceDescriptionChunk) Unmarshal(rawPacket []byte) error {
 	 */
 
 	if len(rawPacket) < (sdesSourceLen + sdesTypeLen) {
-		return errPacketTooShort
+		return ErrPacketTooShort
 	}
 
 	s.Source = binary.BigEndian.Uint32(rawPacket)
@@ -259,7 +391,7 @@ func (s *SourceDescriptionChunk) Unmarshal(rawPacket []byte) error {
 		i += it.Len()
 	}
 
-	return errPacketTooShort
+	return ErrPacketTooShort
 }
 
 func (s SourceDescriptionChunk) len() int {
@@ -283,6 +415,18 @@ type SourceDescriptionItem struct {
 	Type SDESType
 	// Text is a unicode text blob associated with the item. Its meaning varies based on the item's Type.
 	Text string
+	// Prefix names the kind of private extension carried by an SDESPrivate item.
+	// It is ignored for all other item types.
+	Prefix string
+}
+
+// octetCount returns the number of octets following the item's length field.
+func (s SourceDescriptionItem) octetCount() int {
+	if s.Type == SDESPrivate {
+		return sdesPrefixLenLen + len(s.Prefix) + len(s.Text)
+	}
+
+	return len(s.Text)
 }
 
 // Len returns the length of the SourceDescriptionItem when encoded as binary.
@@ -294,11 +438,33 @@ func (s SourceDescriptionItem) Len() int {
 	 *  |    CNAME=1    |     length    | user and domain name        ...
 	 *  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
 	 */
-	return sdesTypeLen + sdesOctetCountLen + len([]byte(s.Text))
+	return sdesTypeLen + sdesOctetCountLen + s.octetCount()
 }
 
 // Marshal encodes the SourceDescriptionItem in binary.
 func (s SourceDescriptionItem) Marshal() ([]byte, error) {
+	rawPacket := make([]byte, s.Len())
+	if _, err := s.marshalTo(rawPacket); err != nil {
+		return nil, err
+	}
+
+	return rawPacket, nil
+}
+
+// validate checks that the item has a type and at most 255 octets of text.
+func (s SourceDescriptionItem) validate() error {
+	if s.Type == SDESEnd {
+		return errSDESMissingType
+	}
+	if s.octetCount() > sdesMaxOctetCount {
+		return errSDESTextTooLong
+	}
+
+	return nil
+}
+
+// marshalTo encodes the SourceDescriptionItem into buf and returns the number of bytes written.
+func (s SourceDescriptionItem) marshalTo(buf []byte) (int, error) {
 	/*
 	 *   0                   1                   2                   3
 	 *   0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
@@ -307,24 +473,38 @@ func (s SourceDescriptionItem) Marshal() ([]byte, error) {
 	 *  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
 	 */
 
-	if s.Type == SDESEnd {
-		return nil, errSDESMissingType
+	if err := s.validate(); err != nil {
+		return 0, err
 	}
+	octetCount := s.octetCount()
 
-	rawPacket := make([]byte, sdesTypeLen+sdesOctetCountLen)
+	if len(buf) < s.Len() {
+		return 0, ErrPacketTooShort
+	}
 
-	rawPacket[sdesTypeOffset] = uint8(s.Type)
+	buf[sdesTypeOffset] = uint8(s.Type)
+	buf[sdesOctetCountOffset] = uint8(octetCount)
 
-	txtBytes := []byte(s.Text)
-	octetCount := len(txtBytes)
-	if octetCount > sdesMaxOctetCount {
-		return nil, errSDESTextTooLong
+	/*
+	 * PRIV items start with the length and text of a prefix:
+	 *
+	 *   0                   1                   2                   3
+	 *   0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+	 *  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 *  |     PRIV=8    |     length    | prefix length |prefix string...
+	 *  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 *  ...             |                  value string               ...
+	 *  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+	 */
+	offset := sdesTextOffset
+	if s.Type == SDESPrivate {
+		buf[offset] = uint8(len(s.Prefix))
+		offset += sdesPrefixLenLen
+		offset += copy(buf[offset:], s.Prefix)
 	}
-	rawPacket[sdesOctetCountOffset] = uint8(octetCount)
-
-	rawPacket = append(rawPacket, txtBytes...) //nolint:makezero
+	copy(buf[offset:], s.Text)
 
-	return rawPacket, nil
+	return s.Len(), nil
 }
 
 // Unmarshal decodes the SourceDescriptionItem from binary.
@@ -338,22 +518,57 @@ func (s *SourceDescriptionItem) Unmarshal(rawPacket []byte) error {
 	 */
 
 	if len(rawPacket) < (sdesTypeLen + sdesOctetCountLen) {
-		return errPacketTooShort
+		return ErrPacketTooShort
 	}
 
 	s.Type = SDESType(rawPacket[sdesTypeOffset])
 
 	octetCount := int(rawPacket[sdesOctetCountOffset])
 	if sdesTextOffset+octetCount > len(rawPacket) {
-		return errPacketTooShort
+		return ErrPacketTooShort
 	}
 
 	txtBytes := rawPacket[sdesTextOffset : sdesTextOffset+octetCount]
+	s.Prefix = ""
+
+	if s.Type == SDESPrivate {
+		if len(txtBytes) < sdesPrefixLenLen {
+			return errSDESPrivateTooShort
+		}
+		prefixLen := int(txtBytes[0])
+		if sdesPrefixLenLen+prefixLen > len(txtBytes) {
+			return errSDESPrivateTooShort
+		}
+		s.Prefix = string(txtBytes[sdesPrefixLenLen : sdesPrefixLenLen+prefixLen])
+		txtBytes = txtBytes[sdesPrefixLenLen+prefixLen:]
+	}
 	s.Text = string(txtBytes)
 
 	return nil
 }
 
+// Validate checks that the SourceDescription can be sent: that it has at most
+// 31 chunks, and that their items have a type and at most 255 octets of text.
+func (s *SourceDescription) Validate() error {
+	if len(s.Chunks) > countMax {
+		return errTooManyChunks
+	}
+	for _, chunk := range s.Chunks {
+		for _, item := range chunk.Items {
+			if err := item.validate(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return checkPacketSize(s.MarshalSize())
+}
+
+// Type returns the packet type, TypeSourceDescription.
+func (s *SourceDescription) Type() PacketType {
+	return TypeSourceDescription
+}
+
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
 func (s *SourceDescription) DestinationSSRC() []uint32 {
 	out := make([]uint32, len(s.Chunks))
@@ -364,6 +579,62 @@ func (s *SourceDescription) DestinationSSRC() []uint32 {
 	return out
 }
 
+// SourceSSRC returns the sources described by the packet's chunks, as each
+// source describes itself.
+func (s *SourceDescription) SourceSSRC() []uint32 {
+	return s.DestinationSSRC()
+}
+
+// RewriteSSRC replaces every SSRC in the packet that equals from with to,
+// and returns the number of SSRCs replaced.
+func (s *SourceDescription) RewriteSSRC(from, to uint32) int {
+	n := 0
+	for i := range s.Chunks {
+		n += rewriteSSRC(&s.Chunks[i].Source, from, to)
+	}
+
+	return n
+}
+
+// Equal reports whether other is a SourceDescription with the same chunks
+// and items. Nil and empty slices are considered equal.
+func (s *SourceDescription) Equal(other Packet) bool {
+	o, ok := other.(*SourceDescription)
+	if !ok || o == nil || len(s.Chunks) != len(o.Chunks) {
+		return false
+	}
+
+	for i := range s.Chunks {
+		if s.Chunks[i].Source != o.Chunks[i].Source ||
+			!slicesEqual(s.Chunks[i].Items, o.Chunks[i].Items) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Clone returns a deep copy of the packet that shares no memory with s.
+func (s *SourceDescription) Clone() Packet {
+	c := *s
+	c.Chunks = cloneSlice(s.Chunks)
+	for i := range c.Chunks {
+		c.Chunks[i].Items = cloneSlice(s.Chunks[i].Items)
+	}
+
+	return &c
+}
+
+// MarshalJSON encodes the packet as a JSON object tagged with its type.
+func (s SourceDescription) MarshalJSON() ([]byte, error) {
+	return marshalJSON(s)
+}
+
+// UnmarshalJSON decodes a JSON object produced by MarshalJSON.
+func (s *SourceDescription) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, s)
+}
+
 func (s *SourceDescription) String() string {
 	out := "Source Description:\n"
 	for _, c := range s.Chunks {
@@ -21,7 +21,7 @@ func TestSourceDescriptionUnmarshal(t *testing.T) {
 		{
 			Name:      "nil",
 			Data:      nil,
-			WantError: errPacketTooShort,
+			WantError: ErrPacketTooShort,
 		},
 		{
 			Name: "no chunks",
@@ -41,7 +41,7 @@ func TestSourceDescriptionUnmarshal(t *testing.T) {
 				// ssrc=0x00000000
 				0x00, 0x00, 0x00, 0x00,
 			},
-			WantError: errPacketTooShort,
+			WantError: ErrPacketTooShort,
 		},
 		{
 			Name: "bad cname length",
@@ -53,7 +53,7 @@ func TestSourceDescriptionUnmarshal(t *testing.T) {
 				// CNAME, len = 1
 				0x01, 0x01,
 			},
-			WantError: errPacketTooShort,
+			WantError: ErrPacketTooShort,
 		},
 		{
 			Name: "short cname",
@@ -65,7 +65,7 @@ func TestSourceDescriptionUnmarshal(t *testing.T) {
 				// CNAME, Missing length
 				0x01,
 			},
-			WantError: errPacketTooShort,
+			WantError: ErrPacketTooShort,
 		},
 		{
 			Name: "no end",
@@ -78,7 +78,7 @@ func TestSourceDescriptionUnmarshal(t *testing.T) {
 				0x01, 0x02, 0x41,
 				// Missing END
 			},
-			WantError: errPacketTooShort,
+			WantError: ErrPacketTooShort,
 		},
 		{
 			Name: "bad octet count",
@@ -90,7 +90,7 @@ func TestSourceDescriptionUnmarshal(t *testing.T) {
 				// CNAME, len=1
 				0x01, 0x01,
 			},
-			WantError: errPacketTooShort,
+			WantError: ErrPacketTooShort,
 		},
 		{
 			Name: "zero item chunk",
@@ -119,7 +119,24 @@ func TestSourceDescriptionUnmarshal(t *testing.T) {
 				// END + padding
 				0x00, 0x00, 0x00, 0x00,
 			},
-			WantError: errWrongType,
+			WantError: ErrWrongType,
+		},
+		{
+			Name: "bytes after the length",
+			Data: []byte{
+				// v=2, p=0, count=1, SDES, len=2
+				0x81, 0xca, 0x00, 0x02,
+				// ssrc=0x01020304
+				0x01, 0x02, 0x03, 0x04,
+				// CNAME, len=1, content=a, END
+				0x01, 0x01, 0x61, 0x00,
+				// not part of the packet
+				0x05, 0x06, 0x07, 0x08,
+			},
+			Want: SourceDescription{Chunks: []SourceDescriptionChunk{{
+				Source: 0x01020304,
+				Items:  []SourceDescriptionItem{{Type: SDESCNAME, Text: "a"}},
+			}}},
 		},
 		{
 			Name: "bad count in header",
@@ -127,7 +144,7 @@ func TestSourceDescriptionUnmarshal(t *testing.T) {
 				// v=2, p=0, count=1, SDES, len=12
 				0x81, 0xca, 0x00, 0x0c,
 			},
-			WantError: errInvalidHeader,
+			WantError: ErrInvalidHeader,
 		},
 		{
 			Name: "empty string",
@@ -175,6 +192,38 @@ func TestSourceDescriptionUnmarshal(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "cname and email",
+			Data: []byte{
+				// v=2, p=0, count=1, SDES, len=4
+				0x81, 0xca, 0x00, 0x04,
+				// ssrc=0x10000000
+				0x10, 0x00, 0x00, 0x00,
+				// CNAME, len=1, content=A
+				0x01, 0x01, 0x41,
+				// EMAIL, len=5, content=a@b.c
+				0x03, 0x05, 0x61, 0x40, 0x62, 0x2e, 0x63,
+				// END + padding
+				0x00, 0x00,
+			},
+			Want: SourceDescription{
+				Chunks: []SourceDescriptionChunk{
+					{
+						Source: 0x10000000,
+						Items: []SourceDescriptionItem{
+							{
+								Type: SDESCNAME,
+								Text: "A",
+							},
+							{
+								Type: SDESEmail,
+								Text: "a@b.c",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			Name: "two chunks",
 			Data: []byte{
@@ -226,6 +275,186 @@ func TestSourceDescriptionUnmarshal(t *testing.T) {
 	}
 }
 
+func TestSourceDescriptionChunkCount(t *testing.T) {
+	for _, test := range []struct {
+		Name string
+		Data []byte
+	}{
+		{
+			Name: "count above chunks within length",
+			Data: []byte{
+				// v=2, p=0, count=2, SDES, len=2
+				0x82, 0xca, 0x00, 0x02,
+				// ssrc=0x01020304
+				0x01, 0x02, 0x03, 0x04,
+				// END + padding
+				0x00, 0x00, 0x00, 0x00,
+				// a second chunk after the end of the packet
+				0x05, 0x06, 0x07, 0x08,
+				0x00, 0x00, 0x00, 0x00,
+			},
+		},
+		{
+			Name: "count below chunks",
+			Data: []byte{
+				// v=2, p=0, count=1, SDES, len=4
+				0x81, 0xca, 0x00, 0x04,
+				// ssrc=0x01020304
+				0x01, 0x02, 0x03, 0x04,
+				// END + padding
+				0x00, 0x00, 0x00, 0x00,
+				// ssrc=0x05060708
+				0x05, 0x06, 0x07, 0x08,
+				// END + padding
+				0x00, 0x00, 0x00, 0x00,
+			},
+		},
+	} {
+		var sdes SourceDescription
+		err := sdes.Unmarshal(test.Data)
+		assert.ErrorIsf(t, err, ErrBadLength, "Unmarshal %q", test.Name)
+		assert.ErrorIsf(t, err, ErrInvalidHeader, "Unmarshal %q", test.Name)
+	}
+}
+
+func TestSourceDescriptionChunkEdgeCases(t *testing.T) {
+	padded := &SourceDescription{Chunks: []SourceDescriptionChunk{{
+		Source: 0x01020304,
+		Items:  []SourceDescriptionItem{{Type: SDESCNAME, Text: "a"}},
+	}}}
+	assert.NoError(t, padded.SetPadding(4))
+
+	for _, test := range []struct {
+		Name      string
+		Data      []byte
+		Want      []Packet
+		WantError error
+	}{
+		{
+			Name: "count zero",
+			Data: []byte{
+				// v=2, p=0, count=0, SDES, len=0
+				0x80, 0xca, 0x00, 0x00,
+			},
+			Want: []Packet{&SourceDescription{}},
+		},
+		{
+			Name: "count zero in compound",
+			Data: []byte{
+				// v=2, p=0, count=0, RR, len=1
+				0x80, 0xc9, 0x00, 0x01,
+				0x90, 0x2f, 0x9e, 0x2e,
+				// v=2, p=0, count=0, SDES, len=0
+				0x80, 0xca, 0x00, 0x00,
+			},
+			Want: []Packet{
+				&ReceiverReport{SSRC: 0x902f9e2e, ProfileExtensions: []byte{}},
+				&SourceDescription{},
+			},
+		},
+		{
+			Name: "count zero with a chunk",
+			Data: []byte{
+				// v=2, p=0, count=0, SDES, len=2
+				0x80, 0xca, 0x00, 0x02,
+				// ssrc=0x01020304
+				0x01, 0x02, 0x03, 0x04,
+				// END + padding
+				0x00, 0x00, 0x00, 0x00,
+			},
+			WantError: ErrInvalidHeader,
+		},
+		{
+			Name: "zero SSRC",
+			Data: []byte{
+				// v=2, p=0, count=1, SDES, len=2
+				0x81, 0xca, 0x00, 0x02,
+				// ssrc=0x00000000
+				0x00, 0x00, 0x00, 0x00,
+				// CNAME, len=1, content=a, END
+				0x01, 0x01, 0x61, 0x00,
+			},
+			Want: []Packet{&SourceDescription{Chunks: []SourceDescriptionChunk{{
+				Items: []SourceDescriptionItem{{Type: SDESCNAME, Text: "a"}},
+			}}}},
+		},
+		{
+			Name: "padding after last item",
+			Data: []byte{
+				// v=2, p=1, count=1, SDES, len=3
+				0xa1, 0xca, 0x00, 0x03,
+				// ssrc=0x01020304
+				0x01, 0x02, 0x03, 0x04,
+				// CNAME, len=1, content=a, END at an odd offset
+				0x01, 0x01, 0x61, 0x00,
+				// padding
+				0x00, 0x00, 0x00, 0x04,
+			},
+			Want: []Packet{padded},
+		},
+		{
+			Name: "end at an odd offset before another chunk",
+			Data: []byte{
+				// v=2, p=0, count=2, SDES, len=5
+				0x82, 0xca, 0x00, 0x05,
+				// ssrc=0x01020304
+				0x01, 0x02, 0x03, 0x04,
+				// CNAME, len=1, content=a, END
+				0x01, 0x01, 0x61, 0x00,
+				// ssrc=0x05060708
+				0x05, 0x06, 0x07, 0x08,
+				// CNAME, len=2, content=bc
+				0x01, 0x02, 0x62, 0x63,
+				// END + padding
+				0x00, 0x00, 0x00, 0x00,
+			},
+			Want: []Packet{&SourceDescription{Chunks: []SourceDescriptionChunk{
+				{Source: 0x01020304, Items: []SourceDescriptionItem{{Type: SDESCNAME, Text: "a"}}},
+				{Source: 0x05060708, Items: []SourceDescriptionItem{{Type: SDESCNAME, Text: "bc"}}},
+			}}},
+		},
+		{
+			Name: "count above chunks",
+			Data: []byte{
+				// v=2, p=0, count=2, SDES, len=2
+				0x82, 0xca, 0x00, 0x02,
+				// ssrc=0x01020304
+				0x01, 0x02, 0x03, 0x04,
+				// END + padding
+				0x00, 0x00, 0x00, 0x00,
+			},
+			WantError: ErrInvalidHeader,
+		},
+		{
+			Name: "count below chunks",
+			Data: []byte{
+				// v=2, p=0, count=1, SDES, len=4
+				0x81, 0xca, 0x00, 0x04,
+				// ssrc=0x01020304
+				0x01, 0x02, 0x03, 0x04,
+				// END + padding
+				0x00, 0x00, 0x00, 0x00,
+				// ssrc=0x05060708
+				0x05, 0x06, 0x07, 0x08,
+				// END + padding
+				0x00, 0x00, 0x00, 0x00,
+			},
+			WantError: ErrInvalidHeader,
+		},
+	} {
+		packets, err := Unmarshal(test.Data)
+		assert.ErrorIsf(t, err, test.WantError, "Unmarshal %q", test.Name)
+		assert.Equalf(t, test.Want, packets, "Unmarshal %q", test.Name)
+		if err != nil {
+			continue
+		}
+
+		data, err := Marshal(packets)
+		assert.NoErrorf(t, err, "Marshal %q", test.Name)
+		assert.Equalf(t, test.Data, data, "Marshal %q", test.Name)
+	}
+}
+
 func TestSourceDescriptionRoundTrip(t *testing.T) {
 	// a slice with enough SourceDescriptionChunks to overflow an 5-bit int
 	var tooManyChunks []SourceDescriptionChunk
@@ -304,6 +533,10 @@ func TestSourceDescriptionRoundTrip(t *testing.T) {
 				}},
 			},
 		},
+		{
+			Name: "email helper",
+			Desc: *NewEmailSourceDescription(1, "ops@example.com"),
+		},
 		{
 			Name: "empty text",
 			Desc: *NewCNAMESourceDescription(1, ""),
@@ -339,3 +572,197 @@ func TestSourceDescriptionRoundTrip(t *testing.T) {
 		assert.Equalf(t, test.Desc, decoded, "%s sdes round trip mismatch", test.Name)
 	}
 }
+
+func TestSourceDescriptionPrivateItem(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Data      []byte
+		Want      SourceDescriptionItem
+		WantError error
+	}{
+		{
+			Name: "prefix and value",
+			Data: []byte{
+				// PRIV, len=6, prefix len=2, prefix=ab, value=xyz
+				0x08, 0x06, 0x02, 0x61, 0x62, 0x78, 0x79, 0x7a,
+			},
+			Want: SourceDescriptionItem{Type: SDESPrivate, Prefix: "ab", Text: "xyz"},
+		},
+		{
+			Name: "empty prefix",
+			Data: []byte{
+				// PRIV, len=2, prefix len=0, value=x
+				0x08, 0x02, 0x00, 0x78,
+			},
+			Want: SourceDescriptionItem{Type: SDESPrivate, Text: "x"},
+		},
+		{
+			Name: "missing prefix length",
+			Data: []byte{
+				// PRIV, len=0
+				0x08, 0x00,
+			},
+			WantError: errSDESPrivateTooShort,
+		},
+		{
+			Name: "prefix too long",
+			Data: []byte{
+				// PRIV, len=2, prefix len=3
+				0x08, 0x02, 0x03, 0x61,
+			},
+			WantError: errSDESPrivateTooShort,
+		},
+		{
+			Name: "unknown type",
+			Data: []byte{
+				// type=42, len=2, value=hi
+				0x2a, 0x02, 0x68, 0x69,
+			},
+			Want: SourceDescriptionItem{Type: 42, Text: "hi"},
+		},
+	} {
+		var item SourceDescriptionItem
+		err := item.Unmarshal(test.Data)
+		assert.ErrorIsf(t, err, test.WantError, "Unmarshal %q", test.Name)
+		if err != nil {
+			continue
+		}
+		assert.Equalf(t, test.Want, item, "Unmarshal %q", test.Name)
+
+		data, err := item.Marshal()
+		assert.NoErrorf(t, err, "Marshal %q", test.Name)
+		assert.Equalf(t, test.Data, data, "Marshal %q", test.Name)
+	}
+}
+
+func TestSourceDescriptionAllItemTypes(t *testing.T) {
+	desc := SourceDescription{
+		Chunks: []SourceDescriptionChunk{{
+			Source: 1,
+			Items: []SourceDescriptionItem{
+				{Type: SDESCNAME, Text: "cname"},
+				{Type: SDESName, Text: "name"},
+				{Type: SDESEmail, Text: "email"},
+				{Type: SDESPhone, Text: "phone"},
+				{Type: SDESLocation, Text: "loc"},
+				{Type: SDESTool, Text: "tool"},
+				{Type: SDESNote, Text: "note"},
+				{Type: SDESPrivate, Prefix: "prefix", Text: "value"},
+			},
+		}},
+	}
+
+	data, err := desc.Marshal()
+	assert.NoError(t, err)
+
+	var decoded SourceDescription
+	assert.NoError(t, decoded.Unmarshal(data))
+	assert.Equal(t, desc, decoded)
+
+	// The prefix counts towards the 255 octet limit
+	tooLong := SourceDescriptionItem{Type: SDESPrivate, Prefix: "p", Text: string(make([]byte, 254))}
+	_, err = tooLong.Marshal()
+	assert.ErrorIs(t, err, errSDESTextTooLong)
+}
+
+func TestNewSourceDescription(t *testing.T) {
+	sdes := NewSourceDescription(map[uint32][]SourceDescriptionItem{
+		3: {{Type: SDESTool, Text: "pion"}},
+		1: {{Type: SDESCNAME, Text: "a"}},
+		2: {
+			{Type: SDESName, Text: "bob"},
+			{Type: SDESEmail, Text: "b@x"},
+		},
+	})
+
+	want := []byte{
+		// v=2, p=0, count=3, SDES, len=9
+		0x83, 0xca, 0x00, 0x09,
+		// ssrc=1
+		0x00, 0x00, 0x00, 0x01,
+		// CNAME, len=1, "a", end
+		0x01, 0x01, 0x61, 0x00,
+		// ssrc=2
+		0x00, 0x00, 0x00, 0x02,
+		// NAME, len=3, "bob"
+		0x02, 0x03, 0x62, 0x6f, 0x62,
+		// EMAIL, len=3, "b@x"
+		0x03, 0x03, 0x62, 0x40, 0x78,
+		// end + 1 octet of padding to the 32-bit boundary
+		0x00, 0x00,
+		// ssrc=3
+		0x00, 0x00, 0x00, 0x03,
+		// TOOL, len=4, "pion"
+		0x06, 0x04, 0x70, 0x69, 0x6f, 0x6e,
+		// end + 1 octet of padding to the 32-bit boundary
+		0x00, 0x00,
+	}
+
+	data, err := sdes.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, want, data)
+
+	var decoded SourceDescription
+	assert.NoError(t, decoded.Unmarshal(data))
+	assert.Equal(t, sdes, &decoded)
+	assert.Equal(t, []uint32{1, 2, 3}, decoded.DestinationSSRC())
+}
+
+func TestFindCNAME(t *testing.T) {
+	packets, err := Unmarshal(realPacket())
+	assert.NoError(t, err)
+
+	cname, ok := FindCNAME(packets, 0x902f9e2e)
+	assert.True(t, ok)
+	assert.Equal(t, "{9c00eb92-1afb-9d49-a47d-91f64eee69f5}", cname)
+
+	_, ok = FindCNAME(packets, 0xbc5e9a40)
+	assert.False(t, ok, "sources without an SDES chunk have no CNAME")
+
+	packets = []Packet{
+		&PictureLossIndication{},
+		NewSourceDescription(map[uint32][]SourceDescriptionItem{
+			1: {{Type: SDESName, Text: "no cname"}},
+			2: {{Type: SDESNote, Text: "note"}, {Type: SDESCNAME, Text: "two"}},
+		}),
+		&CompoundPacket{
+			&ReceiverReport{SSRC: 3},
+			NewCNAMESourceDescription(3, "three"),
+		},
+	}
+	for _, test := range []struct {
+		SSRC      uint32
+		WantCNAME string
+		WantOK    bool
+	}{
+		{1, "", false},
+		{2, "two", true},
+		{3, "three", true},
+		{4, "", false},
+	} {
+		cname, ok := FindCNAME(packets, test.SSRC)
+		assert.Equalf(t, test.WantOK, ok, "FindCNAME %x", test.SSRC)
+		assert.Equalf(t, test.WantCNAME, cname, "FindCNAME %x", test.SSRC)
+	}
+
+	_, ok = FindCNAME(nil, 1)
+	assert.False(t, ok)
+}
+
+func TestSourceDescriptionClonePadded(t *testing.T) {
+	sdes := &SourceDescription{Chunks: []SourceDescriptionChunk{{
+		Source: 0x01020304,
+		Items:  []SourceDescriptionItem{{Type: SDESCNAME, Text: "a"}},
+	}}}
+	assert.NoError(t, sdes.SetPadding(4))
+	want, err := sdes.Marshal()
+	assert.NoError(t, err)
+
+	clone := sdes.Clone()
+	got, err := clone.Marshal()
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	clone.(*SourceDescription).Chunks[0].Items[0].Text = "b" //nolint:forcetypeassert
+	assert.Equal(t, "a", sdes.Chunks[0].Items[0].Text)
+}
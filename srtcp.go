@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	srtcpIndexLength   = 4
+	srtcpEncryptedFlag = 1 << 31
+	srtcpIndexMask     = srtcpEncryptedFlag - 1
+)
+
+// SRTCPHeader holds the parts of an SRTCP packet that are sent in the clear,
+// see RFC 3711 Section 3.4.
+type SRTCPHeader struct {
+	// The header of the first RTCP packet, which is never encrypted.
+	Header Header
+
+	// SSRC of the sender of the first RTCP packet.
+	SSRC uint32
+
+	// Whether the E flag is set, meaning the packets after the first SSRC
+	// are encrypted.
+	Encrypted bool
+
+	// The 31-bit SRTCP index.
+	Index uint32
+
+	// The offset of the E flag and SRTCP index trailer. The packets, in the
+	// clear or encrypted, end there.
+	IndexOffset int
+}
+
+// UnmarshalSRTCPHeader reads the header and sender SSRC of the first packet
+// of an SRTCP packet in data, and its E flag and SRTCP index, without
+// touching the possibly encrypted packets in between. It lets SRTCP be routed
+// by SSRC before it is decrypted.
+//
+// The trailer that follows the index depends on the crypto profile, so its
+// length must be given: authTagLength is the length of the authentication
+// tag after the index, such as 10 for AES_CM_128_HMAC_SHA1_80 or 0 for the
+// AEAD profiles of RFC 7714, whose tag precedes the index, and mkiLength is
+// the length of the MKI, usually 0.
+func UnmarshalSRTCPHeader(data []byte, authTagLength, mkiLength int) (SRTCPHeader, error) {
+	var h SRTCPHeader
+	if err := h.Header.Unmarshal(data); err != nil {
+		return h, err
+	}
+
+	h.IndexOffset = len(data) - authTagLength - mkiLength - srtcpIndexLength
+	if authTagLength < 0 || mkiLength < 0 || h.IndexOffset < headerLength+ssrcLength {
+		return SRTCPHeader{}, fmt.Errorf("%w: %d bytes cannot hold an SRTCP packet", ErrPacketTooShort, len(data))
+	}
+
+	h.SSRC = binary.BigEndian.Uint32(data[headerLength:])
+
+	index := binary.BigEndian.Uint32(data[h.IndexOffset:])
+	h.Encrypted = index&srtcpEncryptedFlag != 0
+	h.Index = index & srtcpIndexMask
+
+	return h, nil
+}
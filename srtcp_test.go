@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalSRTCPHeader(t *testing.T) {
+	for _, test := range []struct {
+		Name          string
+		Data          []byte
+		AuthTagLength int
+		MKILength     int
+		Want          SRTCPHeader
+		WantError     error
+	}{
+		{
+			Name: "encrypted with auth tag",
+			Data: []byte{
+				// RR, SSRC 0x902f9e2e
+				0x81, 0xc9, 0x00, 0x07, 0x90, 0x2f, 0x9e, 0x2e,
+				// encrypted
+				0x3f, 0x81, 0x27, 0x4b, 0xc0, 0x58, 0x9a, 0x11,
+				// E=1, index 5
+				0x80, 0x00, 0x00, 0x05,
+				// auth tag
+				0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a,
+			},
+			AuthTagLength: 10,
+			Want: SRTCPHeader{
+				Header:      Header{Count: 1, Type: TypeReceiverReport, Length: 7},
+				SSRC:        0x902f9e2e,
+				Encrypted:   true,
+				Index:       5,
+				IndexOffset: 16,
+			},
+		},
+		{
+			Name: "unencrypted with MKI",
+			Data: []byte{
+				// PLI
+				0x81, 0xce, 0x00, 0x02, 0x90, 0x2f, 0x9e, 0x2e, 0x4b, 0xc4, 0xfc, 0xb4,
+				// E=0, largest index
+				0x7f, 0xff, 0xff, 0xff,
+				// auth tag
+				0x01, 0x02, 0x03, 0x04,
+				// MKI
+				0xaa, 0xbb,
+			},
+			AuthTagLength: 4,
+			MKILength:     2,
+			Want: SRTCPHeader{
+				Header:      Header{Count: FormatPLI, Type: TypePayloadSpecificFeedback, Length: 2},
+				SSRC:        0x902f9e2e,
+				Index:       0x7fffffff,
+				IndexOffset: 12,
+			},
+		},
+		{
+			Name: "AEAD",
+			Data: []byte{
+				0x80, 0xc8, 0x00, 0x06, 0x90, 0x2f, 0x9e, 0x2e,
+				// encrypted, with the auth tag
+				0x3f, 0x81, 0x27, 0x4b,
+				0x80, 0x00, 0x01, 0x00,
+			},
+			Want: SRTCPHeader{
+				Header:      Header{Type: TypeSenderReport, Length: 6},
+				SSRC:        0x902f9e2e,
+				Encrypted:   true,
+				Index:       0x100,
+				IndexOffset: 12,
+			},
+		},
+		{
+			Name:      "no trailer",
+			Data:      []byte{0x81, 0xc9, 0x00, 0x01, 0x90, 0x2f, 0x9e, 0x2e},
+			WantError: ErrPacketTooShort,
+		},
+		{
+			Name: "trailer overlaps SSRC",
+			Data: []byte{
+				0x81, 0xc9, 0x00, 0x01, 0x90, 0x2f, 0x9e, 0x2e,
+				0x80, 0x00, 0x00, 0x05, 0x01, 0x02,
+			},
+			AuthTagLength: 10,
+			WantError:     ErrPacketTooShort,
+		},
+		{
+			Name:      "empty",
+			Data:      []byte{},
+			WantError: ErrPacketTooShort,
+		},
+		{
+			Name:      "bad version",
+			Data:      []byte{0x00, 0xc9, 0x00, 0x01, 0x90, 0x2f, 0x9e, 0x2e, 0x80, 0x00, 0x00, 0x05},
+			WantError: ErrBadVersion,
+		},
+	} {
+		got, err := UnmarshalSRTCPHeader(test.Data, test.AuthTagLength, test.MKILength)
+		assert.ErrorIsf(t, err, test.WantError, "Unmarshal %q", test.Name)
+		if err != nil {
+			continue
+		}
+		assert.Equalf(t, test.Want, got, "Unmarshal %q", test.Name)
+	}
+}
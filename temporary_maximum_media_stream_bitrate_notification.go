@@ -0,0 +1,256 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// A TMMBNEntry is a single bounding set tuple carried by a
+// TemporaryMaximumMediaStreamBitrateNotification. It uses the same
+// MxTBR encoding as TMMBREntry.
+type TMMBNEntry struct {
+	SSRC     uint32
+	Exponent uint8
+	Mantissa uint32
+	Overhead uint16
+}
+
+// SetBitrate encodes bitrate, in bits per second, into the Exponent and
+// Mantissa fields. Bitrates that do not fit in the 17-bit mantissa are
+// rounded down.
+func (e *TMMBNEntry) SetBitrate(bitrate uint64) {
+	e.Exponent, e.Mantissa = encodeMxTBR(bitrate)
+}
+
+// Bitrate returns the maximum total media bit rate in bits per second.
+func (e TMMBNEntry) Bitrate() uint64 {
+	return decodeMxTBR(e.Exponent, e.Mantissa)
+}
+
+// The TemporaryMaximumMediaStreamBitrateNotification packet is sent in
+// response to a TMMBR and lists the current bounding set of limits.
+// See RFC 5104 Section 4.2.2.
+type TemporaryMaximumMediaStreamBitrateNotification struct {
+	SenderSSRC uint32
+	MediaSSRC  uint32
+
+	Entries []TMMBNEntry
+
+	packetPadding
+}
+
+var _ Packet = (*TemporaryMaximumMediaStreamBitrateNotification)(nil)
+
+// Marshal encodes the TemporaryMaximumMediaStreamBitrateNotification.
+func (p TemporaryMaximumMediaStreamBitrateNotification) Marshal() ([]byte, error) {
+	rawPacket := make([]byte, p.MarshalSize())
+	if _, err := p.MarshalTo(rawPacket); err != nil {
+		return nil, err
+	}
+
+	return rawPacket, nil
+}
+
+// MarshalAppend appends the packet in binary to dst and returns the
+// extended slice.
+func (p TemporaryMaximumMediaStreamBitrateNotification) MarshalAppend(dst []byte) ([]byte, error) {
+	return marshalAppend(dst, p.MarshalSize(), p.MarshalTo)
+}
+
+// MarshalTo encodes the TemporaryMaximumMediaStreamBitrateNotification into
+// buf and returns the number of bytes written.
+func (p TemporaryMaximumMediaStreamBitrateNotification) MarshalTo(buf []byte) (int, error) {
+	size := p.MarshalSize()
+	if len(buf) < size {
+		return 0, ErrPacketTooShort
+	}
+
+	header := p.Header()
+	if err := header.SetLengthFromBytes(size); err != nil {
+		return 0, err
+	}
+	if _, err := header.MarshalTo(buf); err != nil {
+		return 0, err
+	}
+
+	packetBody := buf[headerLength:size]
+
+	binary.BigEndian.PutUint32(packetBody, p.SenderSSRC)
+	binary.BigEndian.PutUint32(packetBody[4:], p.MediaSSRC)
+	for i, e := range p.Entries {
+		entry := packetBody[tmmbOffset+tmmbEntryLength*i:]
+		if err := marshalTMMBEntry(entry, e.SSRC, e.Exponent, e.Mantissa, e.Overhead); err != nil {
+			return 0, err
+		}
+	}
+
+	writePadding(buf[:size], p.padding)
+
+	return size, nil
+}
+
+// Unmarshal decodes the TemporaryMaximumMediaStreamBitrateNotification.
+func (p *TemporaryMaximumMediaStreamBitrateNotification) Unmarshal(rawPacket []byte) error {
+	rawPacket, padding, err := removePadding(rawPacket)
+	if err != nil {
+		return err
+	}
+
+	if len(rawPacket) < (headerLength + ssrcLength) {
+		return ErrPacketTooShort
+	}
+
+	var header Header
+	if err := header.Unmarshal(rawPacket); err != nil {
+		return err
+	}
+
+	length := 4 * int(header.Length)
+	if len(rawPacket) < headerLength+length {
+		return ErrPacketTooShort
+	}
+
+	if header.Type != TypeTransportSpecificFeedback || header.Count != FormatTMMBN {
+		return ErrWrongType
+	}
+
+	// An empty bounding set is allowed, so there may be no entries
+	if length < tmmbOffset || (length-tmmbOffset)%tmmbEntryLength != 0 {
+		return ErrBadLength
+	}
+
+	p.SenderSSRC = binary.BigEndian.Uint32(rawPacket[headerLength:])
+	p.MediaSSRC = binary.BigEndian.Uint32(rawPacket[headerLength+ssrcLength:])
+	p.Entries = nil
+	for i := headerLength + tmmbOffset; i < headerLength+length; i += tmmbEntryLength {
+		var e TMMBNEntry
+		e.SSRC, e.Exponent, e.Mantissa, e.Overhead = unmarshalTMMBEntry(rawPacket[i:])
+		p.Entries = append(p.Entries, e)
+	}
+
+	p.padding = padding
+
+	return nil
+}
+
+// Header returns the Header associated with this packet.
+func (p *TemporaryMaximumMediaStreamBitrateNotification) Header() Header {
+	h := Header{
+		Padding: p.padding != 0,
+		Count:   FormatTMMBN,
+		Type:    TypeTransportSpecificFeedback,
+	}
+	_ = h.SetLengthFromBytes(p.MarshalSize())
+
+	return h
+}
+
+// MarshalSize returns the size of the packet once marshaled.
+func (p *TemporaryMaximumMediaStreamBitrateNotification) MarshalSize() int {
+	return headerLength + tmmbOffset + len(p.Entries)*tmmbEntryLength + int(p.padding)
+}
+
+func (p *TemporaryMaximumMediaStreamBitrateNotification) String() string {
+	out := fmt.Sprintf("TemporaryMaximumMediaStreamBitrateNotification %x %x",
+		p.SenderSSRC, p.MediaSSRC)
+	for _, e := range p.Entries {
+		out += fmt.Sprintf(" (%x %d %d)", e.SSRC, e.Bitrate(), e.Overhead)
+	}
+
+	return out
+}
+
+// Validate checks that the TemporaryMaximumMediaStreamBitrateNotification can
+// be sent: that the fields of its entries fit in the MxTBR encoding. Unlike a
+// request, a notification may have no entries.
+func (p *TemporaryMaximumMediaStreamBitrateNotification) Validate() error {
+	for _, e := range p.Entries {
+		if err := validateTMMBEntry(e.Exponent, e.Mantissa, e.Overhead); err != nil {
+			return err
+		}
+	}
+
+	return checkPacketSize(p.MarshalSize())
+}
+
+// Type returns the packet type, TypeTransportSpecificFeedback.
+func (p *TemporaryMaximumMediaStreamBitrateNotification) Type() PacketType {
+	return TypeTransportSpecificFeedback
+}
+
+// Format returns the feedback message type, FormatTMMBN.
+func (p *TemporaryMaximumMediaStreamBitrateNotification) Format() uint8 {
+	return FormatTMMBN
+}
+
+// DestinationSSRC returns an array of SSRC values that this packet refers to.
+func (p *TemporaryMaximumMediaStreamBitrateNotification) DestinationSSRC() []uint32 {
+	ssrcs := make([]uint32, 0, len(p.Entries))
+	for _, entry := range p.Entries {
+		ssrcs = append(ssrcs, entry.SSRC)
+	}
+
+	return ssrcs
+}
+
+// TargetsSSRC reports whether the packet is feedback about the media source
+// ssrc, the SSRC of one of its entries.
+func (p *TemporaryMaximumMediaStreamBitrateNotification) TargetsSSRC(ssrc uint32) bool {
+	for _, entry := range p.Entries {
+		if entry.SSRC == ssrc {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SourceSSRC returns the SSRC of the sender.
+func (p *TemporaryMaximumMediaStreamBitrateNotification) SourceSSRC() []uint32 {
+	return []uint32{p.SenderSSRC}
+}
+
+// RewriteSSRC replaces every SSRC in the packet that equals from with to,
+// and returns the number of SSRCs replaced.
+func (p *TemporaryMaximumMediaStreamBitrateNotification) RewriteSSRC(from, to uint32) int {
+	n := rewriteSSRC(&p.SenderSSRC, from, to) + rewriteSSRC(&p.MediaSSRC, from, to)
+	for i := range p.Entries {
+		n += rewriteSSRC(&p.Entries[i].SSRC, from, to)
+	}
+
+	return n
+}
+
+// Equal reports whether other is a TemporaryMaximumMediaStreamBitrateNotification
+// with the same contents.
+func (p *TemporaryMaximumMediaStreamBitrateNotification) Equal(other Packet) bool {
+	o, ok := other.(*TemporaryMaximumMediaStreamBitrateNotification)
+	if !ok || o == nil {
+		return false
+	}
+
+	return p.SenderSSRC == o.SenderSSRC &&
+		p.MediaSSRC == o.MediaSSRC &&
+		slicesEqual(p.Entries, o.Entries)
+}
+
+// Clone returns a deep copy of the packet that shares no memory with p.
+func (p *TemporaryMaximumMediaStreamBitrateNotification) Clone() Packet {
+	c := *p
+	c.Entries = cloneSlice(p.Entries)
+
+	return &c
+}
+
+// MarshalJSON encodes the packet as a JSON object tagged with its type.
+func (p TemporaryMaximumMediaStreamBitrateNotification) MarshalJSON() ([]byte, error) {
+	return marshalJSON(p)
+}
+
+// UnmarshalJSON decodes a JSON object produced by MarshalJSON.
+func (p *TemporaryMaximumMediaStreamBitrateNotification) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, p)
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Packet = (*TemporaryMaximumMediaStreamBitrateNotification)(nil) // assert is a Packet
+
+func TestTemporaryMaximumMediaStreamBitrateNotificationUnmarshal(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Data      []byte
+		Want      TemporaryMaximumMediaStreamBitrateNotification
+		WantError error
+	}{
+		{
+			Name: "two entries",
+			Data: []byte{
+				// v=2, p=0, FMT=4, RTPFB, len=6
+				0x84, 0xcd, 0x00, 0x06,
+				// sender=0x4bc4fcb4
+				0x4b, 0xc4, 0xfc, 0xb4,
+				// media=0x0
+				0x00, 0x00, 0x00, 0x00,
+				// ssrc=0x12345678
+				0x12, 0x34, 0x56, 0x78,
+				// exp=5, mantissa=0xF424, overhead=40
+				0x15, 0xe8, 0x48, 0x28,
+				// ssrc=0x98765432
+				0x98, 0x76, 0x54, 0x32,
+				// exp=0, mantissa=0x1FFFF, overhead=0
+				0x03, 0xff, 0xfe, 0x00,
+			},
+			Want: TemporaryMaximumMediaStreamBitrateNotification{
+				SenderSSRC: 0x4bc4fcb4,
+				Entries: []TMMBNEntry{
+					{SSRC: 0x12345678, Exponent: 5, Mantissa: 0xF424, Overhead: 40},
+					{SSRC: 0x98765432, Mantissa: 0x1FFFF},
+				},
+			},
+		},
+		{
+			Name: "empty bounding set",
+			Data: []byte{
+				// v=2, p=0, FMT=4, RTPFB, len=2
+				0x84, 0xcd, 0x00, 0x02,
+				0x4b, 0xc4, 0xfc, 0xb4,
+				0x00, 0x00, 0x00, 0x00,
+			},
+			Want: TemporaryMaximumMediaStreamBitrateNotification{
+				SenderSSRC: 0x4bc4fcb4,
+			},
+		},
+		{
+			Name: "wrong fmt",
+			Data: []byte{
+				// v=2, p=0, FMT=3, RTPFB, len=2
+				0x83, 0xcd, 0x00, 0x02,
+				0x4b, 0xc4, 0xfc, 0xb4,
+				0x00, 0x00, 0x00, 0x00,
+			},
+			WantError: ErrWrongType,
+		},
+		{
+			Name: "partial entry",
+			Data: []byte{
+				// v=2, p=0, FMT=4, RTPFB, len=3
+				0x84, 0xcd, 0x00, 0x03,
+				0x4b, 0xc4, 0xfc, 0xb4,
+				0x00, 0x00, 0x00, 0x00,
+				0x12, 0x34, 0x56, 0x78,
+			},
+			WantError: ErrBadLength,
+		},
+	} {
+		var tmmbn TemporaryMaximumMediaStreamBitrateNotification
+		err := tmmbn.Unmarshal(test.Data)
+		assert.ErrorIsf(t, err, test.WantError, "Unmarshal %q", test.Name)
+		if err != nil {
+			continue
+		}
+		assert.Equalf(t, test.Want, tmmbn, "Unmarshal %q", test.Name)
+
+		packets, err := Unmarshal(test.Data)
+		assert.NoErrorf(t, err, "Unmarshal %q", test.Name)
+		assert.Equalf(t, []Packet{&test.Want}, packets, "Unmarshal %q", test.Name)
+	}
+}
+
+func TestTemporaryMaximumMediaStreamBitrateNotificationRoundTrip(t *testing.T) {
+	packet := TemporaryMaximumMediaStreamBitrateNotification{
+		SenderSSRC: 1,
+		MediaSSRC:  0,
+		Entries:    []TMMBNEntry{{SSRC: 2, Overhead: 28}, {SSRC: 3, Overhead: 40}},
+	}
+	packet.Entries[0].SetBitrate(512000)
+	packet.Entries[1].SetBitrate(4000000)
+
+	data, err := packet.Marshal()
+	assert.NoError(t, err)
+	assert.Len(t, data, packet.MarshalSize())
+
+	var decoded TemporaryMaximumMediaStreamBitrateNotification
+	assert.NoError(t, decoded.Unmarshal(data))
+	assert.Equal(t, packet, decoded)
+	assert.Equal(t, uint64(512000), decoded.Entries[0].Bitrate())
+	assert.Equal(t, uint64(4000000), decoded.Entries[1].Bitrate())
+	assert.Equal(t, []uint32{2, 3}, decoded.DestinationSSRC())
+}
@@ -0,0 +1,331 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// A TMMBREntry is a single request carried by a
+// TemporaryMaximumMediaStreamBitrateRequest. The maximum total media bit
+// rate (MxTBR) is Mantissa * 2^Exponent bits per second.
+type TMMBREntry struct {
+	SSRC     uint32
+	Exponent uint8
+	Mantissa uint32
+	Overhead uint16
+}
+
+// SetBitrate encodes bitrate, in bits per second, into the Exponent and
+// Mantissa fields. Bitrates that do not fit in the 17-bit mantissa are
+// rounded down.
+func (e *TMMBREntry) SetBitrate(bitrate uint64) {
+	e.Exponent, e.Mantissa = encodeMxTBR(bitrate)
+}
+
+// Bitrate returns the maximum total media bit rate in bits per second.
+func (e TMMBREntry) Bitrate() uint64 {
+	return decodeMxTBR(e.Exponent, e.Mantissa)
+}
+
+// The TemporaryMaximumMediaStreamBitrateRequest packet is used by a media
+// receiver to ask senders to limit the bit rate of one or more streams.
+// See RFC 5104 Section 4.2.1.
+type TemporaryMaximumMediaStreamBitrateRequest struct {
+	SenderSSRC uint32
+	MediaSSRC  uint32
+
+	Entries []TMMBREntry
+
+	packetPadding
+}
+
+const (
+	tmmbOffset      = 8
+	tmmbEntryLength = 8
+
+	mxtbrMaxExponent = 63
+	mxtbrMaxMantissa = 0x1FFFF
+	mxtbrMaxOverhead = 0x1FF
+)
+
+var _ Packet = (*TemporaryMaximumMediaStreamBitrateRequest)(nil)
+
+// encodeMxTBR splits bitrate into the 6-bit exponent and 17-bit
+// mantissa used by TMMBR and TMMBN entries.
+func encodeMxTBR(bitrate uint64) (uint8, uint32) {
+	var exp uint8
+	for bitrate > mxtbrMaxMantissa {
+		bitrate >>= 1
+		exp++
+	}
+
+	return exp, uint32(bitrate) //nolint:gosec // G115
+}
+
+// decodeMxTBR computes mantissa * 2^exp, saturating at math.MaxUint64.
+func decodeMxTBR(exp uint8, mantissa uint32) uint64 {
+	if exp > mxtbrMaxExponent || uint64(mantissa) > math.MaxUint64>>exp {
+		return math.MaxUint64
+	}
+
+	return uint64(mantissa) << exp
+}
+
+// marshalTMMBEntry writes a TMMBR or TMMBN FCI entry to buf.
+//
+//	 0                   1                   2                   3
+//	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|                              SSRC                             |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	| MxTBR Exp |  MxTBR Mantissa                 |Measured Overhead|
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+func marshalTMMBEntry(buf []byte, ssrc uint32, exp uint8, mantissa uint32, overhead uint16) error {
+	if err := validateTMMBEntry(exp, mantissa, overhead); err != nil {
+		return err
+	}
+
+	binary.BigEndian.PutUint32(buf, ssrc)
+	binary.BigEndian.PutUint32(buf[4:], uint32(exp)<<26|mantissa<<9|uint32(overhead))
+
+	return nil
+}
+
+// validateTMMBEntry checks that the fields of a TMMBR or TMMBN FCI entry fit
+// in their 6, 17 and 9 bits.
+func validateTMMBEntry(exp uint8, mantissa uint32, overhead uint16) error {
+	if exp > mxtbrMaxExponent || mantissa > mxtbrMaxMantissa || overhead > mxtbrMaxOverhead {
+		return errInvalidMxTBR
+	}
+
+	return nil
+}
+
+// unmarshalTMMBEntry reads a TMMBR or TMMBN FCI entry from buf.
+func unmarshalTMMBEntry(buf []byte) (ssrc uint32, exp uint8, mantissa uint32, overhead uint16) {
+	ssrc = binary.BigEndian.Uint32(buf)
+	word := binary.BigEndian.Uint32(buf[4:])
+
+	exp = uint8(word >> 26)                    //nolint:gosec // G115
+	overhead = uint16(word & mxtbrMaxOverhead) //nolint:gosec // G115
+
+	return ssrc, exp, (word >> 9) & mxtbrMaxMantissa, overhead
+}
+
+// Marshal encodes the TemporaryMaximumMediaStreamBitrateRequest.
+func (p TemporaryMaximumMediaStreamBitrateRequest) Marshal() ([]byte, error) {
+	rawPacket := make([]byte, p.MarshalSize())
+	if _, err := p.MarshalTo(rawPacket); err != nil {
+		return nil, err
+	}
+
+	return rawPacket, nil
+}
+
+// MarshalAppend appends the packet in binary to dst and returns the
+// extended slice.
+func (p TemporaryMaximumMediaStreamBitrateRequest) MarshalAppend(dst []byte) ([]byte, error) {
+	return marshalAppend(dst, p.MarshalSize(), p.MarshalTo)
+}
+
+// MarshalTo encodes the TemporaryMaximumMediaStreamBitrateRequest into buf
+// and returns the number of bytes written.
+func (p TemporaryMaximumMediaStreamBitrateRequest) MarshalTo(buf []byte) (int, error) {
+	size := p.MarshalSize()
+	if len(buf) < size {
+		return 0, ErrPacketTooShort
+	}
+
+	header := p.Header()
+	if err := header.SetLengthFromBytes(size); err != nil {
+		return 0, err
+	}
+	if _, err := header.MarshalTo(buf); err != nil {
+		return 0, err
+	}
+
+	packetBody := buf[headerLength:size]
+
+	binary.BigEndian.PutUint32(packetBody, p.SenderSSRC)
+	binary.BigEndian.PutUint32(packetBody[4:], p.MediaSSRC)
+	for i, e := range p.Entries {
+		entry := packetBody[tmmbOffset+tmmbEntryLength*i:]
+		if err := marshalTMMBEntry(entry, e.SSRC, e.Exponent, e.Mantissa, e.Overhead); err != nil {
+			return 0, err
+		}
+	}
+
+	writePadding(buf[:size], p.padding)
+
+	return size, nil
+}
+
+// Unmarshal decodes the TemporaryMaximumMediaStreamBitrateRequest.
+func (p *TemporaryMaximumMediaStreamBitrateRequest) Unmarshal(rawPacket []byte) error {
+	rawPacket, padding, err := removePadding(rawPacket)
+	if err != nil {
+		return err
+	}
+
+	if len(rawPacket) < (headerLength + ssrcLength) {
+		return ErrPacketTooShort
+	}
+
+	var header Header
+	if err := header.Unmarshal(rawPacket); err != nil {
+		return err
+	}
+
+	length := 4 * int(header.Length)
+	if len(rawPacket) < headerLength+length {
+		return ErrPacketTooShort
+	}
+
+	if header.Type != TypeTransportSpecificFeedback || header.Count != FormatTMMBR {
+		return ErrWrongType
+	}
+
+	// The FCI field MUST contain one or more TMMBR entries
+	if length <= tmmbOffset || (length-tmmbOffset)%tmmbEntryLength != 0 {
+		return ErrBadLength
+	}
+
+	p.SenderSSRC = binary.BigEndian.Uint32(rawPacket[headerLength:])
+	p.MediaSSRC = binary.BigEndian.Uint32(rawPacket[headerLength+ssrcLength:])
+	p.Entries = nil
+	for i := headerLength + tmmbOffset; i < headerLength+length; i += tmmbEntryLength {
+		var e TMMBREntry
+		e.SSRC, e.Exponent, e.Mantissa, e.Overhead = unmarshalTMMBEntry(rawPacket[i:])
+		p.Entries = append(p.Entries, e)
+	}
+
+	p.padding = padding
+
+	return nil
+}
+
+// Header returns the Header associated with this packet.
+func (p *TemporaryMaximumMediaStreamBitrateRequest) Header() Header {
+	h := Header{
+		Padding: p.padding != 0,
+		Count:   FormatTMMBR,
+		Type:    TypeTransportSpecificFeedback,
+	}
+	_ = h.SetLengthFromBytes(p.MarshalSize())
+
+	return h
+}
+
+// MarshalSize returns the size of the packet once marshaled.
+func (p *TemporaryMaximumMediaStreamBitrateRequest) MarshalSize() int {
+	return headerLength + tmmbOffset + len(p.Entries)*tmmbEntryLength + int(p.padding)
+}
+
+func (p *TemporaryMaximumMediaStreamBitrateRequest) String() string {
+	out := fmt.Sprintf("TemporaryMaximumMediaStreamBitrateRequest %x %x",
+		p.SenderSSRC, p.MediaSSRC)
+	for _, e := range p.Entries {
+		out += fmt.Sprintf(" (%x %d %d)", e.SSRC, e.Bitrate(), e.Overhead)
+	}
+
+	return out
+}
+
+// Validate checks that the TemporaryMaximumMediaStreamBitrateRequest can be
+// sent: RFC 5104 Section 4.2.1.2 requires one or more entries, and their
+// fields must fit in the MxTBR encoding.
+func (p *TemporaryMaximumMediaStreamBitrateRequest) Validate() error {
+	if len(p.Entries) == 0 {
+		return errEmptyFCI
+	}
+	for _, e := range p.Entries {
+		if err := validateTMMBEntry(e.Exponent, e.Mantissa, e.Overhead); err != nil {
+			return err
+		}
+	}
+
+	return checkPacketSize(p.MarshalSize())
+}
+
+// Type returns the packet type, TypeTransportSpecificFeedback.
+func (p *TemporaryMaximumMediaStreamBitrateRequest) Type() PacketType {
+	return TypeTransportSpecificFeedback
+}
+
+// Format returns the feedback message type, FormatTMMBR.
+func (p *TemporaryMaximumMediaStreamBitrateRequest) Format() uint8 {
+	return FormatTMMBR
+}
+
+// DestinationSSRC returns an array of SSRC values that this packet refers to.
+func (p *TemporaryMaximumMediaStreamBitrateRequest) DestinationSSRC() []uint32 {
+	ssrcs := make([]uint32, 0, len(p.Entries))
+	for _, entry := range p.Entries {
+		ssrcs = append(ssrcs, entry.SSRC)
+	}
+
+	return ssrcs
+}
+
+// TargetsSSRC reports whether the packet is feedback about the media source
+// ssrc, the SSRC of one of its entries.
+func (p *TemporaryMaximumMediaStreamBitrateRequest) TargetsSSRC(ssrc uint32) bool {
+	for _, entry := range p.Entries {
+		if entry.SSRC == ssrc {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SourceSSRC returns the SSRC of the sender.
+func (p *TemporaryMaximumMediaStreamBitrateRequest) SourceSSRC() []uint32 {
+	return []uint32{p.SenderSSRC}
+}
+
+// RewriteSSRC replaces every SSRC in the packet that equals from with to,
+// and returns the number of SSRCs replaced.
+func (p *TemporaryMaximumMediaStreamBitrateRequest) RewriteSSRC(from, to uint32) int {
+	n := rewriteSSRC(&p.SenderSSRC, from, to) + rewriteSSRC(&p.MediaSSRC, from, to)
+	for i := range p.Entries {
+		n += rewriteSSRC(&p.Entries[i].SSRC, from, to)
+	}
+
+	return n
+}
+
+// Equal reports whether other is a TemporaryMaximumMediaStreamBitrateRequest
+// with the same contents.
+func (p *TemporaryMaximumMediaStreamBitrateRequest) Equal(other Packet) bool {
+	o, ok := other.(*TemporaryMaximumMediaStreamBitrateRequest)
+	if !ok || o == nil {
+		return false
+	}
+
+	return p.SenderSSRC == o.SenderSSRC &&
+		p.MediaSSRC == o.MediaSSRC &&
+		slicesEqual(p.Entries, o.Entries)
+}
+
+// Clone returns a deep copy of the packet that shares no memory with p.
+func (p *TemporaryMaximumMediaStreamBitrateRequest) Clone() Packet {
+	c := *p
+	c.Entries = cloneSlice(p.Entries)
+
+	return &c
+}
+
+// MarshalJSON encodes the packet as a JSON object tagged with its type.
+func (p TemporaryMaximumMediaStreamBitrateRequest) MarshalJSON() ([]byte, error) {
+	return marshalJSON(p)
+}
+
+// UnmarshalJSON decodes a JSON object produced by MarshalJSON.
+func (p *TemporaryMaximumMediaStreamBitrateRequest) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, p)
+}
@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: 2023 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtcp
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var _ Packet = (*TemporaryMaximumMediaStreamBitrateRequest)(nil) // assert is a Packet
+
+func TestTemporaryMaximumMediaStreamBitrateRequestUnmarshal(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Data      []byte
+		Want      TemporaryMaximumMediaStreamBitrateRequest
+		WantError error
+	}{
+		{
+			Name: "valid",
+			Data: []byte{
+				// v=2, p=0, FMT=3, RTPFB, len=4
+				0x83, 0xcd, 0x00, 0x04,
+				// sender=0x4bc4fcb4
+				0x4b, 0xc4, 0xfc, 0xb4,
+				// media=0x0
+				0x00, 0x00, 0x00, 0x00,
+				// ssrc=0x12345678
+				0x12, 0x34, 0x56, 0x78,
+				// exp=5, mantissa=0xF424, overhead=40
+				0x15, 0xe8, 0x48, 0x28,
+			},
+			Want: TemporaryMaximumMediaStreamBitrateRequest{
+				SenderSSRC: 0x4bc4fcb4,
+				MediaSSRC:  0x0,
+				Entries: []TMMBREntry{
+					{
+						SSRC:     0x12345678,
+						Exponent: 5,
+						Mantissa: 0xF424,
+						Overhead: 40,
+					},
+				},
+			},
+		},
+		{
+			Name: "packet too short",
+			Data: []byte{
+				0x00, 0x00, 0x00, 0x00,
+			},
+			WantError: ErrPacketTooShort,
+		},
+		{
+			Name: "wrong fmt",
+			Data: []byte{
+				// v=2, p=0, FMT=4, RTPFB, len=4
+				0x84, 0xcd, 0x00, 0x04,
+				0x4b, 0xc4, 0xfc, 0xb4,
+				0x00, 0x00, 0x00, 0x00,
+				0x12, 0x34, 0x56, 0x78,
+				0x15, 0xe8, 0x48, 0x28,
+			},
+			WantError: ErrWrongType,
+		},
+		{
+			Name: "no entries",
+			Data: []byte{
+				// v=2, p=0, FMT=3, RTPFB, len=2
+				0x83, 0xcd, 0x00, 0x02,
+				0x4b, 0xc4, 0xfc, 0xb4,
+				0x00, 0x00, 0x00, 0x00,
+			},
+			WantError: ErrBadLength,
+		},
+		{
+			Name: "partial entry",
+			Data: []byte{
+				// v=2, p=0, FMT=3, RTPFB, len=3
+				0x83, 0xcd, 0x00, 0x03,
+				0x4b, 0xc4, 0xfc, 0xb4,
+				0x00, 0x00, 0x00, 0x00,
+				0x12, 0x34, 0x56, 0x78,
+			},
+			WantError: ErrBadLength,
+		},
+	} {
+		var tmmbr TemporaryMaximumMediaStreamBitrateRequest
+		err := tmmbr.Unmarshal(test.Data)
+		assert.ErrorIsf(t, err, test.WantError, "Unmarshal %q", test.Name)
+		if err != nil {
+			continue
+		}
+
+		assert.Equalf(t, test.Want, tmmbr, "Unmarshal %q", test.Name)
+		assert.Equalf(t, uint64(2000000), tmmbr.Entries[0].Bitrate(), "Unmarshal %q", test.Name)
+
+		packets, err := Unmarshal(test.Data)
+		assert.NoErrorf(t, err, "Unmarshal %q", test.Name)
+		assert.Equalf(t, []Packet{&test.Want}, packets, "Unmarshal %q", test.Name)
+	}
+}
+
+func TestTemporaryMaximumMediaStreamBitrateRequestRoundTrip(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Packet    TemporaryMaximumMediaStreamBitrateRequest
+		WantError error
+	}{
+		{
+			Name: "valid",
+			Packet: TemporaryMaximumMediaStreamBitrateRequest{
+				SenderSSRC: 1,
+				Entries: []TMMBREntry{
+					{SSRC: 2, Exponent: 0, Mantissa: 0x1FFFF, Overhead: 0x1FF},
+					{SSRC: 3, Exponent: 63, Mantissa: 1, Overhead: 0},
+				},
+			},
+		},
+		{
+			Name: "mantissa overflow",
+			Packet: TemporaryMaximumMediaStreamBitrateRequest{
+				Entries: []TMMBREntry{{SSRC: 2, Mantissa: 0x20000}},
+			},
+			WantError: errInvalidMxTBR,
+		},
+		{
+			Name: "exponent overflow",
+			Packet: TemporaryMaximumMediaStreamBitrateRequest{
+				Entries: []TMMBREntry{{SSRC: 2, Exponent: 64}},
+			},
+			WantError: errInvalidMxTBR,
+		},
+		{
+			Name: "overhead overflow",
+			Packet: TemporaryMaximumMediaStreamBitrateRequest{
+				Entries: []TMMBREntry{{SSRC: 2, Overhead: 0x200}},
+			},
+			WantError: errInvalidMxTBR,
+		},
+	} {
+		data, err := test.Packet.Marshal()
+		assert.ErrorIsf(t, err, test.WantError, "Marshal %q", test.Name)
+		if err != nil {
+			continue
+		}
+
+		var decoded TemporaryMaximumMediaStreamBitrateRequest
+		assert.NoErrorf(t, decoded.Unmarshal(data), "Unmarshal %q", test.Name)
+		assert.Equalf(t, test.Packet, decoded, "%q round trip mismatch", test.Name)
+	}
+}
+
+func TestTMMBREntryBitrate(t *testing.T) {
+	for _, test := range []struct {
+		Name    string
+		Bitrate uint64
+		Want    uint64
+	}{
+		{Name: "zero", Bitrate: 0, Want: 0},
+		{Name: "fits mantissa", Bitrate: 0x1FFFF, Want: 0x1FFFF},
+		{Name: "exact", Bitrate: 2000000, Want: 2000000},
+		{Name: "rounds down", Bitrate: 1000001, Want: 1000000},
+		{Name: "max", Bitrate: math.MaxUint64, Want: 0x1FFFF << 47},
+	} {
+		var entry TMMBREntry
+		entry.SetBitrate(test.Bitrate)
+		assert.LessOrEqualf(t, entry.Mantissa, uint32(0x1FFFF), "SetBitrate %q", test.Name)
+		assert.Equalf(t, test.Want, entry.Bitrate(), "Bitrate %q", test.Name)
+	}
+
+	assert.Equal(t, uint64(math.MaxUint64), TMMBREntry{Exponent: 63, Mantissa: 0x1FFFF}.Bitrate())
+}
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"time"
 )
 
 // https://tools.ietf.org/html/draft-holmer-rmcat-transport-wide-cc-extensions-01#page-5
@@ -414,12 +415,42 @@ func (t TransportLayerCC) String() string {
 
 // Marshal encodes the TransportLayerCC in binary.
 func (t TransportLayerCC) Marshal() ([]byte, error) {
-	header, err := t.Header.Marshal()
-	if err != nil {
+	rawPacket := make([]byte, t.MarshalSize())
+	if _, err := t.MarshalTo(rawPacket); err != nil {
 		return nil, err
 	}
 
-	payload := make([]byte, t.MarshalSize()-headerLength)
+	return rawPacket, nil
+}
+
+// MarshalAppend appends the packet in binary to dst and returns the
+// extended slice.
+func (t TransportLayerCC) MarshalAppend(dst []byte) ([]byte, error) {
+	return marshalAppend(dst, t.MarshalSize(), t.MarshalTo)
+}
+
+// MarshalTo encodes the TransportLayerCC in binary into buf and returns the number of bytes written.
+func (t TransportLayerCC) MarshalTo(buf []byte) (int, error) {
+	size := t.MarshalSize()
+	if len(buf) < size {
+		return 0, ErrPacketTooShort
+	}
+
+	// The length is derived from the chunks and deltas being written, not
+	// taken from the stored header, which may describe a different packet.
+	header := t.Header
+	if err := header.SetLengthFromBytes(size); err != nil {
+		return 0, err
+	}
+	if _, err := header.MarshalTo(buf); err != nil {
+		return 0, err
+	}
+
+	payload := buf[headerLength:size]
+	for i := range payload {
+		payload[i] = 0
+	}
+
 	binary.BigEndian.PutUint32(payload, t.SenderSSRC)
 	binary.BigEndian.PutUint32(payload[4:], t.MediaSSRC)
 	binary.BigEndian.PutUint16(payload[baseSequenceNumberOffset:], t.BaseSequenceNumber)
@@ -431,7 +462,7 @@ func (t TransportLayerCC) Marshal() ([]byte, error) {
 	for i, chunk := range t.PacketChunks {
 		b, err := chunk.Marshal()
 		if err != nil {
-			return nil, err
+			return 0, err
 		}
 		copy(payload[packetChunkOffset+i*2:], b)
 	}
@@ -449,11 +480,12 @@ func (t TransportLayerCC) Marshal() ([]byte, error) {
 		}
 	}
 
-	if t.Header.Padding {
+	// Without bytes to pad the last byte belongs to the deltas or chunks.
+	if t.Header.Padding && size != int(t.packetLen()) {
 		payload[len(payload)-1] = uint8(t.MarshalSize() - int(t.packetLen())) //nolint:gosec // G115
 	}
 
-	return append(header, payload...), nil
+	return size, nil
 }
 
 // Unmarshal ..
@@ -461,7 +493,7 @@ func (t TransportLayerCC) Marshal() ([]byte, error) {
 //nolint:gocognit,cyclop
 func (t *TransportLayerCC) Unmarshal(rawPacket []byte) error {
 	if len(rawPacket) < (headerLength + ssrcLength) {
-		return errPacketTooShort
+		return ErrPacketTooShort
 	}
 
 	if err := t.Header.Unmarshal(rawPacket); err != nil {
@@ -470,18 +502,18 @@ func (t *TransportLayerCC) Unmarshal(rawPacket []byte) error {
 
 	// https://tools.ietf.org/html/rfc4585#page-33
 	// header's length + payload's length
-	totalLength := 4 * (t.Header.Length + 1)
+	totalLength := (int(t.Header.Length) + 1) * 4
 
 	if totalLength < headerLength+packetChunkOffset {
-		return errPacketTooShort
+		return ErrPacketTooShort
 	}
 
-	if len(rawPacket) < int(totalLength) {
-		return errPacketTooShort
+	if len(rawPacket) < totalLength {
+		return ErrPacketTooShort
 	}
 
 	if t.Header.Type != TypeTransportSpecificFeedback || t.Header.Count != FormatTCC {
-		return errWrongType
+		return ErrWrongType
 	}
 
 	t.SenderSSRC = binary.BigEndian.Uint32(rawPacket[headerLength:])
@@ -491,11 +523,14 @@ func (t *TransportLayerCC) Unmarshal(rawPacket []byte) error {
 	t.ReferenceTime = get24BitsFromBytes(rawPacket[headerLength+referenceTimeOffset : headerLength+referenceTimeOffset+3])
 	t.FbPktCount = rawPacket[headerLength+fbPktCountOffset]
 
-	packetStatusPos := uint16(headerLength + packetChunkOffset)
+	t.PacketChunks = nil
+	t.RecvDeltas = nil
+
+	packetStatusPos := headerLength + packetChunkOffset
 	var processedPacketNum uint16
 	for processedPacketNum < t.PacketStatusCount {
-		if packetStatusPos+packetStatusChunkLength >= totalLength {
-			return errPacketTooShort
+		if packetStatusPos+packetStatusChunkLength > totalLength {
+			return ErrPacketTooShort
 		}
 		typ := getNBitsFromByte(rawPacket[packetStatusPos : packetStatusPos+1][0], 0, 1)
 		var iPacketStatus PacketStatusChunk
@@ -523,22 +558,27 @@ func (t *TransportLayerCC) Unmarshal(rawPacket []byte) error {
 			if err != nil {
 				return err
 			}
+			// Symbols past the packet status count are padding and carry no deltas
+			packetNumberToProcess := localMin(
+				t.PacketStatusCount-processedPacketNum,
+				uint16(len(packetStatus.SymbolList)), //nolint:gosec // G115
+			)
 			if packetStatus.SymbolSize == TypeTCCSymbolSizeOneBit {
-				for j := 0; j < len(packetStatus.SymbolList); j++ {
+				for j := uint16(0); j < packetNumberToProcess; j++ {
 					if packetStatus.SymbolList[j] == TypeTCCPacketReceivedSmallDelta {
 						t.RecvDeltas = append(t.RecvDeltas, &RecvDelta{Type: TypeTCCPacketReceivedSmallDelta})
 					}
 				}
 			}
 			if packetStatus.SymbolSize == TypeTCCSymbolSizeTwoBit {
-				for j := 0; j < len(packetStatus.SymbolList); j++ {
+				for j := uint16(0); j < packetNumberToProcess; j++ {
 					if packetStatus.SymbolList[j] == TypeTCCPacketReceivedSmallDelta ||
 						packetStatus.SymbolList[j] == TypeTCCPacketReceivedLargeDelta {
 						t.RecvDeltas = append(t.RecvDeltas, &RecvDelta{Type: packetStatus.SymbolList[j]})
 					}
 				}
 			}
-			processedPacketNum += uint16(len(packetStatus.SymbolList)) //nolint:gosec // G115
+			processedPacketNum += packetNumberToProcess
 		}
 		packetStatusPos += packetStatusChunkLength
 		t.PacketChunks = append(t.PacketChunks, iPacketStatus)
@@ -548,7 +588,7 @@ func (t *TransportLayerCC) Unmarshal(rawPacket []byte) error {
 	for _, delta := range t.RecvDeltas {
 		if delta.Type == TypeTCCPacketReceivedSmallDelta {
 			if recvDeltasPos+1 > totalLength {
-				return errPacketTooShort
+				return ErrPacketTooShort
 			}
 			err := delta.Unmarshal(rawPacket[recvDeltasPos : recvDeltasPos+1])
 			if err != nil {
@@ -558,7 +598,7 @@ func (t *TransportLayerCC) Unmarshal(rawPacket []byte) error {
 		}
 		if delta.Type == TypeTCCPacketReceivedLargeDelta {
 			if recvDeltasPos+2 > totalLength {
-				return errPacketTooShort
+				return ErrPacketTooShort
 			}
 			err := delta.Unmarshal(rawPacket[recvDeltasPos : recvDeltasPos+2])
 			if err != nil {
@@ -571,11 +611,210 @@ func (t *TransportLayerCC) Unmarshal(rawPacket []byte) error {
 	return nil
 }
 
+// PacketStatus is the receive status of a single RTP packet reported by a
+// TransportLayerCC.
+type PacketStatus struct {
+	// Transport wide sequence number of the packet
+	SequenceNumber uint16
+	// Whether the packet was received
+	Received bool
+	// Time the packet arrived after the previous received packet, or after
+	// the reference time for the first one. Zero for packets that were not
+	// received, or were received without a delta.
+	Delta time.Duration
+}
+
+// PacketStatuses expands the packet status chunks into the status of each of
+// the PacketStatusCount packets starting at BaseSequenceNumber, pairing the
+// received packets with their RecvDeltas. Sequence numbers wrap around. It
+// fails if the receive deltas do not match the status symbols.
+func (t TransportLayerCC) PacketStatuses() ([]PacketStatus, error) {
+	statuses := make([]PacketStatus, 0, t.PacketStatusCount)
+	deltas := t.RecvDeltas
+
+	add := func(symbol uint16) error {
+		status := PacketStatus{
+			SequenceNumber: t.BaseSequenceNumber + uint16(len(statuses)), //nolint:gosec // G115
+			Received:       symbol != TypeTCCPacketNotReceived,
+		}
+		if symbol == TypeTCCPacketReceivedSmallDelta || symbol == TypeTCCPacketReceivedLargeDelta {
+			if len(deltas) == 0 || deltas[0] == nil || deltas[0].Type != symbol {
+				return fmt.Errorf("%w: sequence number %d", errTCCDeltaMismatch, status.SequenceNumber)
+			}
+			status.Delta = time.Duration(deltas[0].Delta) * time.Microsecond
+			deltas = deltas[1:]
+		}
+		statuses = append(statuses, status)
+
+		return nil
+	}
+
+	for _, chunk := range t.PacketChunks {
+		switch chunk := chunk.(type) {
+		case *RunLengthChunk:
+			for i := uint16(0); i < chunk.RunLength && len(statuses) < int(t.PacketStatusCount); i++ {
+				if err := add(chunk.PacketStatusSymbol); err != nil {
+					return nil, err
+				}
+			}
+		case *StatusVectorChunk:
+			for _, symbol := range chunk.SymbolList {
+				if len(statuses) == int(t.PacketStatusCount) {
+					break
+				}
+				if err := add(symbol); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if len(statuses) != int(t.PacketStatusCount) || len(deltas) != 0 {
+		return nil, fmt.Errorf("%w: %d statuses and %d unused deltas for %d packets",
+			errTCCDeltaMismatch, len(statuses), len(deltas), t.PacketStatusCount)
+	}
+
+	return statuses, nil
+}
+
+// Validate checks that the TransportLayerCC can be sent: that its chunks
+// describe PacketStatusCount packets, with a receive delta of the right size
+// for each received one.
+func (t *TransportLayerCC) Validate() error {
+	if _, err := t.PacketStatuses(); err != nil {
+		return err
+	}
+
+	return checkPacketSize(t.MarshalSize())
+}
+
+// Type returns the packet type, TypeTransportSpecificFeedback.
+func (t *TransportLayerCC) Type() PacketType {
+	return TypeTransportSpecificFeedback
+}
+
+// Format returns the feedback message type, FormatTCC.
+func (t *TransportLayerCC) Format() uint8 {
+	return FormatTCC
+}
+
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
 func (t TransportLayerCC) DestinationSSRC() []uint32 {
 	return []uint32{t.MediaSSRC}
 }
 
+// TargetsSSRC reports whether the packet is feedback about the media source
+// ssrc, its MediaSSRC.
+func (t TransportLayerCC) TargetsSSRC(ssrc uint32) bool {
+	return t.MediaSSRC == ssrc
+}
+
+// SourceSSRC returns the SSRC of the sender.
+func (t TransportLayerCC) SourceSSRC() []uint32 {
+	return []uint32{t.SenderSSRC}
+}
+
+// RewriteSSRC replaces every SSRC in the packet that equals from with to,
+// and returns the number of SSRCs replaced.
+func (t *TransportLayerCC) RewriteSSRC(from, to uint32) int {
+	return rewriteSSRC(&t.SenderSSRC, from, to) + rewriteSSRC(&t.MediaSSRC, from, to)
+}
+
+// Equal reports whether other is a TransportLayerCC with the same contents.
+// Nil and empty slices are considered equal.
+//
+//nolint:cyclop
+func (t TransportLayerCC) Equal(other Packet) bool {
+	o, ok := other.(*TransportLayerCC)
+	if !ok || o == nil ||
+		t.Header != o.Header ||
+		t.SenderSSRC != o.SenderSSRC ||
+		t.MediaSSRC != o.MediaSSRC ||
+		t.BaseSequenceNumber != o.BaseSequenceNumber ||
+		t.PacketStatusCount != o.PacketStatusCount ||
+		t.ReferenceTime != o.ReferenceTime ||
+		t.FbPktCount != o.FbPktCount ||
+		len(t.PacketChunks) != len(o.PacketChunks) ||
+		len(t.RecvDeltas) != len(o.RecvDeltas) {
+		return false
+	}
+
+	for i := range t.PacketChunks {
+		if !packetStatusChunksEqual(t.PacketChunks[i], o.PacketChunks[i]) {
+			return false
+		}
+	}
+
+	for i := range t.RecvDeltas {
+		a, b := t.RecvDeltas[i], o.RecvDeltas[i]
+		if a == nil || b == nil {
+			if a != b {
+				return false
+			}
+
+			continue
+		}
+		if *a != *b {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Clone returns a deep copy of the packet that shares no memory with t.
+func (t TransportLayerCC) Clone() Packet {
+	chunks := cloneSlice(t.PacketChunks)
+	for i, chunk := range chunks {
+		switch chunk := chunk.(type) {
+		case *RunLengthChunk:
+			c := *chunk
+			chunks[i] = &c
+		case *StatusVectorChunk:
+			c := *chunk
+			c.SymbolList = cloneSlice(chunk.SymbolList)
+			chunks[i] = &c
+		}
+	}
+	t.PacketChunks = chunks
+
+	deltas := cloneSlice(t.RecvDeltas)
+	for i, delta := range deltas {
+		if delta != nil {
+			d := *delta
+			deltas[i] = &d
+		}
+	}
+	t.RecvDeltas = deltas
+
+	return &t
+}
+
+// MarshalJSON encodes the packet as a JSON object tagged with its type.
+func (t TransportLayerCC) MarshalJSON() ([]byte, error) {
+	return marshalJSON(t)
+}
+
+// UnmarshalJSON decodes a JSON object produced by MarshalJSON.
+func (t *TransportLayerCC) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, t)
+}
+
+func packetStatusChunksEqual(a, b PacketStatusChunk) bool {
+	switch a := a.(type) {
+	case *RunLengthChunk:
+		b, ok := b.(*RunLengthChunk)
+
+		return ok && a.Type == b.Type && a.PacketStatusSymbol == b.PacketStatusSymbol && a.RunLength == b.RunLength
+	case *StatusVectorChunk:
+		b, ok := b.(*StatusVectorChunk)
+
+		return ok && a.Type == b.Type && a.SymbolSize == b.SymbolSize && slicesEqual(a.SymbolList, b.SymbolList)
+	}
+
+	return a == b
+}
+
 func localMin(x, y uint16) uint16 {
 	if x < y {
 		return x
@@ -5,6 +5,7 @@ package rtcp
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -737,7 +738,7 @@ func TestTransportLayerCC_Unmarshal(t *testing.T) {
 				0x20, 0x3, 0x94, 0x1,
 			},
 			Want:      TransportLayerCC{},
-			WantError: errPacketTooShort,
+			WantError: ErrPacketTooShort,
 		},
 		{
 			Name: "example9",
@@ -750,7 +751,84 @@ func TestTransportLayerCC_Unmarshal(t *testing.T) {
 				0x40, 0x2, 0x94, 0x1,
 			},
 			Want:      TransportLayerCC{},
-			WantError: errPacketTooShort,
+			WantError: ErrPacketTooShort,
+		},
+		{
+			Name: "chunks end at packet boundary",
+			Data: []byte{
+				0x8f, 0xcd, 0x0, 0x5,
+				0xfa, 0x17, 0xfa, 0x17,
+				0x43, 0x3, 0x2f, 0xa0,
+				0x0, 0x99, 0x0, 0x2,
+				0x3d, 0xe8, 0x2, 0x17,
+				0x0, 0x1, 0x0, 0x1,
+			},
+			Want: TransportLayerCC{
+				Header: Header{
+					Count:  FormatTCC,
+					Type:   TypeTransportSpecificFeedback,
+					Length: 5,
+				},
+				SenderSSRC:         4195875351,
+				MediaSSRC:          1124282272,
+				BaseSequenceNumber: 153,
+				PacketStatusCount:  2,
+				ReferenceTime:      4057090,
+				FbPktCount:         23,
+				PacketChunks: []PacketStatusChunk{
+					&RunLengthChunk{
+						Type:               TypeTCCRunLengthChunk,
+						PacketStatusSymbol: TypeTCCPacketNotReceived,
+						RunLength:          1,
+					},
+					&RunLengthChunk{
+						Type:               TypeTCCRunLengthChunk,
+						PacketStatusSymbol: TypeTCCPacketNotReceived,
+						RunLength:          1,
+					},
+				},
+			},
+		},
+		{
+			Name: "status vector past status count",
+			Data: []byte{
+				0x8f, 0xcd, 0x0, 0x5,
+				0xfa, 0x17, 0xfa, 0x17,
+				0x43, 0x3, 0x2f, 0xa0,
+				0x0, 0x99, 0x0, 0x2,
+				0x3d, 0xe8, 0x2, 0x17,
+				0xbf, 0xff, 0x4, 0x8,
+			},
+			Want: TransportLayerCC{
+				Header: Header{
+					Count:  FormatTCC,
+					Type:   TypeTransportSpecificFeedback,
+					Length: 5,
+				},
+				SenderSSRC:         4195875351,
+				MediaSSRC:          1124282272,
+				BaseSequenceNumber: 153,
+				PacketStatusCount:  2,
+				ReferenceTime:      4057090,
+				FbPktCount:         23,
+				PacketChunks: []PacketStatusChunk{
+					&StatusVectorChunk{
+						Type:       TypeTCCStatusVectorChunk,
+						SymbolSize: TypeTCCSymbolSizeOneBit,
+						SymbolList: []uint16{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+					},
+				},
+				RecvDeltas: []*RecvDelta{
+					{
+						Type:  TypeTCCPacketReceivedSmallDelta,
+						Delta: 1000,
+					},
+					{
+						Type:  TypeTCCPacketReceivedSmallDelta,
+						Delta: 2000,
+					},
+				},
+			},
 		},
 	} {
 		test := test
@@ -762,6 +840,10 @@ func TestTransportLayerCC_Unmarshal(t *testing.T) {
 				return
 			}
 			assert.Equalf(t, test.Want, chunk, "Unmarshal %q", test.Name)
+
+			// Decoding into a used value must not keep the old chunks
+			assert.NoErrorf(t, chunk.Unmarshal(test.Data), "Unmarshal %q", test.Name)
+			assert.Equalf(t, test.Want, chunk, "Unmarshal %q", test.Name)
 		})
 	}
 }
@@ -1167,3 +1249,79 @@ func TestTransportLayerCC_Marshal(t *testing.T) {
 		})
 	}
 }
+
+func TestTransportLayerCC_PacketStatuses(t *testing.T) {
+	var packet TransportLayerCC
+	assert.NoError(t, packet.Unmarshal([]byte{
+		0xaf, 0xcd, 0x0, 0x7,
+		0xfa, 0x17, 0xfa, 0x17,
+		0x19, 0x3d, 0xd8, 0xbb,
+		0x1, 0x74, 0x0, 0x6,
+		0x45, 0xb1, 0x5a, 0x40,
+		0x40, 0x2, 0x20, 0x04,
+		0x1f, 0xfe, 0x1f, 0x9a,
+		0xd0, 0x0, 0xd0, 0x0,
+	}))
+	statuses, err := packet.PacketStatuses()
+	assert.NoError(t, err)
+	assert.Equal(t, []PacketStatus{
+		{SequenceNumber: 372, Received: true, Delta: 2047500 * time.Microsecond},
+		{SequenceNumber: 373, Received: true, Delta: 2022500 * time.Microsecond},
+		{SequenceNumber: 374, Received: true, Delta: 52 * time.Millisecond},
+		{SequenceNumber: 375, Received: true},
+		{SequenceNumber: 376, Received: true, Delta: 52 * time.Millisecond},
+		{SequenceNumber: 377, Received: true},
+	}, statuses)
+
+	// Status vector symbols past the packet status count are ignored, and
+	// sequence numbers wrap around.
+	packet = TransportLayerCC{
+		BaseSequenceNumber: 65534,
+		PacketStatusCount:  4,
+		PacketChunks: []PacketStatusChunk{
+			&StatusVectorChunk{
+				Type:       TypeTCCStatusVectorChunk,
+				SymbolSize: TypeTCCSymbolSizeTwoBit,
+				SymbolList: []uint16{
+					TypeTCCPacketReceivedLargeDelta,
+					TypeTCCPacketNotReceived,
+					TypeTCCPacketReceivedWithoutDelta,
+					TypeTCCPacketReceivedSmallDelta,
+					TypeTCCPacketReceivedSmallDelta,
+					TypeTCCPacketNotReceived,
+					TypeTCCPacketNotReceived,
+				},
+			},
+		},
+		RecvDeltas: []*RecvDelta{
+			{Type: TypeTCCPacketReceivedLargeDelta, Delta: -250},
+			{Type: TypeTCCPacketReceivedSmallDelta, Delta: 1000},
+		},
+	}
+	statuses, err = packet.PacketStatuses()
+	assert.NoError(t, err)
+	assert.Equal(t, []PacketStatus{
+		{SequenceNumber: 65534, Received: true, Delta: -250 * time.Microsecond},
+		{SequenceNumber: 65535},
+		{SequenceNumber: 0, Received: true},
+		{SequenceNumber: 1, Received: true, Delta: time.Millisecond},
+	}, statuses)
+
+	for _, test := range []struct {
+		Name   string
+		Deltas []*RecvDelta
+	}{
+		{"missing delta", packet.RecvDeltas[:1]},
+		{"extra delta", append(packet.RecvDeltas, &RecvDelta{Type: TypeTCCPacketReceivedSmallDelta})},
+		{"wrong delta size", []*RecvDelta{packet.RecvDeltas[1], packet.RecvDeltas[0]}},
+	} {
+		broken := packet
+		broken.RecvDeltas = test.Deltas
+		_, err := broken.PacketStatuses()
+		assert.ErrorIsf(t, err, errTCCDeltaMismatch, "PacketStatuses %q", test.Name)
+	}
+
+	packet.PacketStatusCount = 8
+	_, err = packet.PacketStatuses()
+	assert.ErrorIs(t, err, errTCCDeltaMismatch, "too few statuses")
+}
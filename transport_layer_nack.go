@@ -7,6 +7,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"math"
+	"sort"
 )
 
 // PacketBitmap shouldn't be used like a normal integral,
@@ -34,18 +35,36 @@ type TransportLayerNack struct {
 	MediaSSRC uint32
 
 	Nacks []NackPair
+
+	packetPadding
 }
 
 // NackPairsFromSequenceNumbers generates a slice of NackPair from a list of SequenceNumbers
 // This handles generating the proper values for PacketID/LostPackets.
+//
+// The sequence numbers may be given in any order and may contain duplicates.
+// They are ordered as RTP sequence numbers, so losses around a wraparound
+// (e.g. 65534, 65535, 0, 1) share pairs, which requires them to span less
+// than half of the sequence number space. The result uses the fewest pairs
+// that cover every sequence number.
 func NackPairsFromSequenceNumbers(sequenceNumbers []uint16) (pairs []NackPair) {
 	if len(sequenceNumbers) == 0 {
 		return []NackPair{}
 	}
 
-	nackPair := &NackPair{PacketID: sequenceNumbers[0]}
-	for i := 1; i < len(sequenceNumbers); i++ {
-		m := sequenceNumbers[i]
+	sorted := make([]uint16, len(sequenceNumbers))
+	copy(sorted, sequenceNumbers)
+	sort.Slice(sorted, func(i, j int) bool {
+		return int16(sorted[i]-sorted[j]) < 0 //nolint:gosec // G115, serial number comparison
+	})
+
+	nackPair := &NackPair{PacketID: sorted[0]}
+	for i := 1; i < len(sorted); i++ {
+		m := sorted[i]
+
+		if m == nackPair.PacketID {
+			continue
+		}
 
 		if m-nackPair.PacketID > 16 {
 			pairs = append(pairs, *nackPair)
@@ -61,6 +80,61 @@ func NackPairsFromSequenceNumbers(sequenceNumbers []uint16) (pairs []NackPair) {
 	return
 }
 
+// NewTransportLayerNack creates a TransportLayerNack reporting the loss of
+// sequenceNumbers, as grouped by NackPairsFromSequenceNumbers. A single lost
+// packet is reported by one NackPair with no following packets lost.
+func NewTransportLayerNack(senderSSRC, mediaSSRC uint32, sequenceNumbers ...uint16) *TransportLayerNack {
+	return &TransportLayerNack{
+		SenderSSRC: senderSSRC,
+		MediaSSRC:  mediaSSRC,
+		Nacks:      NackPairsFromSequenceNumbers(sequenceNumbers),
+	}
+}
+
+// MergeNackPairs coalesces several lists of NackPairs, such as ones collected
+// over different time windows, into the fewest pairs that cover every
+// sequence number they report lost. As with NackPairsFromSequenceNumbers, the
+// sequence numbers may wrap around but must span less than half of the
+// sequence number space.
+func MergeNackPairs(lists ...[]NackPair) []NackPair {
+	var sequenceNumbers []uint16
+	for _, pairs := range lists {
+		for i := range pairs {
+			pairs[i].Range(func(seqno uint16) bool {
+				sequenceNumbers = append(sequenceNumbers, seqno)
+
+				return true
+			})
+		}
+	}
+
+	return NackPairsFromSequenceNumbers(sequenceNumbers)
+}
+
+// MergeTransportLayerNacks coalesces NACKs for the same media source into a
+// single TransportLayerNack, with the pairs merged by MergeNackPairs. The
+// result is sent by the sender of the first NACK. It fails if nacks is empty
+// or its NACKs are for different media sources.
+func MergeTransportLayerNacks(nacks ...*TransportLayerNack) (*TransportLayerNack, error) {
+	if len(nacks) == 0 {
+		return nil, errNoNacksToMerge
+	}
+
+	lists := make([][]NackPair, 0, len(nacks))
+	for _, nack := range nacks {
+		if nack.MediaSSRC != nacks[0].MediaSSRC {
+			return nil, errNackMediaSSRCMismatch
+		}
+		lists = append(lists, nack.Nacks)
+	}
+
+	return &TransportLayerNack{
+		SenderSSRC: nacks[0].SenderSSRC,
+		MediaSSRC:  nacks[0].MediaSSRC,
+		Nacks:      MergeNackPairs(lists...),
+	}, nil
+}
+
 // Range calls f sequentially for each sequence number covered by n.
 // If f returns false, Range stops the iteration.
 func (n *NackPair) Range(f func(seqno uint16) bool) {
@@ -93,37 +167,85 @@ func (n *NackPair) PacketList() []uint16 {
 	return out
 }
 
+// PacketList returns the sequence numbers of every packet reported lost by
+// the TransportLayerNack, in the order of its NackPairs.
+func (p *TransportLayerNack) PacketList() []uint16 {
+	var out []uint16
+	for i := range p.Nacks {
+		p.Nacks[i].Range(func(seqno uint16) bool {
+			out = append(out, seqno)
+
+			return true
+		})
+	}
+
+	return out
+}
+
 const (
-	tlnLength  = 2
-	nackOffset = 8
+	tlnLength      = 2
+	nackOffset     = 8
+	nackPairLength = 4
 )
 
 // Marshal encodes the TransportLayerNack in binary.
 func (p TransportLayerNack) Marshal() ([]byte, error) {
+	rawPacket := make([]byte, p.MarshalSize())
+	if _, err := p.MarshalTo(rawPacket); err != nil {
+		return nil, err
+	}
+
+	return rawPacket, nil
+}
+
+// MarshalAppend appends the packet in binary to dst and returns the
+// extended slice.
+func (p TransportLayerNack) MarshalAppend(dst []byte) ([]byte, error) {
+	return marshalAppend(dst, p.MarshalSize(), p.MarshalTo)
+}
+
+// MarshalTo encodes the TransportLayerNack in binary into buf and returns the number of bytes written.
+func (p TransportLayerNack) MarshalTo(buf []byte) (int, error) {
 	if len(p.Nacks)+tlnLength > math.MaxUint8 {
-		return nil, errTooManyReports
+		return 0, errTooManyReports
 	}
 
-	rawPacket := make([]byte, nackOffset+(len(p.Nacks)*4))
-	binary.BigEndian.PutUint32(rawPacket, p.SenderSSRC)
-	binary.BigEndian.PutUint32(rawPacket[4:], p.MediaSSRC)
-	for i := 0; i < len(p.Nacks); i++ {
-		binary.BigEndian.PutUint16(rawPacket[nackOffset+(4*i):], p.Nacks[i].PacketID)
-		binary.BigEndian.PutUint16(rawPacket[nackOffset+(4*i)+2:], uint16(p.Nacks[i].LostPackets))
+	size := p.MarshalSize()
+	if len(buf) < size {
+		return 0, ErrPacketTooShort
 	}
-	h := p.Header()
-	hData, err := h.Marshal()
-	if err != nil {
-		return nil, err
+
+	header := p.Header()
+	if err := header.SetLengthFromBytes(size); err != nil {
+		return 0, err
+	}
+	if _, err := header.MarshalTo(buf); err != nil {
+		return 0, err
+	}
+
+	packetBody := buf[headerLength:]
+
+	binary.BigEndian.PutUint32(packetBody, p.SenderSSRC)
+	binary.BigEndian.PutUint32(packetBody[4:], p.MediaSSRC)
+	for i := 0; i < len(p.Nacks); i++ {
+		binary.BigEndian.PutUint16(packetBody[nackOffset+(4*i):], p.Nacks[i].PacketID)
+		binary.BigEndian.PutUint16(packetBody[nackOffset+(4*i)+2:], uint16(p.Nacks[i].LostPackets))
 	}
 
-	return append(hData, rawPacket...), nil
+	writePadding(buf[:size], p.padding)
+
+	return size, nil
 }
 
 // Unmarshal decodes the TransportLayerNack from binary.
 func (p *TransportLayerNack) Unmarshal(rawPacket []byte) error {
+	rawPacket, padding, err := removePadding(rawPacket)
+	if err != nil {
+		return err
+	}
+
 	if len(rawPacket) < (headerLength + ssrcLength) {
-		return errPacketTooShort
+		return ErrPacketTooShort
 	}
 
 	var header Header
@@ -131,43 +253,57 @@ func (p *TransportLayerNack) Unmarshal(rawPacket []byte) error {
 		return err
 	}
 
-	if len(rawPacket) < (headerLength + int(4*header.Length)) {
-		return errPacketTooShort
+	if len(rawPacket) < (headerLength + 4*int(header.Length)) {
+		return ErrPacketTooShort
 	}
 
 	if header.Type != TypeTransportSpecificFeedback || header.Count != FormatTLN {
-		return errWrongType
+		return ErrWrongType
 	}
 
 	// The FCI field MUST contain at least one and MAY contain more than one Generic NACK
-	if 4*header.Length <= nackOffset {
-		return errBadLength
+	if 4*int(header.Length) <= nackOffset {
+		return ErrBadLength
 	}
 
+	end := headerLength + 4*int(header.Length)
+
 	p.SenderSSRC = binary.BigEndian.Uint32(rawPacket[headerLength:])
 	p.MediaSSRC = binary.BigEndian.Uint32(rawPacket[headerLength+ssrcLength:])
-	for i := headerLength + nackOffset; i < (headerLength + int(header.Length*4)); i += 4 {
+	p.Nacks = nil
+	for i := headerLength + nackOffset; i < end; i += nackPairLength {
+		// Checked by the length validation above, but never index past the
+		// buffer on untrusted input.
+		if i+nackPairLength > len(rawPacket) {
+			return ErrPacketTooShort
+		}
+
 		p.Nacks = append(p.Nacks, NackPair{
 			binary.BigEndian.Uint16(rawPacket[i:]),
 			PacketBitmap(binary.BigEndian.Uint16(rawPacket[i+2:])),
 		})
 	}
 
+	p.padding = padding
+
 	return nil
 }
 
 // MarshalSize returns the size of the packet once marshaled.
 func (p *TransportLayerNack) MarshalSize() int {
-	return headerLength + nackOffset + (len(p.Nacks) * 4)
+	return headerLength + nackOffset + (len(p.Nacks) * 4) + int(p.padding)
 }
 
 // Header returns the Header associated with this packet.
 func (p *TransportLayerNack) Header() Header {
-	return Header{
-		Count:  FormatTLN,
-		Type:   TypeTransportSpecificFeedback,
-		Length: uint16((p.MarshalSize() / 4) - 1), //nolint:gosec // G115
+	h := Header{
+		Padding: p.padding != 0,
+		Count:   FormatTLN,
+		Type:    TypeTransportSpecificFeedback,
 	}
+	_ = h.SetLengthFromBytes(p.MarshalSize())
+
+	return h
 }
 
 func (p TransportLayerNack) String() string {
@@ -181,7 +317,77 @@ func (p TransportLayerNack) String() string {
 	return out
 }
 
+// Validate checks that the TransportLayerNack can be sent: RFC 4585 Section
+// 6.2.1 requires at least one NACK pair.
+func (p *TransportLayerNack) Validate() error {
+	if len(p.Nacks) == 0 {
+		return errEmptyFCI
+	}
+	if len(p.Nacks)+tlnLength > math.MaxUint8 {
+		return errTooManyReports
+	}
+
+	return nil
+}
+
+// Type returns the packet type, TypeTransportSpecificFeedback.
+func (p *TransportLayerNack) Type() PacketType {
+	return TypeTransportSpecificFeedback
+}
+
+// Format returns the feedback message type, FormatTLN.
+func (p *TransportLayerNack) Format() uint8 {
+	return FormatTLN
+}
+
 // DestinationSSRC returns an array of SSRC values that this packet refers to.
 func (p *TransportLayerNack) DestinationSSRC() []uint32 {
 	return []uint32{p.MediaSSRC}
 }
+
+// TargetsSSRC reports whether the packet is feedback about the media source
+// ssrc, its MediaSSRC.
+func (p *TransportLayerNack) TargetsSSRC(ssrc uint32) bool {
+	return p.MediaSSRC == ssrc
+}
+
+// SourceSSRC returns the SSRC of the sender.
+func (p *TransportLayerNack) SourceSSRC() []uint32 {
+	return []uint32{p.SenderSSRC}
+}
+
+// RewriteSSRC replaces every SSRC in the packet that equals from with to,
+// and returns the number of SSRCs replaced.
+func (p *TransportLayerNack) RewriteSSRC(from, to uint32) int {
+	return rewriteSSRC(&p.SenderSSRC, from, to) + rewriteSSRC(&p.MediaSSRC, from, to)
+}
+
+// Equal reports whether other is a TransportLayerNack with the same contents.
+func (p *TransportLayerNack) Equal(other Packet) bool {
+	o, ok := other.(*TransportLayerNack)
+	if !ok || o == nil {
+		return false
+	}
+
+	return p.SenderSSRC == o.SenderSSRC &&
+		p.MediaSSRC == o.MediaSSRC &&
+		slicesEqual(p.Nacks, o.Nacks)
+}
+
+// Clone returns a deep copy of the packet that shares no memory with p.
+func (p *TransportLayerNack) Clone() Packet {
+	c := *p
+	c.Nacks = cloneSlice(p.Nacks)
+
+	return &c
+}
+
+// MarshalJSON encodes the packet as a JSON object tagged with its type.
+func (p TransportLayerNack) MarshalJSON() ([]byte, error) {
+	return marshalJSON(p)
+}
+
+// UnmarshalJSON decodes a JSON object produced by MarshalJSON.
+func (p *TransportLayerNack) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, p)
+}
@@ -44,7 +44,7 @@ func TestTransportLayerNackUnmarshal(t *testing.T) {
 				0x90, 0x2f, 0x9e, 0x2e,
 				// report ends early
 			},
-			WantError: errPacketTooShort,
+			WantError: ErrPacketTooShort,
 		},
 		{
 			Name: "bad length",
@@ -56,7 +56,7 @@ func TestTransportLayerNackUnmarshal(t *testing.T) {
 				// media=0x902f9e2e
 				0x90, 0x2f, 0x9e, 0x2e,
 			},
-			WantError: errBadLength,
+			WantError: ErrBadLength,
 		},
 		{
 			Name: "wrong type",
@@ -78,12 +78,12 @@ func TestTransportLayerNackUnmarshal(t *testing.T) {
 				// delay=150137
 				0x0, 0x2, 0x4a, 0x79,
 			},
-			WantError: errWrongType,
+			WantError: ErrWrongType,
 		},
 		{
 			Name:      "nil",
 			Data:      nil,
-			WantError: errPacketTooShort,
+			WantError: ErrPacketTooShort,
 		},
 	} {
 		var tln TransportLayerNack
@@ -130,6 +130,27 @@ func TestNackPair(t *testing.T) {
 	testNackPair(t, []uint16{42, 44}, NackPair{42, 2})
 	testNackPair(t, []uint16{42, 43, 44}, NackPair{42, 3})
 	testNackPair(t, []uint16{42, 42 + 16}, NackPair{42, 0x8000})
+
+	// BLP bits past the end of the sequence number space wrap to 0
+	testNackPair(t, []uint16{65535}, NackPair{65535, 0})
+	testNackPair(t, []uint16{65534, 65535, 0, 1}, NackPair{65534, 0x7})
+	testNackPair(t, []uint16{65530, 65535, 0, 10}, NackPair{65530, 0x8030})
+}
+
+func TestTransportLayerNackPacketList(t *testing.T) {
+	nack := TransportLayerNack{
+		Nacks: []NackPair{
+			{PacketID: 100, LostPackets: 0x1},
+			{PacketID: 65533, LostPackets: 0x4005},
+		},
+	}
+	assert.Equal(t, []uint16{100, 101, 65533, 65534, 0, 12}, nack.PacketList())
+
+	assert.Empty(t, (&TransportLayerNack{}).PacketList())
+
+	// PacketList is the inverse of NewTransportLayerNack.
+	sequenceNumbers := []uint16{65500, 65520, 65535, 0, 3, 4, 200}
+	assert.Equal(t, sequenceNumbers, NewTransportLayerNack(1, 2, sequenceNumbers...).PacketList())
 }
 
 func TestNackPairRange(t *testing.T) {
@@ -186,8 +207,175 @@ func TestTransportLayerNackPairGeneration(t *testing.T) {
 				{PacketID: 500, LostPackets: 0x3},
 			},
 		},
+		{
+			"Unsorted with duplicates",
+			[]uint16{105, 100, 115, 101, 100, 105},
+			[]NackPair{
+				{PacketID: 100, LostPackets: 0x4011},
+			},
+		},
+		{
+			"Window boundary",
+			[]uint16{100, 116, 117, 133},
+			[]NackPair{
+				{PacketID: 100, LostPackets: 0x8000},
+				{PacketID: 117, LostPackets: 0x8000},
+			},
+		},
+		{
+			"Wraparound, Single NACKPair",
+			[]uint16{0, 65534, 1, 65535},
+			[]NackPair{
+				{PacketID: 65534, LostPackets: 0x7},
+			},
+		},
+		{
+			"Wraparound, Multiple NACKPair",
+			[]uint16{65530, 10, 20},
+			[]NackPair{
+				{PacketID: 65530, LostPackets: 0x8000},
+				{PacketID: 20, LostPackets: 0},
+			},
+		},
 	} {
 		actual := NackPairsFromSequenceNumbers(test.SequenceNumbers)
 		assert.Equalf(t, test.Expected, actual, "%q NackPair generation mismatch", test.Name)
 	}
 }
+
+func TestNewTransportLayerNack(t *testing.T) {
+	sequenceNumbers := []uint16{65535, 0, 2, 40}
+	nack := NewTransportLayerNack(0x902f9e2e, 0x4bc4fcb4, sequenceNumbers...)
+	assert.Equal(t, &TransportLayerNack{
+		SenderSSRC: 0x902f9e2e,
+		MediaSSRC:  0x4bc4fcb4,
+		Nacks: []NackPair{
+			{PacketID: 65535, LostPackets: 0x5},
+			{PacketID: 40, LostPackets: 0},
+		},
+	}, nack)
+	assert.Equal(t, []uint16{65535, 0, 2, 40}, sequenceNumbers, "input is not reordered")
+
+	nack = NewTransportLayerNack(0x902f9e2e, 0x4bc4fcb4, 1234)
+	assert.Equal(t, &TransportLayerNack{
+		SenderSSRC: 0x902f9e2e,
+		MediaSSRC:  0x4bc4fcb4,
+		Nacks:      []NackPair{{PacketID: 1234, LostPackets: 0}},
+	}, nack)
+	assert.Equal(t, []uint16{1234}, nack.PacketList())
+}
+
+func TestTransportLayerNackUnmarshalCraftedLength(t *testing.T) {
+	for _, test := range []struct {
+		Name      string
+		Data      []byte
+		WantError error
+	}{
+		{
+			Name: "length past buffer",
+			Data: []byte{
+				0x81, 0xcd, 0xff, 0xff,
+				0x90, 0x2f, 0x9e, 0x2e,
+				0x90, 0x2f, 0x9e, 0x2e,
+				0xaa, 0xaa, 0x55, 0x55,
+			},
+			WantError: ErrPacketTooShort,
+		},
+		{
+			Name: "no nack pairs",
+			Data: []byte{
+				0x81, 0xcd, 0x00, 0x02,
+				0x90, 0x2f, 0x9e, 0x2e,
+				0x90, 0x2f, 0x9e, 0x2e,
+			},
+			WantError: ErrBadLength,
+		},
+	} {
+		var nack TransportLayerNack
+		assert.NotPanicsf(t, func() {
+			assert.ErrorIsf(t, nack.Unmarshal(test.Data), test.WantError, "Unmarshal %q", test.Name)
+		}, "Unmarshal %q", test.Name)
+	}
+
+	// Pairs from an earlier packet must not be kept.
+	nack := TransportLayerNack{Nacks: []NackPair{{1, 0}, {2, 0}}}
+	assert.NoError(t, nack.Unmarshal([]byte{
+		0x81, 0xcd, 0x00, 0x03,
+		0x90, 0x2f, 0x9e, 0x2e,
+		0x90, 0x2f, 0x9e, 0x2e,
+		0xaa, 0xaa, 0x55, 0x55,
+	}))
+	assert.Equal(t, []NackPair{{0xaaaa, 0x5555}}, nack.Nacks)
+}
+
+func TestMergeNackPairs(t *testing.T) {
+	for _, test := range []struct {
+		Name  string
+		Lists [][]NackPair
+		Want  []NackPair
+	}{
+		{
+			Name: "none",
+			Want: []NackPair{},
+		},
+		{
+			Name: "overlapping",
+			Lists: [][]NackPair{
+				{{PacketID: 100, LostPackets: 0b101}}, // 100, 101, 103
+				{{PacketID: 101, LostPackets: 0b11}},  // 101, 102, 103
+			},
+			Want: []NackPair{{PacketID: 100, LostPackets: 0b111}},
+		},
+		{
+			Name: "adjacent windows",
+			Lists: [][]NackPair{
+				{{PacketID: 100}},
+				{{PacketID: 116}, {PacketID: 117}},
+			},
+			Want: []NackPair{
+				{PacketID: 100, LostPackets: 1 << 15},
+				{PacketID: 117},
+			},
+		},
+		{
+			Name: "out of order with wraparound",
+			Lists: [][]NackPair{
+				{{PacketID: 2}},
+				{{PacketID: 65534, LostPackets: 0b1}}, // 65534, 65535
+				{{PacketID: 0}, {PacketID: 65535}},
+			},
+			Want: []NackPair{{PacketID: 65534, LostPackets: 0b1011}},
+		},
+		{
+			Name: "full and empty pairs",
+			Lists: [][]NackPair{
+				{{PacketID: 10, LostPackets: 0xffff}},
+				{{PacketID: 26}, {PacketID: 27}},
+			},
+			Want: []NackPair{
+				{PacketID: 10, LostPackets: 0xffff},
+				{PacketID: 27},
+			},
+		},
+	} {
+		assert.Equalf(t, test.Want, MergeNackPairs(test.Lists...), "MergeNackPairs %q", test.Name)
+	}
+}
+
+func TestMergeTransportLayerNacks(t *testing.T) {
+	merged, err := MergeTransportLayerNacks(
+		NewTransportLayerNack(1, 0x902f9e2e, 5, 6, 40),
+		NewTransportLayerNack(2, 0x902f9e2e, 6, 7, 41),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, NewTransportLayerNack(1, 0x902f9e2e, 5, 6, 7, 40, 41), merged)
+
+	_, err = MergeTransportLayerNacks()
+	assert.ErrorIs(t, err, errNoNacksToMerge)
+
+	_, err = MergeTransportLayerNacks(
+		NewTransportLayerNack(1, 0x902f9e2e, 5),
+		NewTransportLayerNack(1, 0x12345678, 6),
+	)
+	assert.ErrorIs(t, err, errNackMediaSSRCMismatch)
+}
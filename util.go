@@ -41,3 +41,39 @@ func getNBitsFromByte(b byte, begin, n uint16) uint16 {
 func get24BitsFromBytes(b []byte) uint32 {
 	return uint32(b[0])<<16 + uint32(b[1])<<8 + uint32(b[2])
 }
+
+// slicesEqual reports whether a and b hold the same elements in the same
+// order. Nil and empty slices are considered equal.
+func slicesEqual[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// cloneSlice returns a copy of s backed by a new array. A nil slice stays nil.
+func cloneSlice[T any](s []T) []T {
+	if s == nil {
+		return nil
+	}
+
+	return append(make([]T, 0, len(s)), s...)
+}
+
+// rewriteSSRC sets *ssrc to to if it equals from, and returns the number of
+// SSRCs replaced.
+func rewriteSSRC(ssrc *uint32, from, to uint32) int {
+	if *ssrc != from {
+		return 0
+	}
+	*ssrc = to
+
+	return 1
+}